@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/megatherium/blunderbust/internal/config"
+)
+
+var (
+	configShowResolved bool
+	configShowProject  string
+)
+
+// configCmd groups the config inspection subcommands.
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+}
+
+// configShowCmd prints the configuration as blunderbust sees it.
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the configuration",
+	Long: `Print the global configuration file after loading, with file-based templates
+inlined.
+
+With --resolved, the project-local ` + config.ProjectConfigFile + ` of the project directory
+(--project, default: the current directory) is layered over it, as it is
+when that project is active in the TUI, and each harness, default and env
+value is annotated with the file it came from.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigShow,
+}
+
+// configValidateCmd checks the configuration without starting the TUI.
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the configuration for errors",
+	Long: `Check the global configuration file and report every problem found with its
+line and column: YAML syntax errors, unknown keys, duplicate harness names,
+templates that do not parse, defaults naming missing harnesses, models or
+agents, unreachable project directories and invalid launcher settings.
+
+Exits with a non-zero status when problems are found.`,
+	Args:          cobra.NoArgs,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE:          runConfigValidate,
+}
+
+// configSchemaCmd prints the JSON Schema of the config file.
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the configuration",
+	Long: `Print a JSON Schema of the configuration file for editor completion and
+validation. With the YAML language server, reference it from the first line
+of the config:
+
+  # yaml-language-server: $schema=./config.schema.json`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		schema, err := config.JSONSchema()
+		if err != nil {
+			return err
+		}
+		_, err = os.Stdout.Write(schema)
+		return err
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configSchemaCmd)
+	configShowCmd.Flags().BoolVar(&configShowResolved, "resolved", false, "Layer the project-local config over the global one and show where values come from")
+	configShowCmd.Flags().StringVar(&configShowProject, "project", ".", "Project directory whose "+config.ProjectConfigFile+" is layered with --resolved")
+	configCmd.AddCommand(configShowCmd)
+}
+
+func runConfigShow(_ *cobra.Command, _ []string) error {
+	cfgPath, err := filepath.Abs(resolveConfigPath())
+	if err != nil {
+		return fmt.Errorf("resolving config path: %w", err)
+	}
+	global, err := config.NewYAMLLoader().Load(cfgPath)
+	if err != nil {
+		return err
+	}
+
+	var layer *config.ProjectLayer
+	if configShowResolved {
+		projectDir, err := filepath.Abs(configShowProject)
+		if err != nil {
+			return fmt.Errorf("resolving project path: %w", err)
+		}
+		layer, err = config.LoadProjectLayer(projectDir, cfgPath)
+		if err != nil {
+			return err
+		}
+	}
+	return config.WriteResolved(os.Stdout, config.Resolve(global, cfgPath, layer))
+}
+
+func runConfigValidate(_ *cobra.Command, _ []string) error {
+	cfgPath := resolveConfigPath()
+	problems, err := config.Validate(cfgPath)
+	if err != nil {
+		return err
+	}
+	for _, p := range problems {
+		if p.Line == 0 {
+			fmt.Fprintf(os.Stdout, "%s: %s\n", cfgPath, p.Message)
+		} else {
+			fmt.Fprintf(os.Stdout, "%s:%s\n", cfgPath, p)
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%d problem(s) found in %s", len(problems), cfgPath)
+	}
+	fmt.Fprintf(os.Stdout, "%s is valid.\n", cfgPath)
+	return nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/dolt"
+	"github.com/megatherium/blunderbust/internal/data/localstate"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+var (
+	historyLimit   int
+	historyProject string
+)
+
+// historyCmd prints finished agent sessions and aggregate statistics.
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show agent session history and statistics",
+	Long: `Show finished agent sessions recorded in the agent_sessions table of the
+Beads Dolt database, or in the local state file when general.agent_state is
+"local", with runs per model, success rate, and average duration per harness.`,
+	Args: cobra.NoArgs,
+	RunE: runHistory,
+}
+
+func init() {
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 20, "Number of recent sessions to list (0 lists none)")
+	historyCmd.Flags().StringVar(&historyProject, "project", "", "Only include sessions for this project directory")
+}
+
+func runHistory(cmd *cobra.Command, _ []string) error {
+	store, closeStore, err := openHistoryStore(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeStore()
+
+	var projectDirs []string
+	if historyProject != "" {
+		dir, err := filepath.Abs(historyProject)
+		if err != nil {
+			return fmt.Errorf("resolving project path: %w", err)
+		}
+		projectDirs = append(projectDirs, dir)
+	}
+
+	sessions, err := store.ListAgentSessions(cmd.Context(), projectDirs, 0)
+	if err != nil {
+		return err
+	}
+
+	writeHistoryReport(os.Stdout, sessions, historyLimit)
+	return nil
+}
+
+// openHistoryStore opens the agent state backend selected in the config.
+func openHistoryStore(cmd *cobra.Command) (data.AgentStateStore, func(), error) {
+	cfg, err := config.NewYAMLLoader().Load(resolveConfigPath())
+	if err == nil && cfg.General != nil && cfg.General.AgentState == domain.AgentStateLocal {
+		dir, err := app.StateDir()
+		if err != nil {
+			return nil, nil, err
+		}
+		return localstate.New(filepath.Join(dir, localstate.FileName)), func() {}, nil
+	}
+
+	opts := domain.AppOptions{
+		BeadsDir: resolveBeadsPath(),
+		DSN:      dsn,
+		Debug:    debug,
+	}
+	store, err := dolt.NewStore(cmd.Context(), opts, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening beads database: %w", err)
+	}
+	return store, func() { _ = store.Close() }, nil
+}
+
+// writeHistoryReport prints aggregates followed by the limit most recent sessions.
+func writeHistoryReport(out io.Writer, sessions []domain.AgentSession, limit int) {
+	if len(sessions) == 0 {
+		fmt.Fprintln(out, "No finished agent sessions recorded.")
+		return
+	}
+
+	summary := domain.SummarizeSessions(sessions)
+	fmt.Fprintf(out, "%d runs, %.0f%% succeeded, average duration %s\n\n",
+		summary.Total.Runs, summary.Total.SuccessRate()*100, formatDuration(summary.Total.AvgDuration))
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	writeGroups(w, "MODEL", summary.ByModel)
+	fmt.Fprintln(w)
+	writeGroups(w, "HARNESS", summary.ByHarness)
+
+	if limit > 0 {
+		if limit > len(sessions) {
+			limit = len(sessions)
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "STARTED\tSTATUS\tEXIT\tDURATION\tHARNESS\tMODEL\tTICKET\tLOG")
+		for _, s := range sessions[:limit] {
+			exit := "-"
+			if s.ExitCode != nil {
+				exit = fmt.Sprintf("%d", *s.ExitCode)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				s.StartedAt.Local().Format("2006-01-02 15:04"), s.Status, exit, formatDuration(s.Duration()),
+				s.HarnessName, s.Model, s.Ticket, s.OutputLogPath)
+		}
+	}
+	_ = w.Flush()
+}
+
+func writeGroups(w io.Writer, title string, groups []domain.SessionGroupStats) {
+	fmt.Fprintf(w, "%s\tRUNS\tSUCCESS\tAVG DURATION\n", title)
+	for _, g := range groups {
+		fmt.Fprintf(w, "%s\t%d\t%.0f%%\t%s\n", g.Key, g.Runs, g.SuccessRate()*100, formatDuration(g.AvgDuration))
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return d.Round(time.Second).String()
+}
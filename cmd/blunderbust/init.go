@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/megatherium/blunderbust/internal/config"
+)
+
+var (
+	initForce       bool
+	initInteractive bool
+	initProject     string
+)
+
+// initCmd generates a starter config from the harness CLIs found on PATH.
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Generate a config for the harnesses installed on this machine",
+	Long: `Scan PATH for the harness CLIs blunderbust knows (claude, opencode, codex,
+aider, goose, ...) and write a starter config with a command_template and
+prompt_template for each one found. The git repository of the project
+directory (--project, default: the current directory) is added to the default
+workspace, and the first harness found becomes defaults.harness.
+
+The config is written to --config, or ~/.config/blunderbust/config.yaml. An
+existing config is left alone unless --force is given; then the new harnesses
+and the project are merged into it, keeping everything it already defines.
+
+With --interactive, each harness, the default harness and the project are
+confirmed first. With --dry-run, the config is printed instead of written.`,
+	Args: cobra.NoArgs,
+	RunE: runInit,
+}
+
+func init() {
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Merge into an existing config instead of refusing to touch it")
+	initCmd.Flags().BoolVarP(&initInteractive, "interactive", "i", false, "Confirm each harness, the default harness and the project")
+	initCmd.Flags().StringVar(&initProject, "project", ".", "Directory whose git repository is added as a workspace project")
+}
+
+func runInit(cmd *cobra.Command, _ []string) error {
+	out := cmd.OutOrStdout()
+	path, err := initConfigPath()
+	if err != nil {
+		return err
+	}
+	existing, err := os.ReadFile(path)
+	switch {
+	case err == nil && !initForce:
+		return fmt.Errorf("config already exists at %s (use --force to merge into it)", path)
+	case err != nil && !os.IsNotExist(err):
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	detected := config.DetectHarnesses(exec.LookPath)
+	if len(detected) == 0 {
+		return fmt.Errorf("no known harness CLI found on PATH")
+	}
+	projectDir, err := filepath.Abs(initProject)
+	if err != nil {
+		return fmt.Errorf("resolving project path: %w", err)
+	}
+	starter := config.StarterConfig{ProjectDir: repoRoot(cmd.Context(), projectDir)}
+
+	fmt.Fprintf(out, "Found %d harness(es) on PATH:\n", len(detected))
+	for _, d := range detected {
+		fmt.Fprintf(out, "  %-12s %s\n", d.Name, d.Path)
+	}
+	if initInteractive {
+		if starter, err = confirmStarter(bufio.NewReader(cmd.InOrStdin()), out, detected, starter.ProjectDir); err != nil {
+			return err
+		}
+	} else {
+		for _, d := range detected {
+			starter.Harnesses = append(starter.Harnesses, config.StarterHarness(d))
+		}
+		starter.DefaultHarness = detected[0].Name
+	}
+
+	data, added, err := config.MergeStarter(existing, filepath.Dir(path), starter)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if dryRun {
+		_, err := out.Write(data)
+		return err
+	}
+	if len(added) == 0 {
+		fmt.Fprintf(out, "Nothing to add to %s.\n", path)
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	fmt.Fprintf(out, "Wrote %s:\n", path)
+	for _, a := range added {
+		fmt.Fprintf(out, "  + %s\n", a)
+	}
+	return nil
+}
+
+// initConfigPath is --config, or the XDG config path even when it does not
+// exist yet.
+func initConfigPath() (string, error) {
+	if configPath != "" {
+		return filepath.Abs(configPath)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("finding home directory (use --config): %w", err)
+	}
+	return filepath.Join(home, ".config", "blunderbust", "config.yaml"), nil
+}
+
+// repoRoot returns the top level of the git repository containing dir, or
+// dir itself outside a repository.
+func repoRoot(ctx context.Context, dir string) string {
+	out, err := exec.CommandContext(ctx, "git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if root := strings.TrimSpace(string(out)); err == nil && root != "" {
+		return root
+	}
+	return dir
+}
+
+// confirmStarter asks which detected harnesses to include, which one is
+// the default, and whether to add the project.
+func confirmStarter(in *bufio.Reader, out io.Writer, detected []config.DetectedHarness, projectDir string) (config.StarterConfig, error) {
+	var starter config.StarterConfig
+	for _, d := range detected {
+		ok, err := askYesNo(in, out, fmt.Sprintf("Include %s?", d.Name))
+		if err != nil {
+			return starter, err
+		}
+		if ok {
+			starter.Harnesses = append(starter.Harnesses, config.StarterHarness(d))
+		}
+	}
+	if len(starter.Harnesses) == 0 {
+		return starter, fmt.Errorf("no harness selected")
+	}
+
+	names := make([]string, len(starter.Harnesses))
+	for i, h := range starter.Harnesses {
+		names[i] = h.Name
+	}
+	for starter.DefaultHarness == "" {
+		answer, err := ask(in, out, fmt.Sprintf("Default harness (%s) [%s]:", strings.Join(names, ", "), names[0]))
+		if err != nil {
+			return starter, err
+		}
+		if answer == "" {
+			answer = names[0]
+		}
+		if slices.Contains(names, answer) {
+			starter.DefaultHarness = answer
+		} else {
+			fmt.Fprintf(out, "Unknown harness %q.\n", answer)
+		}
+	}
+
+	ok, err := askYesNo(in, out, fmt.Sprintf("Add %s as a workspace project?", projectDir))
+	if err != nil {
+		return starter, err
+	}
+	if ok {
+		starter.ProjectDir = projectDir
+	}
+	return starter, nil
+}
+
+// askYesNo asks a question that defaults to yes.
+func askYesNo(in *bufio.Reader, out io.Writer, question string) (bool, error) {
+	for {
+		answer, err := ask(in, out, question+" [Y/n]")
+		if err != nil {
+			return false, err
+		}
+		switch strings.ToLower(answer) {
+		case "", "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+// ask prints question and reads one line of input.
+func ask(in *bufio.Reader, out io.Writer, question string) (string, error) {
+	fmt.Fprint(out, question+" ")
+	line, err := in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("reading answer: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
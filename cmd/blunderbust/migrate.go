@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/megatherium/blunderbust/internal/data/dolt"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// migrateCmd applies pending schema migrations to the Blunderbust-owned tables.
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply schema migrations to Blunderbust's tables in the Beads database",
+	Long: `Apply pending schema migrations to the tables Blunderbust keeps in the Beads
+Dolt database (running_agents, agent_sessions). Each migration is recorded in
+the blunderbust_schema_version table and committed to Dolt on its own.
+
+Migrations are also applied automatically when the TUI opens a database. Use
+--dry-run to list pending migrations without changing anything.`,
+	Args: cobra.NoArgs,
+	RunE: runMigrate,
+}
+
+func runMigrate(cmd *cobra.Command, _ []string) error {
+	opts := domain.AppOptions{
+		BeadsDir:       resolveBeadsPath(),
+		DSN:            dsn,
+		Debug:          debug,
+		SkipMigrations: true,
+	}
+	store, err := dolt.NewStore(cmd.Context(), opts, false)
+	if err != nil {
+		return fmt.Errorf("opening beads database: %w", err)
+	}
+	defer store.Close()
+
+	version, err := store.SchemaVersion(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		pending, err := store.PendingMigrations(cmd.Context())
+		if err != nil {
+			return err
+		}
+		writeMigrations(os.Stdout, version, pending, "pending")
+		return nil
+	}
+
+	applied, err := store.Migrate(cmd.Context())
+	writeMigrations(os.Stdout, version, applied, "applied")
+	return err
+}
+
+// writeMigrations prints the schema version followed by one line per migration.
+func writeMigrations(out io.Writer, version int, migrations []dolt.Migration, verb string) {
+	fmt.Fprintf(out, "Schema version: %d\n", version)
+	if len(migrations) == 0 {
+		fmt.Fprintf(out, "No migrations %s.\n", verb)
+		return
+	}
+	for _, m := range migrations {
+		fmt.Fprintf(out, "%s %3d  %s\n", verb, m.Version, m.Description)
+	}
+}
@@ -37,10 +37,11 @@ func runRoot(_ *cobra.Command, args []string) error {
 
 	debugLogf("Loaded %d harness(es) from config", len(cfg.Harnesses))
 	target := cfg.Launcher.Target
-	debugLogf("Launcher target: %s", target)
+	session := cfg.Launcher.Session
+	debugLogf("Launcher target: %s, session: %q", target, session)
 
 	runner := tmux.NewRealRunner()
-	l := tmux.NewTmuxLauncher(runner, dryRun, false, target)
+	l := tmux.NewTmuxLauncher(runner, dryRun, false, target, session)
 	statusChecker := tmux.NewStatusChecker(runner)
 	renderer := config.NewRenderer()
 
@@ -55,6 +56,15 @@ func runRoot(_ *cobra.Command, args []string) error {
 		AutostartDolt: cfg.General != nil && cfg.General.AutostartDolt,
 		TargetProject: targetProject,
 	}
+	if cfg.General != nil {
+		appOpts.AttentionNotify = cfg.General.AttentionNotify
+		appOpts.AgentState = cfg.General.AgentState
+		appOpts.Limits = cfg.General.Limits()
+		appOpts.CommandTimeout = cfg.General.CommandTimeout
+	}
+	if cfg.Defaults != nil {
+		appOpts.Defaults = *cfg.Defaults
+	}
 
 	application, err := app.NewApp(cfgLoader, l, statusChecker, runner, renderer, appOpts)
 	if err != nil {
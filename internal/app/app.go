@@ -8,11 +8,15 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/megatherium/blunderbust/internal/config"
 	"github.com/megatherium/blunderbust/internal/data"
 	"github.com/megatherium/blunderbust/internal/data/dolt"
 	"github.com/megatherium/blunderbust/internal/data/fake"
+	"github.com/megatherium/blunderbust/internal/data/forge"
+	"github.com/megatherium/blunderbust/internal/data/jsonl"
+	"github.com/megatherium/blunderbust/internal/data/localstate"
 	"github.com/megatherium/blunderbust/internal/discovery"
 	"github.com/megatherium/blunderbust/internal/domain"
 	"github.com/megatherium/blunderbust/internal/exec"
@@ -31,6 +35,29 @@ func ExtractRepoRoot(beadsDir string) string {
 	return repoRoot
 }
 
+// StateDir returns the directory for blunderbust's local state
+// (~/.local/state/blunderbust, or $XDG_STATE_HOME/blunderbust when set).
+func StateDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "blunderbust"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine user home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "state", "blunderbust"), nil
+}
+
+// AgentLogPath returns the path an agent's captured output is saved to when it ends.
+func AgentLogPath(launcherID string, endedAt time.Time) (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s-%s.log", strings.ReplaceAll(launcherID, "/", "_"), endedAt.Format("20060102-150405"))
+	return filepath.Join(dir, "logs", name), nil
+}
+
 // fontDetector abstracts the command execution for detecting nerd fonts.
 // This interface exists for testability and allows mocking the fc-list command.
 type fontDetector interface {
@@ -86,6 +113,9 @@ type App struct {
 	Registry      *discovery.Registry
 	Opts          domain.AppOptions
 	Fonts         FontConfig
+
+	localState     *localstate.Store
+	importsChecked map[string]bool
 }
 
 // NewApp creates a new App instance with necessary dependencies.
@@ -148,10 +178,11 @@ func (a *App) CreateProjectContext(ctx context.Context) (*data.ProjectContext, e
 
 	// Create store for the first project in workspaces config
 	firstProjectDir := a.projects[0].Dir
+	src := a.projects[0].Tickets
 	a.mu.Unlock()
 
 	beadsDir := filepath.Join(firstProjectDir, ".beads")
-	store, err := a.createStore(ctx, beadsDir)
+	store, err := a.createStore(ctx, beadsDir, src)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create store for project %s at %s: %w", firstProjectDir, beadsDir, err)
 	}
@@ -168,7 +199,7 @@ func (a *App) loadSingleProject(ctx context.Context, beadsDir string) (*data.Pro
 	if beadsDir == "" {
 		beadsDir = ".beads" // reasonable default for fallback
 	}
-	store, err := a.createStore(ctx, beadsDir)
+	store, err := a.createStore(ctx, beadsDir, a.ticketSource(ExtractRepoRoot(beadsDir)))
 	if err != nil {
 		return nil, err
 	}
@@ -186,8 +217,9 @@ func (a *App) loadSingleProject(ctx context.Context, beadsDir string) (*data.Pro
 	return a.Project(), nil
 }
 
-// createStore creates a TicketStore based on AppOptions.
-func (a *App) createStore(ctx context.Context, beadsDir string) (data.TicketStore, error) {
+// createStore creates a TicketStore based on AppOptions and the project's
+// configured ticket source src.
+func (a *App) createStore(ctx context.Context, beadsDir string, src domain.TicketSource) (data.TicketStore, error) {
 	if a.Opts.Demo {
 		if a.Opts.Debug {
 			fmt.Println("Using fake ticket store (demo mode)")
@@ -195,9 +227,37 @@ func (a *App) createStore(ctx context.Context, beadsDir string) (data.TicketStor
 		return fake.NewWithSampleData(), nil
 	}
 
+	if src.IsRemote() {
+		store, err := forge.NewStore(src)
+		if err != nil {
+			return nil, err
+		}
+		if a.Opts.Debug {
+			fmt.Printf("Reading %s issues of %s\n", src.Type, src.Repo)
+		}
+		return store, nil
+	}
+
+	// Beads projects without a Dolt database still carry the issues.jsonl export.
+	if !dolt.MetadataExists(beadsDir) && jsonl.Exists(beadsDir) {
+		store, err := jsonl.NewStore(beadsDir)
+		if err != nil {
+			return nil, err
+		}
+		if a.Opts.Debug {
+			fmt.Printf("Reading beads issues from %s\n", store.Path())
+		}
+		return store, nil
+	}
+
 	// We create a local modified AppOptions to override BeadsDir per project context
 	opts := a.Opts
 	opts.BeadsDir = beadsDir
+	// With the local agent state backend Blunderbust keeps no tables of its
+	// own in the Beads database, so there is nothing to migrate.
+	if opts.AgentState == domain.AgentStateLocal {
+		opts.SkipMigrations = true
+	}
 
 	store, err := dolt.NewStore(ctx, opts, a.Opts.AutostartDolt)
 	if err != nil {
@@ -211,9 +271,113 @@ func (a *App) createStore(ctx context.Context, beadsDir string) (data.TicketStor
 	return store, nil
 }
 
+// ticketSource returns the configured ticket source of projectDir.
+func (a *App) ticketSource(projectDir string) domain.TicketSource {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.ticketSourceLocked(projectDir)
+}
+
+// ticketSourceLocked is ticketSource for callers that hold a.mu.
+func (a *App) ticketSourceLocked(projectDir string) domain.TicketSource {
+	for _, p := range a.projects {
+		if p.Dir == projectDir {
+			return p.Tickets
+		}
+	}
+	return domain.TicketSource{}
+}
+
 // CreateStore creates a TicketStore for given beads directory.
 func (a *App) CreateStore(ctx context.Context, beadsDir string) (data.TicketStore, error) {
-	return a.createStore(ctx, beadsDir)
+	return a.createStore(ctx, beadsDir, a.ticketSource(ExtractRepoRoot(beadsDir)))
+}
+
+// AgentState returns the store that tracks running agents and session
+// history, or nil when there is none (no project, or demo mode). Projects
+// whose ticket store cannot hold agent state (issues.jsonl) use the local
+// backend.
+//
+// With the local backend, the first call for a project imports this host's
+// running agents and the session history from the project's Dolt tables.
+// The import runs once; if it fails the error is returned once and the local
+// store is used without it for the rest of the session.
+func (a *App) AgentState(ctx context.Context) (data.AgentStateStore, error) {
+	project := a.Project()
+	if project == nil || project.Store() == nil || a.Opts.Demo {
+		return nil, nil
+	}
+	if a.Opts.AgentState != domain.AgentStateLocal {
+		if store, ok := project.Store().(data.AgentStateStore); ok {
+			return store, nil
+		}
+	}
+
+	local, err := a.localAgentState()
+	if err != nil {
+		return nil, err
+	}
+	if doltStore, ok := project.Store().(*dolt.Store); ok {
+		if err := a.importAgentState(ctx, local, doltStore); err != nil {
+			return nil, err
+		}
+	}
+	return local, nil
+}
+
+// localAgentState returns the local agent state store, creating it on first use.
+func (a *App) localAgentState() (*localstate.Store, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.localState == nil {
+		dir, err := StateDir()
+		if err != nil {
+			return nil, err
+		}
+		a.localState = localstate.New(filepath.Join(dir, localstate.FileName))
+	}
+	return a.localState, nil
+}
+
+// importAgentState imports the active project's Dolt agent tables into the
+// local store, at most once per project and session.
+func (a *App) importAgentState(ctx context.Context, local *localstate.Store, src *dolt.Store) error {
+	a.mu.Lock()
+	key := "dolt:" + a.ActiveProject
+	if a.importsChecked[key] {
+		a.mu.Unlock()
+		return nil
+	}
+	if a.importsChecked == nil {
+		a.importsChecked = make(map[string]bool)
+	}
+	a.importsChecked[key] = true
+	projectDirs := make([]string, 0, len(a.projects))
+	for _, p := range a.projects {
+		projectDirs = append(projectDirs, p.Dir)
+	}
+	if len(projectDirs) == 0 {
+		projectDirs = append(projectDirs, a.ActiveProject)
+	}
+	a.mu.Unlock()
+
+	done, err := local.Imported(key)
+	if err != nil || done {
+		return err
+	}
+	exists, err := src.HasAgentState(ctx)
+	if err != nil || !exists {
+		return err
+	}
+	n, err := local.ImportFrom(ctx, key, src, projectDirs)
+	if err != nil {
+		return fmt.Errorf("failed to import agent state from the Beads database (run 'bdb migrate' if its schema is outdated): %w", err)
+	}
+	if a.Opts.Debug && n > 0 {
+		fmt.Fprintf(os.Stderr, "Imported %d agent records into %s\n", n, local.Path())
+	}
+	return nil
 }
 
 // StatusChecker returns the status checker for monitoring tmux windows.
@@ -243,6 +407,70 @@ func (a *App) GetProjects() []domain.Project {
 	return a.projects
 }
 
+// ProjectConfig returns the configured project with directory dir.
+func (a *App) ProjectConfig(dir string) (domain.Project, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for _, p := range a.projects {
+		if p.Dir == dir {
+			return p, true
+		}
+	}
+	return domain.Project{}, false
+}
+
+// Limits returns the global concurrency limits.
+func (a *App) Limits() domain.ConcurrencyLimits {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.Opts.Limits
+}
+
+// AttentionNotify returns how to notify when an agent needs attention.
+func (a *App) AttentionNotify() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.Opts.AttentionNotify
+}
+
+// CommandTimeout returns how long a hook, verify_command or context
+// provider may run.
+func (a *App) CommandTimeout() time.Duration {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.Opts.CommandTimeout <= 0 {
+		return domain.DefaultCommandTimeout
+	}
+	return a.Opts.CommandTimeout
+}
+
+// SetGeneral applies the general settings of a reloaded config: the
+// concurrency limits, the attention notification and the command timeout. It returns the keys
+// of the settings that changed but only take effect after a restart.
+func (a *App) SetGeneral(g *domain.GeneralConfig) (restart []string) {
+	if g == nil {
+		g = &domain.GeneralConfig{}
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.Opts.Limits = g.Limits()
+	a.Opts.AttentionNotify = g.AttentionNotify
+	a.Opts.CommandTimeout = g.CommandTimeout
+	if g.AgentState != a.Opts.AgentState {
+		restart = append(restart, "general.agent_state")
+	}
+	if g.AutostartDolt != a.Opts.AutostartDolt {
+		restart = append(restart, "general.autostart_dolt")
+	}
+	return restart
+}
+
+// ProjectLayer loads the project-local config of projectDir, or returns
+// nil if the project has none.
+func (a *App) ProjectLayer(projectDir string) (*config.ProjectLayer, error) {
+	return config.LoadProjectLayer(projectDir, a.Opts.ConfigPath)
+}
+
 // SetActiveProject switches the active project context, creating the store lazily if needed.
 func (a *App) SetActiveProject(ctx context.Context, projectDir string) error {
 	a.mu.Lock()
@@ -255,7 +483,7 @@ func (a *App) SetActiveProject(ctx context.Context, projectDir string) error {
 	}
 
 	beadsDir := filepath.Join(projectDir, ".beads")
-	store, err := a.createStore(ctx, beadsDir)
+	store, err := a.createStore(ctx, beadsDir, a.ticketSourceLocked(projectDir))
 	if err != nil {
 		return err
 	}
@@ -271,10 +499,11 @@ func (a *App) StoreForProject(ctx context.Context, projectDir string) (data.Tick
 		a.mu.RUnlock()
 		return store, nil
 	}
+	src := a.ticketSourceLocked(projectDir)
 	a.mu.RUnlock()
 
 	beadsDir := filepath.Join(projectDir, ".beads")
-	store, err := a.createStore(ctx, beadsDir)
+	store, err := a.createStore(ctx, beadsDir, src)
 	if err != nil {
 		return nil, err
 	}
@@ -352,6 +581,25 @@ func (a *App) AddProject(project domain.Project) {
 	a.projects = append(a.projects, project)
 }
 
+// RefreshProjects updates the prompt templates, env files and hooks of
+// known projects from a reloaded config. Projects are not added or removed: their stores and the
+// sidebar are only set up at startup.
+func (a *App) RefreshProjects(projects []domain.Project) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, p := range projects {
+		for i := range a.projects {
+			if a.projects[i].Dir == p.Dir {
+				a.projects[i].PromptTemplates = p.PromptTemplates
+				a.projects[i].EnvFile = p.EnvFile
+				a.projects[i].Hooks = p.Hooks
+				a.projects[i].Verify = p.Verify
+			}
+		}
+	}
+}
+
 // AddStore adds a store for a project directory.
 func (a *App) AddStore(projectDir string, store data.TicketStore) {
 	a.mu.Lock()
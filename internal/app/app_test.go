@@ -3,13 +3,18 @@ package app
 import (
 	"context"
 	"errors"
+	"os"
 	osexec "os/exec"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/jsonl"
 	"github.com/megatherium/blunderbust/internal/domain"
 )
 
@@ -139,3 +144,83 @@ func TestApp_SetActiveProject_CreationFailure(t *testing.T) {
 type mockStore struct {
 	data.TicketStore
 }
+
+func TestAgentLogPath(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/tmp/state")
+	ended := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	path, err := AgentLogPath("bb-1/x", ended)
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/state/blunderbust/logs/bb-1_x-20260304-050607.log", path)
+}
+
+func TestApp_CreateStore_JSONLWithoutDoltMetadata(t *testing.T) {
+	beadsDir := t.TempDir()
+	issues := `{"id":"bd-1","title":"Ready","status":"open","priority":1,"issue_type":"task"}` + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(beadsDir, jsonl.FileName), []byte(issues), 0o644))
+
+	myApp := &App{}
+	store, err := myApp.CreateStore(context.Background(), beadsDir)
+	require.NoError(t, err)
+	require.IsType(t, &jsonl.Store{}, store)
+
+	tickets, err := store.ListTickets(context.Background(), data.TicketFilter{})
+	require.NoError(t, err)
+	require.Len(t, tickets, 1)
+	assert.Equal(t, "bd-1", tickets[0].ID)
+}
+
+func TestApp_StoreForProject_ConcurrentWithRefreshProjects(t *testing.T) {
+	projectDir := t.TempDir()
+	beadsDir := filepath.Join(projectDir, ".beads")
+	require.NoError(t, os.MkdirAll(beadsDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(beadsDir, jsonl.FileName), nil, 0o644))
+
+	myApp := &App{
+		Stores:   make(map[string]data.TicketStore),
+		projects: []domain.Project{{Dir: projectDir, Name: "p"}},
+	}
+
+	// Run with -race: the ticket source is looked up while projects are
+	// refreshed from another goroutine.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			myApp.RefreshProjects([]domain.Project{{Dir: projectDir, Name: "p"}})
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		myApp.mu.Lock()
+		delete(myApp.Stores, projectDir)
+		myApp.mu.Unlock()
+		store, err := myApp.StoreForProject(context.Background(), projectDir)
+		require.NoError(t, err)
+		require.IsType(t, &jsonl.Store{}, store)
+	}
+	wg.Wait()
+}
+
+func TestApp_LaunchQueue_SaveLoad(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	myApp := &App{}
+
+	queue, err := myApp.LoadLaunchQueue()
+	require.NoError(t, err)
+	assert.Empty(t, queue)
+
+	want := []domain.QueuedLaunch{
+		{ID: 1, TicketID: "bd-1", HarnessName: "claude", Model: "opus", RenderedCommand: "claude"},
+		{ID: 2, TicketID: "bd-2", HarnessName: "codex", PreLaunch: []string{"bd sync"}},
+	}
+	require.NoError(t, myApp.SaveLaunchQueue(want))
+	queue, err = myApp.LoadLaunchQueue()
+	require.NoError(t, err)
+	assert.Equal(t, want, queue)
+
+	require.NoError(t, myApp.SaveLaunchQueue(nil))
+	path, err := launchQueuePath()
+	require.NoError(t, err)
+	assert.NoFileExists(t, path)
+}
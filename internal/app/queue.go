@@ -0,0 +1,78 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// launchQueueFile is the name of the launch queue's file in StateDir.
+const launchQueueFile = "launch_queue.json"
+
+// LoadLaunchQueue returns the launches queued by a concurrency limit when
+// bdb last exited. There is no saved queue in demo mode.
+func (a *App) LoadLaunchQueue() ([]domain.QueuedLaunch, error) {
+	if a.Opts.Demo {
+		return nil, nil
+	}
+	path, err := launchQueuePath()
+	if err != nil {
+		return nil, err
+	}
+	buf, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read launch queue: %w", err)
+	}
+	var queue []domain.QueuedLaunch
+	if err := json.Unmarshal(buf, &queue); err != nil {
+		return nil, fmt.Errorf("failed to parse launch queue %s: %w", path, err)
+	}
+	return queue, nil
+}
+
+// SaveLaunchQueue replaces the saved launch queue, removing it when empty.
+func (a *App) SaveLaunchQueue(queue []domain.QueuedLaunch) error {
+	if a.Opts.Demo {
+		return nil
+	}
+	path, err := launchQueuePath()
+	if err != nil {
+		return err
+	}
+	if len(queue) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to remove launch queue: %w", err)
+		}
+		return nil
+	}
+	buf, err := json.MarshalIndent(queue, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode launch queue: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0o600); err != nil {
+		return fmt.Errorf("failed to write launch queue: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write launch queue: %w", err)
+	}
+	return nil
+}
+
+func launchQueuePath() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, launchQueueFile), nil
+}
@@ -0,0 +1,223 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// StarterPromptTemplate is the prompt_template of generated harnesses.
+const StarterPromptTemplate = "Work on ticket {{.TicketID}}: {{.TicketTitle}}\n\n{{.TicketDescription}}"
+
+// starterHarnesses lists the harnesses of harnessBinaryAliases in the order
+// they are preferred as the default harness. Arguments follow the binary in
+// the generated command_template; %s stands for the shell-quoted prompt. Harnesses
+// without arguments take the prompt as their first argument.
+var starterHarnesses = []struct {
+	name string
+	args string
+}{
+	{"claude", `{{if .Model}} --model {{.Model}}{{end}} %s`},
+	{"opencode", `{{if .Model}} --model {{.Model}}{{end}}{{if .Agent}} --agent {{.Agent}}{{end}} --prompt %s`},
+	{"codex", `{{if .Model}} --model {{.Model}}{{end}} %s`},
+	{"gemini", `{{if .Model}} --model {{.Model}}{{end}} --prompt-interactive %s`},
+	{"aider", `{{if .Model}} --model {{.Model}}{{end}} --message %s`},
+	{"goose", ` run --text %s`},
+	{"amp", ``},
+	{"crush", ``},
+	{"cline", ``},
+	{"continue", ``},
+	{"droid", ``},
+	{"grok", ``},
+	{"interpreter", ``},
+	{"kilocode", ``},
+	{"kimi", ``},
+	{"mistral", ``},
+	{"openhands", ``},
+	{"roo", ``},
+}
+
+// DetectedHarness is a known harness whose binary was found on PATH.
+type DetectedHarness struct {
+	Name   string
+	Binary string // the executable name found, one of the harness's aliases
+	Path   string
+}
+
+// DetectHarnesses looks up the binaries of the known harnesses with
+// lookPath, usually exec.LookPath, and returns the ones found in the order
+// they are preferred as the default harness.
+func DetectHarnesses(lookPath func(string) (string, error)) []DetectedHarness {
+	var found []DetectedHarness
+	for _, starter := range starterHarnesses {
+		for _, binary := range HarnessBinaryCandidates(starter.name) {
+			if path, err := lookPath(binary); err == nil {
+				found = append(found, DetectedHarness{Name: starter.name, Binary: binary, Path: path})
+				break
+			}
+		}
+	}
+	return found
+}
+
+// StarterHarness returns the generated harness for a detected harness.
+func StarterHarness(d DetectedHarness) domain.Harness {
+	args := ` %s`
+	for _, starter := range starterHarnesses {
+		if starter.name == d.Name && starter.args != "" {
+			args = starter.args
+		}
+	}
+	return domain.Harness{
+		Name:            d.Name,
+		CommandTemplate: d.Binary + fmt.Sprintf(args, `{{shquote .Prompt}}`),
+		PromptTemplate:  StarterPromptTemplate,
+	}
+}
+
+// StarterConfig is the config generated by bdb init: the harnesses, the
+// project to add to the default workspace, and the default harness.
+type StarterConfig struct {
+	Harnesses      []domain.Harness
+	ProjectDir     string // empty to add no project
+	DefaultHarness string // empty to set no defaults
+}
+
+// MergeStarter adds the starter config to the YAML config data, which may
+// be empty, and returns the new file content. Harnesses and projects that
+// already exist are kept as they are, and defaults are only added when the
+// config has none. Comments and '@' template references in data are
+// preserved. configDir resolves relative project directories in data.
+// added lists what was added, for reporting.
+func MergeStarter(data []byte, configDir string, starter StarterConfig) (out []byte, added []string, err error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse existing config: %w", err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, HeadComment: "Generated by bdb init. See config.example.yaml for all options."}
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("existing config is not a YAML mapping")
+	}
+
+	harnesses := mappingValue(root, "harnesses", yaml.SequenceNode)
+	existing := make(map[string]bool)
+	for _, item := range harnesses.Content {
+		if name := mappingValue(item, "name", 0); name != nil {
+			existing[name.Value] = true
+		}
+	}
+	for _, h := range starter.Harnesses {
+		if existing[h.Name] {
+			continue
+		}
+		node, err := encodeNode(yamlHarness{Name: h.Name, CommandTemplate: h.CommandTemplate, PromptTemplate: h.PromptTemplate})
+		if err != nil {
+			return nil, nil, err
+		}
+		harnesses.Content = append(harnesses.Content, node)
+		added = append(added, "harness "+h.Name)
+	}
+
+	if starter.ProjectDir != "" {
+		workspace := mappingValue(mappingValue(root, "workspaces", yaml.MappingNode), "default", yaml.MappingNode)
+		projects := mappingValue(workspace, "projects", yaml.SequenceNode)
+		if !hasProject(projects, configDir, starter.ProjectDir) {
+			node, err := encodeNode(yamlProject{Dir: starter.ProjectDir})
+			if err != nil {
+				return nil, nil, err
+			}
+			projects.Content = append(projects.Content, node)
+			added = append(added, "project "+starter.ProjectDir)
+		}
+	}
+
+	if starter.DefaultHarness != "" && mappingValue(root, "defaults", 0) == nil {
+		defaults := mappingValue(root, "defaults", yaml.MappingNode)
+		defaults.Content = append(defaults.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: "harness"},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: starter.DefaultHarness})
+		added = append(added, "defaults.harness "+starter.DefaultHarness)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	return buf.Bytes(), added, nil
+}
+
+// mappingValue returns the value of key in the mapping node m. When the
+// key is missing and kind is non-zero, an empty node of that kind is added
+// under key and returned; otherwise nil is returned. A null value is
+// replaced the same way.
+func mappingValue(m *yaml.Node, key string, kind yaml.Kind) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value != key {
+			continue
+		}
+		value := m.Content[i+1]
+		if kind != 0 && value.Kind == yaml.ScalarNode && value.Tag == "!!null" {
+			*value = yaml.Node{Kind: kind}
+		}
+		return value
+	}
+	if kind == 0 {
+		return nil
+	}
+	value := &yaml.Node{Kind: kind}
+	m.Content = append(m.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	return value
+}
+
+// hasProject reports whether the projects sequence lists dir.
+func hasProject(projects *yaml.Node, configDir, dir string) bool {
+	for _, item := range projects.Content {
+		node := mappingValue(item, "dir", 0)
+		if node == nil {
+			continue
+		}
+		existing := node.Value
+		if !filepath.IsAbs(existing) {
+			existing = filepath.Join(configDir, existing)
+		}
+		if filepath.Clean(existing) == filepath.Clean(dir) {
+			return true
+		}
+	}
+	return false
+}
+
+func encodeNode(v any) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	// Multi-line templates read better as literal blocks.
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if value := node.Content[i+1]; strings.Contains(value.Value, "\n") {
+			value.Style = yaml.LiteralStyle
+		}
+	}
+	return &node, nil
+}
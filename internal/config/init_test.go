@@ -0,0 +1,133 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func TestStarterHarnesses_CoverAliases(t *testing.T) {
+	listed := make(map[string]bool)
+	for _, s := range starterHarnesses {
+		listed[s.name] = true
+	}
+	for name := range harnessBinaryAliases {
+		if !listed[name] {
+			t.Errorf("Harness %q has binary aliases but no starter template", name)
+		}
+	}
+}
+
+func TestDetectHarnesses(t *testing.T) {
+	onPath := map[string]bool{"kilo": true, "claude": true}
+	lookPath := func(binary string) (string, error) {
+		if onPath[binary] {
+			return "/usr/bin/" + binary, nil
+		}
+		return "", errors.New("not found")
+	}
+
+	found := DetectHarnesses(lookPath)
+	if len(found) != 2 {
+		t.Fatalf("Expected 2 harnesses, got %+v", found)
+	}
+	if found[0] != (DetectedHarness{Name: "claude", Binary: "claude", Path: "/usr/bin/claude"}) {
+		t.Errorf("Expected claude first, got %+v", found[0])
+	}
+	if found[1].Name != "kilocode" || found[1].Binary != "kilo" {
+		t.Errorf("Expected kilocode found by its alias, got %+v", found[1])
+	}
+
+	if got := StarterHarness(found[0]).CommandTemplate; got != `claude{{if .Model}} --model {{.Model}}{{end}} {{shquote .Prompt}}` {
+		t.Errorf("Unexpected claude command_template: %q", got)
+	}
+	if got := StarterHarness(found[1]).CommandTemplate; got != `kilo {{shquote .Prompt}}` {
+		t.Errorf("Unexpected kilocode command_template: %q", got)
+	}
+}
+
+func TestMergeStarter_NewConfig(t *testing.T) {
+	dir := t.TempDir()
+	starter := StarterConfig{
+		Harnesses:      []domain.Harness{StarterHarness(DetectedHarness{Name: "claude", Binary: "claude"})},
+		ProjectDir:     dir,
+		DefaultHarness: "claude",
+	}
+	out, added, err := MergeStarter(nil, dir, starter)
+	if err != nil {
+		t.Fatalf("MergeStarter() error = %v", err)
+	}
+	if strings.Join(added, ", ") != "harness claude, project "+dir+", defaults.harness claude" {
+		t.Errorf("Unexpected additions: %v", added)
+	}
+
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, out, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := NewYAMLLoader().Load(path)
+	if err != nil {
+		t.Fatalf("Generated config does not load: %v\n%s", err, out)
+	}
+	if cfg.Harnesses[0].PromptTemplate != StarterPromptTemplate {
+		t.Errorf("Unexpected prompt_template: %q", cfg.Harnesses[0].PromptTemplate)
+	}
+	if len(cfg.Workspace.Projects) != 1 || cfg.Workspace.Projects[0].Dir != dir {
+		t.Errorf("Expected the project in the default workspace, got %+v", cfg.Workspace.Projects)
+	}
+	if cfg.Defaults == nil || cfg.Defaults.Harness != "claude" {
+		t.Errorf("Expected defaults.harness claude, got %+v", cfg.Defaults)
+	}
+}
+
+func TestMergeStarter_ExistingConfig(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "api"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	existing := `# My harnesses
+harnesses:
+  - name: claude
+    command_template: "@./claude.txt" # kept as a file
+defaults:
+  harness: claude
+workspaces:
+  default:
+    projects:
+      - dir: ./api
+`
+	starter := StarterConfig{
+		Harnesses: []domain.Harness{
+			StarterHarness(DetectedHarness{Name: "claude", Binary: "claude"}),
+			StarterHarness(DetectedHarness{Name: "aider", Binary: "aider"}),
+		},
+		ProjectDir:     filepath.Join(dir, "api"),
+		DefaultHarness: "aider",
+	}
+	out, added, err := MergeStarter([]byte(existing), dir, starter)
+	if err != nil {
+		t.Fatalf("MergeStarter() error = %v", err)
+	}
+	if strings.Join(added, ", ") != "harness aider" {
+		t.Errorf("Expected only the new harness to be added, got %v", added)
+	}
+	got := string(out)
+	for _, want := range []string{"# My harnesses", `command_template: "@./claude.txt" # kept as a file`, "- name: aider", "harness: claude"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected merged config to contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "dir:") != 1 {
+		t.Errorf("Expected the existing project not to be added again, got:\n%s", got)
+	}
+}
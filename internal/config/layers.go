@@ -0,0 +1,357 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigFile is the project-local config file, looked up in the root
+// of the active project and layered over the global config.
+const ProjectConfigFile = ".blunderbust.yaml"
+
+// yamlProjectLayer is the raw YAML structure of a project-local config.
+type yamlProjectLayer struct {
+	Templates map[string]string `yaml:"templates,omitempty"`
+	Harnesses []yamlHarness     `yaml:"harnesses,omitempty"`
+	Env       map[string]string `yaml:"env,omitempty"`
+	Defaults  *yamlDefaults     `yaml:"defaults,omitempty"`
+}
+
+// ProjectLayer is a project-local config. Precedence over the global config:
+//
+//   - A harness replaces the global harness of the same name as a whole;
+//     other harnesses are added after the global ones. It may extend a
+//     global harness, including the one it replaces.
+//   - Templates are added to the global fragments, replacing those of the
+//     same name.
+//   - Env is added to the env of every harness, replacing keys the harness
+//     already sets.
+//   - Each non-empty field of Defaults replaces the global default.
+type ProjectLayer struct {
+	Path      string
+	Harnesses []domain.Harness
+	Env       map[string]string
+	Defaults  domain.Defaults
+
+	// Files are the layer's file and the template files it read.
+	Files []string
+}
+
+// LoadProjectLayer reads the project-local config of projectDir. The
+// fragments and harnesses of the global config at globalPath are available
+// to its templates and extends. Template files are resolved relative to
+// projectDir; prompt snippets are read from the global config's directory,
+// where they are saved. It returns nil when the project has no config file.
+func LoadProjectLayer(projectDir, globalPath string) (*ProjectLayer, error) {
+	path := filepath.Join(projectDir, ProjectConfigFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read project config %s: %w", path, err)
+	}
+
+	var raw yamlProjectLayer
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML in %s: %w", path, err)
+	}
+
+	globalDir := filepath.Dir(globalPath)
+	fragments, base, err := loadGlobalBase(globalPath)
+	if err != nil {
+		return nil, err
+	}
+	src := &templateSource{dir: projectDir, files: []string{path}, fragments: fragments}
+	if err := loadFragments(raw.Templates, src); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	// Project fragments already include the global ones they use.
+	src.fragments = mergeMaps(fragments, src.fragments, func(k string) string { return k })
+
+	l := NewYAMLLoader()
+	if err := l.validateHarnessNames(raw.Harnesses); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	harnesses, err := resolveExtends(raw.Harnesses, base)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	layer := &ProjectLayer{Path: path, Env: raw.Env}
+	for i, rawHarness := range harnesses {
+		harness, err := l.convertHarness(rawHarness, i, src)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if harness.PromptSnippets, err = LoadSnippets(globalDir, harness.Name); err != nil {
+			return nil, fmt.Errorf("%s: harness %q: %w", path, harness.Name, err)
+		}
+		layer.Harnesses = append(layer.Harnesses, *harness)
+	}
+	if raw.Defaults != nil {
+		layer.Defaults = domain.Defaults{
+			Harness: raw.Defaults.Harness,
+			Model:   raw.Defaults.Model,
+			Agent:   raw.Defaults.Agent,
+		}
+	}
+	layer.Files = src.files
+	return layer, nil
+}
+
+// loadGlobalBase reads the fragments and the resolved harnesses of the
+// global config at path for a project layer. Template files of the
+// harnesses are made absolute, so that a project harness extending one
+// still finds them. A missing global config yields neither.
+func loadGlobalBase(path string) (map[string]string, map[string]yamlHarness, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	var raw yamlConfig
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse YAML in %s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	src := &templateSource{dir: dir}
+	if err := loadFragments(raw.Templates, src); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	harnesses, err := resolveExtends(raw.Harnesses, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	base := make(map[string]yamlHarness, len(harnesses))
+	for _, h := range harnesses {
+		h.CommandTemplate = absTemplateFile(h.CommandTemplate, dir)
+		h.PromptTemplate = absTemplateFile(h.PromptTemplate, dir)
+		if h.PromptTemplates != nil {
+			templates := make(map[string]string, len(h.PromptTemplates))
+			for key, value := range h.PromptTemplates {
+				templates[key] = absTemplateFile(value, dir)
+			}
+			h.PromptTemplates = templates
+		}
+		base[h.Name] = h
+	}
+	return src.fragments, base, nil
+}
+
+// absTemplateFile makes the path of an '@' template value absolute against
+// dir. Other values are returned as is.
+func absTemplateFile(value, dir string) string {
+	file, ok := strings.CutPrefix(value, "@")
+	if !ok || filepath.IsAbs(file) {
+		return value
+	}
+	return "@" + filepath.Join(dir, file)
+}
+
+// ApplyHarnesses returns harnesses with the layer's harnesses and env
+// applied. harnesses is not modified. A nil layer returns harnesses as is.
+func (l *ProjectLayer) ApplyHarnesses(harnesses []domain.Harness) []domain.Harness {
+	if l == nil {
+		return harnesses
+	}
+	merged := make([]domain.Harness, 0, len(harnesses)+len(l.Harnesses))
+	overrides := make(map[string]domain.Harness, len(l.Harnesses))
+	for _, h := range l.Harnesses {
+		overrides[h.Name] = h
+	}
+	for _, h := range harnesses {
+		if override, ok := overrides[h.Name]; ok {
+			h = override
+			delete(overrides, h.Name)
+		}
+		merged = append(merged, h)
+	}
+	for _, h := range l.Harnesses {
+		if _, ok := overrides[h.Name]; ok {
+			merged = append(merged, h)
+		}
+	}
+
+	if len(l.Env) > 0 {
+		for i := range merged {
+			env := maps.Clone(merged[i].Env)
+			if env == nil {
+				env = make(map[string]string, len(l.Env))
+			}
+			maps.Copy(env, l.Env)
+			merged[i].Env = env
+		}
+	}
+	return merged
+}
+
+// ApplyDefaults returns defaults with each field the layer's defaults set
+// replaced. A nil layer returns defaults as is.
+func (l *ProjectLayer) ApplyDefaults(defaults domain.Defaults) domain.Defaults {
+	if l == nil {
+		return defaults
+	}
+	if l.Defaults.Harness != "" {
+		defaults.Harness = l.Defaults.Harness
+	}
+	if l.Defaults.Model != "" {
+		defaults.Model = l.Defaults.Model
+	}
+	if l.Defaults.Agent != "" {
+		defaults.Agent = l.Defaults.Agent
+	}
+	return defaults
+}
+
+// ResolvedConfig is the effective config of a project: the global config
+// with the project layer applied, and where each value came from.
+type ResolvedConfig struct {
+	Config      *domain.Config
+	GlobalPath  string
+	ProjectPath string // empty when the project has no layer
+
+	// origins maps dotted keys, e.g. "harnesses.claude.env.GOFLAGS", to the
+	// file that set them. Keys set by the global config are not recorded.
+	origins map[string]string
+}
+
+// Resolve applies layer, which may be nil, to the global config loaded from
+// globalPath. global is not modified.
+func Resolve(global *domain.Config, globalPath string, layer *ProjectLayer) *ResolvedConfig {
+	cfg := *global
+	r := &ResolvedConfig{Config: &cfg, GlobalPath: globalPath, origins: make(map[string]string)}
+	if layer == nil {
+		return r
+	}
+	r.ProjectPath = layer.Path
+
+	cfg.Harnesses = layer.ApplyHarnesses(global.Harnesses)
+	for _, h := range layer.Harnesses {
+		r.origins["harnesses."+h.Name] = layer.Path
+	}
+	for _, h := range cfg.Harnesses {
+		for key := range layer.Env {
+			r.origins["harnesses."+h.Name+".env."+key] = layer.Path
+		}
+	}
+
+	defaults := domain.Defaults{}
+	if global.Defaults != nil {
+		defaults = *global.Defaults
+	}
+	if layer.Defaults.Harness != "" {
+		defaults.Harness = layer.Defaults.Harness
+		r.origins["defaults.harness"] = layer.Path
+	}
+	if layer.Defaults.Model != "" {
+		defaults.Model = layer.Defaults.Model
+		r.origins["defaults.model"] = layer.Path
+	}
+	if layer.Defaults.Agent != "" {
+		defaults.Agent = layer.Defaults.Agent
+		r.origins["defaults.agent"] = layer.Path
+	}
+	if global.Defaults != nil || defaults != (domain.Defaults{}) {
+		cfg.Defaults = &defaults
+	}
+	return r
+}
+
+// Origin returns the file that set key, a dotted path such as
+// "defaults.model" or "harnesses.claude". A key inherits the origin of its
+// closest recorded parent, falling back to the global config.
+func (r *ResolvedConfig) Origin(key string) string {
+	for {
+		if origin, ok := r.origins[key]; ok {
+			return origin
+		}
+		i := strings.LastIndex(key, ".")
+		if i < 0 {
+			return r.GlobalPath
+		}
+		key = key[:i]
+	}
+}
+
+// WriteResolved writes the effective config as YAML. Harnesses, defaults
+// and top-level sections carry a comment naming the file they came from,
+// as do env keys set by the project layer.
+func WriteResolved(w io.Writer, r *ResolvedConfig) error {
+	var doc yaml.Node
+	if err := doc.Encode(NewYAMLLoader().domainToYAML(r.Config)); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	doc.HeadComment = "Effective config\nglobal:  " + r.GlobalPath
+	if r.ProjectPath != "" {
+		doc.HeadComment += "\nproject: " + r.ProjectPath
+	}
+
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		key, value := doc.Content[i], doc.Content[i+1]
+		switch key.Value {
+		case "harnesses":
+			for _, item := range value.Content {
+				annotateHarness(item, r)
+			}
+		case "defaults":
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				field := value.Content[j]
+				field.LineComment = "from " + r.Origin("defaults."+field.Value)
+			}
+		default:
+			key.LineComment = "from " + r.Origin(key.Value)
+		}
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return enc.Close()
+}
+
+// annotateHarness comments a harness mapping node with its origin, and its
+// env keys where they differ from it.
+func annotateHarness(item *yaml.Node, r *ResolvedConfig) {
+	fields := make(map[string]*yaml.Node)
+	var nameKey *yaml.Node
+	for j := 0; j+1 < len(item.Content); j += 2 {
+		fields[item.Content[j].Value] = item.Content[j+1]
+		if item.Content[j].Value == "name" {
+			nameKey = item.Content[j]
+		}
+	}
+	if nameKey == nil {
+		return
+	}
+	prefix := "harnesses." + fields["name"].Value
+	origin := r.Origin(prefix)
+	nameKey.LineComment = "from " + origin
+
+	env := fields["env"]
+	if env == nil {
+		return
+	}
+	for j := 0; j+1 < len(env.Content); j += 2 {
+		if keyOrigin := r.Origin(prefix + ".env." + env.Content[j].Value); keyOrigin != origin {
+			env.Content[j].LineComment = "from " + keyOrigin
+		}
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func writeProjectLayer(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ProjectConfigFile), []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write project config: %v", err)
+	}
+	return dir
+}
+
+func TestLoadProjectLayer_Missing(t *testing.T) {
+	layer, err := LoadProjectLayer(t.TempDir(), filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil || layer != nil {
+		t.Fatalf("LoadProjectLayer() = %v, %v; want nil, nil", layer, err)
+	}
+	if got := layer.ApplyHarnesses([]domain.Harness{{Name: "a"}}); len(got) != 1 {
+		t.Errorf("A nil layer should leave harnesses as is, got %v", got)
+	}
+}
+
+func TestProjectLayer_ApplyDefaults(t *testing.T) {
+	global := domain.Defaults{Harness: "claude", Model: "sonnet", Agent: "coder"}
+	var none *ProjectLayer
+	if got := none.ApplyDefaults(global); got != global {
+		t.Errorf("A nil layer should leave defaults as is, got %+v", got)
+	}
+
+	layer := &ProjectLayer{Defaults: domain.Defaults{Model: "opus"}}
+	want := domain.Defaults{Harness: "claude", Model: "opus", Agent: "coder"}
+	if got := layer.ApplyDefaults(global); got != want {
+		t.Errorf("ApplyDefaults() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadProjectLayer_Invalid(t *testing.T) {
+	dir := writeProjectLayer(t, "harnesses:\n  - name: claude\n")
+	_, err := LoadProjectLayer(dir, filepath.Join(t.TempDir(), "config.yaml"))
+	if err == nil || !strings.Contains(err.Error(), "missing required field: command_template") {
+		t.Fatalf("Expected a validation error, got: %v", err)
+	}
+}
+
+func TestResolve_ProjectLayer(t *testing.T) {
+	dir := writeProjectLayer(t, `
+harnesses:
+  - name: claude
+    command_template: "claude --project"
+  - name: aider
+    command_template: "aider"
+env:
+  GOFLAGS: -mod=mod
+defaults:
+  model: sonnet
+`)
+	layer, err := LoadProjectLayer(dir, filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatalf("LoadProjectLayer() error = %v", err)
+	}
+
+	global := &domain.Config{
+		Harnesses: []domain.Harness{
+			{Name: "opencode", CommandTemplate: "opencode", Env: map[string]string{"GOFLAGS": "-mod=vendor", "A": "1"}},
+			{Name: "claude", CommandTemplate: "claude"},
+		},
+		Defaults: &domain.Defaults{Harness: "opencode", Model: "opus"},
+	}
+	r := Resolve(global, "/home/me/config.yaml", layer)
+
+	var names []string
+	for _, h := range r.Config.Harnesses {
+		names = append(names, h.Name)
+	}
+	if strings.Join(names, ",") != "opencode,claude,aider" {
+		t.Errorf("Expected overrides in place and additions last, got %v", names)
+	}
+	if r.Config.Harnesses[1].CommandTemplate != "claude --project" {
+		t.Errorf("Expected the project harness to replace the global one, got %q", r.Config.Harnesses[1].CommandTemplate)
+	}
+	if env := r.Config.Harnesses[0].Env; env["GOFLAGS"] != "-mod=mod" || env["A"] != "1" {
+		t.Errorf("Expected project env merged over harness env, got %v", env)
+	}
+	if global.Harnesses[0].Env["GOFLAGS"] != "-mod=vendor" {
+		t.Error("Resolve must not modify the global config")
+	}
+	if *r.Config.Defaults != (domain.Defaults{Harness: "opencode", Model: "sonnet"}) {
+		t.Errorf("Unexpected defaults: %+v", *r.Config.Defaults)
+	}
+
+	layerPath := filepath.Join(dir, ProjectConfigFile)
+	origins := map[string]string{
+		"harnesses.opencode":             "/home/me/config.yaml",
+		"harnesses.opencode.env.A":       "/home/me/config.yaml",
+		"harnesses.opencode.env.GOFLAGS": layerPath,
+		"harnesses.claude":               layerPath,
+		"defaults.harness":               "/home/me/config.yaml",
+		"defaults.model":                 layerPath,
+		"launcher":                       "/home/me/config.yaml",
+	}
+	for key, want := range origins {
+		if got := r.Origin(key); got != want {
+			t.Errorf("Origin(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestLoadProjectLayer_GlobalBase(t *testing.T) {
+	globalDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(globalDir, "review.md"), []byte("Review {{.TicketID}}"), 0o644); err != nil {
+		t.Fatalf("Failed to write template file: %v", err)
+	}
+	globalPath := filepath.Join(globalDir, "config.yaml")
+	global := `templates:
+  flags: "--verbose"
+harnesses:
+  - name: claude
+    command_template: "claude {{template \"flags\"}}"
+    prompt_template: "@review.md"
+    models: [opus]
+`
+	if err := os.WriteFile(globalPath, []byte(global), 0o644); err != nil {
+		t.Fatalf("Failed to write global config: %v", err)
+	}
+	dir := writeProjectLayer(t, `templates:
+  project: "{{template \"flags\"}} --project"
+harnesses:
+  - name: claude
+    extends: claude
+    models: [sonnet]
+  - name: review
+    extends: claude
+    command_template: "claude {{template \"project\"}}"
+`)
+
+	layer, err := LoadProjectLayer(dir, globalPath)
+	if err != nil {
+		t.Fatalf("LoadProjectLayer() error = %v", err)
+	}
+	if len(layer.Harnesses) != 2 {
+		t.Fatalf("Expected 2 harnesses, got %d", len(layer.Harnesses))
+	}
+	claude, review := layer.Harnesses[0], layer.Harnesses[1]
+	if claude.CommandTemplate != "claude --verbose" {
+		t.Errorf("Expected the global fragment expanded, got %q", claude.CommandTemplate)
+	}
+	if claude.PromptTemplate != "Review {{.TicketID}}" {
+		t.Errorf("Expected the global template file read from the global dir, got %q", claude.PromptTemplate)
+	}
+	if strings.Join(claude.SupportedModels, ",") != "opus,sonnet" {
+		t.Errorf("Expected the global harness extended, got models %v", claude.SupportedModels)
+	}
+	if review.CommandTemplate != "claude --verbose --project" {
+		t.Errorf("Expected project fragments to use global ones, got %q", review.CommandTemplate)
+	}
+}
+
+func TestWriteResolved(t *testing.T) {
+	dir := writeProjectLayer(t, "env:\n  GOFLAGS: -mod=mod\ndefaults:\n  model: sonnet\n")
+	layer, err := LoadProjectLayer(dir, filepath.Join(t.TempDir(), "config.yaml"))
+	if err != nil {
+		t.Fatalf("LoadProjectLayer() error = %v", err)
+	}
+	global := &domain.Config{
+		Harnesses: []domain.Harness{{Name: "claude", CommandTemplate: "claude"}},
+		Launcher:  &domain.LauncherConfig{Target: "foreground"},
+		Defaults:  &domain.Defaults{Harness: "claude"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteResolved(&buf, Resolve(global, "/home/me/config.yaml", layer)); err != nil {
+		t.Fatalf("WriteResolved() error = %v", err)
+	}
+	out := buf.String()
+	layerPath := filepath.Join(dir, ProjectConfigFile)
+	for _, want := range []string{
+		"# project: " + layerPath,
+		"- name: claude # from /home/me/config.yaml",
+		"GOFLAGS: -mod=mod # from " + layerPath,
+		"harness: claude # from /home/me/config.yaml",
+		"model: sonnet # from " + layerPath,
+		"launcher: # from /home/me/config.yaml",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
@@ -9,11 +9,23 @@ package config
 import (
 	"bytes"
 	"fmt"
+	"slices"
+	"strings"
 	"text/template"
 
 	"github.com/megatherium/blunderbust/internal/domain"
 )
 
+// templateFuncs are the functions available in every template.
+var templateFuncs = template.FuncMap{"shquote": shellQuote}
+
+// shellQuote quotes s as a single POSIX sh word, e.g. for {{shquote .Prompt}}
+// in a command_template, so that quotes, $ and backticks in the ticket text
+// reach the harness as they are.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
 // Renderer handles template rendering for harness configurations.
 type Renderer struct{}
 
@@ -34,23 +46,56 @@ func (r *Renderer) RenderCommand(harness domain.Harness, ctx domain.TemplateCont
 }
 
 // RenderPrompt renders the prompt template for a harness with the given context.
-// If the harness has no prompt_template, returns an empty string with no error.
-// Returns the rendered prompt string or an error with context about which harness failed.
-func (r *Renderer) RenderPrompt(harness domain.Harness, ctx domain.TemplateContext) (string, error) {
-	if harness.PromptTemplate == "" {
-		return "", nil
+// The template is chosen by SelectPromptTemplate for the ticket's issue type,
+// with project's templates taking precedence over the harness's.
+// If no template matches, returns an empty string with no error.
+// Returns the rendered prompt and the name of the template it was rendered
+// from, or an error with context about which harness failed.
+func (r *Renderer) RenderPrompt(harness domain.Harness, project domain.PromptTemplates, ctx domain.TemplateContext) (string, string, error) {
+	source, tmpl := SelectPromptTemplate(harness, project, ctx.TicketIssueType)
+	if tmpl == "" {
+		return "", "", nil
 	}
-	return r.renderTemplate(
-		harness.Name,
-		"prompt_template",
-		harness.PromptTemplate,
-		ctx,
-	)
+	prompt, err := r.renderTemplate(harness.Name, source, tmpl, ctx)
+	return prompt, source, err
+}
+
+// SelectPromptTemplate returns the most specific prompt template for a
+// ticket issue type and a name describing where it came from. Candidates,
+// most specific first:
+//
+//  1. the project's prompt_templates entry for the issue type
+//  2. the harness's prompt_templates entry for the issue type
+//  3. the project's prompt_templates default entry
+//  4. the harness's prompt_template
+//
+// Both are empty when none of them is set.
+func SelectPromptTemplate(harness domain.Harness, project domain.PromptTemplates, issueType string) (string, string) {
+	if issueType != "" {
+		if tmpl := project[issueType]; tmpl != "" {
+			return "project prompt_templates." + issueType, tmpl
+		}
+		if tmpl := harness.PromptTemplates[issueType]; tmpl != "" {
+			return "prompt_templates." + issueType, tmpl
+		}
+	}
+	if tmpl := project[domain.DefaultPromptTemplateKey]; tmpl != "" {
+		return "project prompt_templates." + domain.DefaultPromptTemplateKey, tmpl
+	}
+	if harness.PromptTemplate != "" {
+		return "prompt_template", harness.PromptTemplate
+	}
+	return "", ""
+}
+
+// RenderSnippet renders a prompt snippet of a harness with the given context.
+func (r *Renderer) RenderSnippet(harness domain.Harness, snippet domain.PromptSnippet, ctx domain.TemplateContext) (string, error) {
+	return r.renderTemplate(harness.Name, "snippet "+snippet.Name, snippet.Text, ctx)
 }
 
 // renderTemplate executes a Go text/template with the given context.
 func (r *Renderer) renderTemplate(harnessName, templateName, templateStr string, ctx domain.TemplateContext) (string, error) {
-	tmpl, err := template.New(templateName).Parse(templateStr)
+	tmpl, err := template.New(templateName).Funcs(templateFuncs).Parse(templateStr)
 	if err != nil {
 		return "", fmt.Errorf(
 			"failed to parse %s for harness %q: %w",
@@ -77,29 +122,70 @@ func (r *Renderer) renderTemplate(harnessName, templateName, templateStr string,
 // Returns a LaunchSpec with all fields populated.
 // Note: Prompt is rendered before command to allow {{.Prompt}} in command templates.
 func (r *Renderer) RenderSelection(selection domain.Selection, workDir string) (*domain.LaunchSpec, error) {
-	ctx := BuildTemplateContext(selection, workDir)
+	return r.RenderSelectionContext(selection, BuildTemplateContext(selection, workDir))
+}
 
-	renderedPrompt, err := r.RenderPrompt(selection.Harness, ctx)
+// RenderSelectionContext renders a selection with a prepared template
+// context, e.g. one carrying gathered prompt context.
+func (r *Renderer) RenderSelectionContext(selection domain.Selection, ctx domain.TemplateContext) (*domain.LaunchSpec, error) {
+	renderedPrompt, source, err := r.RenderPrompt(selection.Harness, selection.ProjectPromptTemplates, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to render prompt: %w", err)
 	}
 
-	ctx.Prompt = renderedPrompt
+	spec, err := r.RenderSelectionWithPrompt(selection, ctx, renderedPrompt)
+	if err != nil {
+		return nil, err
+	}
+	spec.PromptSource = source
+	return spec, nil
+}
+
+// RenderSelectionWithPrompt renders the command for a selection with prompt
+// in place of the harness's prompt_template, e.g. after the user edited it.
+func (r *Renderer) RenderSelectionWithPrompt(selection domain.Selection, ctx domain.TemplateContext, prompt string) (*domain.LaunchSpec, error) {
+	ctx.Prompt = prompt
+	workDir := ctx.WorkDir
 
 	renderedCmd, err := r.RenderCommand(selection.Harness, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to render command: %w", err)
 	}
 
+	harness, project := selection.Harness.Hooks, selection.ProjectHooks
+	preLaunch, err := r.renderHooks(selection.Harness.Name, "pre_launch", slices.Concat(project.PreLaunch, harness.PreLaunch), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render hooks: %w", err)
+	}
+	postExit, err := r.renderHooks(selection.Harness.Name, "post_exit", slices.Concat(harness.PostExit, project.PostExit), ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render hooks: %w", err)
+	}
+
 	return &domain.LaunchSpec{
 		Selection:       selection,
 		RenderedCommand: renderedCmd,
-		RenderedPrompt:  renderedPrompt,
+		RenderedPrompt:  prompt,
 		LauncherID:      selection.Ticket.ID,
 		WorkDir:         workDir,
+		PreLaunch:       preLaunch,
+		PostExit:        postExit,
 	}, nil
 }
 
+// renderHooks renders hook commands with the given context.
+func (r *Renderer) renderHooks(harnessName, kind string, hooks []string, ctx domain.TemplateContext) ([]string, error) {
+	var rendered []string
+	for i, hook := range hooks {
+		command, err := r.renderTemplate(harnessName, fmt.Sprintf("hooks.%s[%d]", kind, i), hook, ctx)
+		if err != nil {
+			return nil, err
+		}
+		rendered = append(rendered, command)
+	}
+	return rendered, nil
+}
+
 // BuildTemplateContext creates a TemplateContext from a Selection.
 // This is the single source of truth for mapping Selection to TemplateContext.
 func BuildTemplateContext(sel domain.Selection, workDir string) domain.TemplateContext {
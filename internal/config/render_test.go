@@ -108,7 +108,7 @@ func TestRenderer_RenderPrompt_WithTemplate(t *testing.T) {
 		TicketTitle: "Fix Bug",
 	}
 
-	result, err := renderer.RenderPrompt(harness, ctx)
+	result, _, err := renderer.RenderPrompt(harness, nil, ctx)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -129,7 +129,7 @@ func TestRenderer_RenderPrompt_EmptyTemplate(t *testing.T) {
 		TicketID: "bb-123",
 	}
 
-	result, err := renderer.RenderPrompt(harness, ctx)
+	result, _, err := renderer.RenderPrompt(harness, nil, ctx)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -149,7 +149,7 @@ func TestRenderer_RenderPrompt_NoTemplateField(t *testing.T) {
 		TicketID: "bb-123",
 	}
 
-	result, err := renderer.RenderPrompt(harness, ctx)
+	result, _, err := renderer.RenderPrompt(harness, nil, ctx)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -167,7 +167,7 @@ func TestRenderer_RenderPrompt_InvalidTemplate(t *testing.T) {
 	}
 	ctx := domain.TemplateContext{}
 
-	_, err := renderer.RenderPrompt(harness, ctx)
+	_, _, err := renderer.RenderPrompt(harness, nil, ctx)
 	if err == nil {
 		t.Fatal("Expected error for invalid prompt template")
 	}
@@ -393,7 +393,7 @@ Priority: {{.TicketPriority}}`,
 		TicketPriority: 1,
 	}
 
-	result, err := renderer.RenderPrompt(harness, ctx)
+	result, _, err := renderer.RenderPrompt(harness, nil, ctx)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -426,6 +426,27 @@ func TestRenderer_Escaping(t *testing.T) {
 	}
 }
 
+func TestRenderer_ShellQuote(t *testing.T) {
+	renderer := NewRenderer()
+	harness := domain.Harness{
+		Name:            "quote",
+		CommandTemplate: "claude {{shquote .Prompt}}",
+	}
+	ctx := domain.TemplateContext{
+		Prompt: "Fix \"$(rm -rf ~)\" and it's `done`",
+	}
+
+	result, err := renderer.RenderCommand(harness, ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `claude 'Fix "$(rm -rf ~)" and it'\''s ` + "`done`'"
+	if result != expected {
+		t.Errorf("Expected %q, got %q", expected, result)
+	}
+}
+
 func TestRenderer_RenderCommand_WithPromptVariable(t *testing.T) {
 	renderer := NewRenderer()
 	now := time.Now()
@@ -595,3 +616,162 @@ func TestRenderer_RenderCommand_ModelBackwardCompatible(t *testing.T) {
 		t.Errorf("Expected %q, got %q", expected, result)
 	}
 }
+
+func TestRenderer_RenderSelectionWithPrompt(t *testing.T) {
+	renderer := NewRenderer()
+	selection := domain.Selection{
+		Ticket: domain.Ticket{ID: "bb-xyz", Title: "Test Ticket"},
+		Harness: domain.Harness{
+			Name:            "test",
+			CommandTemplate: `ai-agent --ticket {{.TicketID}} --prompt "{{.Prompt}}"`,
+			PromptTemplate:  "Fix issue {{.TicketID}}",
+		},
+	}
+
+	spec, err := renderer.RenderSelectionWithPrompt(selection, BuildTemplateContext(selection, "/work"), "Edited {{.TicketID}}")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if spec.RenderedPrompt != "Edited {{.TicketID}}" {
+		t.Errorf("Expected the edited prompt verbatim, got %q", spec.RenderedPrompt)
+	}
+	expectedCmd := `ai-agent --ticket bb-xyz --prompt "Edited {{.TicketID}}"`
+	if spec.RenderedCommand != expectedCmd {
+		t.Errorf("Expected command %q, got %q", expectedCmd, spec.RenderedCommand)
+	}
+	if spec.WorkDir != "/work" || spec.LauncherID != "bb-xyz" {
+		t.Errorf("Unexpected spec: %+v", spec)
+	}
+}
+
+func TestRenderer_RenderSelectionContext_PromptContext(t *testing.T) {
+	renderer := NewRenderer()
+	selection := domain.Selection{
+		Ticket: domain.Ticket{ID: "bb-xyz"},
+		Harness: domain.Harness{
+			Name:            "test",
+			CommandTemplate: "agent",
+			PromptTemplate:  "{{.TicketID}}{{with .ParentTicket}} in {{.ID}}{{end}}{{range .ChangedFiles}} {{.}}{{end}}",
+		},
+	}
+	ctx := BuildTemplateContext(selection, "/work")
+	ctx.ParentTicket = &domain.TicketDependency{ID: "bb-epic"}
+	ctx.ChangedFiles = []string{"a.go", "b.go"}
+
+	spec, err := renderer.RenderSelectionContext(selection, ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if spec.RenderedPrompt != "bb-xyz in bb-epic a.go b.go" {
+		t.Errorf("Unexpected prompt: %q", spec.RenderedPrompt)
+	}
+}
+
+func TestSelectPromptTemplate(t *testing.T) {
+	harness := domain.Harness{
+		PromptTemplate:  "harness default",
+		PromptTemplates: domain.PromptTemplates{"bug": "harness bug", "feature": "harness feature"},
+	}
+	project := domain.PromptTemplates{"bug": "project bug", "default": "project default"}
+
+	tests := []struct {
+		name       string
+		project    domain.PromptTemplates
+		issueType  string
+		wantSource string
+		wantTmpl   string
+	}{
+		{"project issue type", project, "bug", "project prompt_templates.bug", "project bug"},
+		{"harness issue type", project, "feature", "prompt_templates.feature", "harness feature"},
+		{"project default", project, "chore", "project prompt_templates.default", "project default"},
+		{"harness default", nil, "chore", "prompt_template", "harness default"},
+		{"no issue type", nil, "", "prompt_template", "harness default"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, tmpl := SelectPromptTemplate(harness, tt.project, tt.issueType)
+			if source != tt.wantSource || tmpl != tt.wantTmpl {
+				t.Errorf("SelectPromptTemplate() = %q, %q; want %q, %q", source, tmpl, tt.wantSource, tt.wantTmpl)
+			}
+		})
+	}
+
+	if source, tmpl := SelectPromptTemplate(domain.Harness{}, nil, "bug"); source != "" || tmpl != "" {
+		t.Errorf("Expected no template, got %q, %q", source, tmpl)
+	}
+}
+
+func TestRenderer_RenderSelection_PromptSource(t *testing.T) {
+	renderer := NewRenderer()
+	selection := domain.Selection{
+		Ticket: domain.Ticket{ID: "bb-1", IssueType: "bug"},
+		Harness: domain.Harness{
+			Name:            "test",
+			CommandTemplate: `agent "{{.Prompt}}"`,
+			PromptTemplate:  "Work on {{.TicketID}}",
+			PromptTemplates: domain.PromptTemplates{"bug": "Fix {{.TicketID}}"},
+		},
+	}
+
+	spec, err := renderer.RenderSelection(selection, "/work")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if spec.RenderedPrompt != "Fix bb-1" || spec.PromptSource != "prompt_templates.bug" {
+		t.Errorf("Unexpected prompt %q from %q", spec.RenderedPrompt, spec.PromptSource)
+	}
+	if spec.RenderedCommand != `agent "Fix bb-1"` {
+		t.Errorf("Unexpected command: %q", spec.RenderedCommand)
+	}
+}
+
+func TestRenderer_RenderSnippet(t *testing.T) {
+	renderer := NewRenderer()
+	harness := domain.Harness{Name: "test"}
+	ctx := domain.TemplateContext{TicketID: "bb-1"}
+
+	got, err := renderer.RenderSnippet(harness, domain.PromptSnippet{Name: "review", Text: "Review {{.TicketID}}"}, ctx)
+	if err != nil || got != "Review bb-1" {
+		t.Errorf("RenderSnippet() = %q, %v", got, err)
+	}
+	_, err = renderer.RenderSnippet(harness, domain.PromptSnippet{Name: "broken", Text: "{{.Nope"}, ctx)
+	if err == nil || !strings.Contains(err.Error(), "snippet broken") {
+		t.Errorf("Expected a parse error naming the snippet, got %v", err)
+	}
+}
+
+func TestRenderer_RenderSelection_Hooks(t *testing.T) {
+	selection := domain.Selection{
+		Ticket: domain.Ticket{ID: "bb-7"},
+		Harness: domain.Harness{
+			Name:            "claude",
+			CommandTemplate: "claude",
+			Hooks: domain.Hooks{
+				PreLaunch: []string{"bd sync", "npm ci --prefix {{.WorkDir}}"},
+				PostExit:  []string{"go test ./... # {{.TicketID}}"},
+			},
+		},
+		ProjectHooks: domain.Hooks{
+			PreLaunch: []string{"cp ../.env {{.WorkDir}}"},
+			PostExit:  []string{"notify-send {{.TicketID}}"},
+		},
+	}
+
+	spec, err := NewRenderer().RenderSelection(selection, "/wt")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	wantPre := []string{"cp ../.env /wt", "bd sync", "npm ci --prefix /wt"}
+	if strings.Join(spec.PreLaunch, "|") != strings.Join(wantPre, "|") {
+		t.Errorf("Expected project pre_launch hooks first, got %q", spec.PreLaunch)
+	}
+	wantPost := []string{"go test ./... # bb-7", "notify-send bb-7"}
+	if strings.Join(spec.PostExit, "|") != strings.Join(wantPost, "|") {
+		t.Errorf("Expected project post_exit hooks last, got %q", spec.PostExit)
+	}
+
+	selection.Harness.Hooks.PostExit = []string{"{{.Nope}}"}
+	if _, err := NewRenderer().RenderSelection(selection, "/wt"); err == nil || !strings.Contains(err.Error(), "hooks.post_exit[0]") {
+		t.Errorf("Expected the failing hook named, got %v", err)
+	}
+}
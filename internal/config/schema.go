@@ -0,0 +1,147 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// schemaEnums lists the accepted values of fields, by dotted path without
+// sequence indexes or map keys.
+var schemaEnums = map[string][]string{
+	"harnesses.attention.state":          {"approval", "idle", "error"},
+	"harnesses.context.provider":         contextProviderNames(),
+	"launcher.target":                    {"foreground", "background"},
+	"general.attention_notify":           {domain.AttentionNotifyNone, domain.AttentionNotifyBell, domain.AttentionNotifyDisplayMessage},
+	"general.agent_state":                {domain.AgentStateDolt, domain.AgentStateLocal},
+	"workspaces.projects.tickets.source": {domain.TicketSourceBeads, domain.TicketSourceGitHub, domain.TicketSourceGitLab},
+}
+
+// schemaDescriptions documents fields in the schema, by the same paths as
+// schemaEnums.
+var schemaDescriptions = map[string]string{
+	"harnesses":                          "Harnesses that can be launched: an AI coding CLI and how to invoke it.",
+	"harnesses.name":                     "Unique name of the harness.",
+	"harnesses.extends":                  "Name of a harness to inherit from. Templates replace the parent's, env and prompt_templates are merged, models and agents are added.",
+	"harnesses.command_template":         "Go template of the launch command; {{shquote .Prompt}} passes the prompt as one shell word. Prefix with @ to read it from a file relative to the config.",
+	"harnesses.prompt_template":          "Go template of the prompt, available as {{.Prompt}} in command_template. Prefix with @ to read it from a file.",
+	"harnesses.prompt_templates":         "Prompt templates by issue type, preferred over prompt_template for matching tickets.",
+	"harnesses.models":                   "Models to choose from. Use discover:active or provider:<id> for discovered models.",
+	"harnesses.agents":                   "Agent modes to choose from.",
+	"harnesses.env":                      "Environment variables set for the launched command. ${VAR} expands from the environment; a value starting with ! is a shell command whose output is the value.",
+	"harnesses.attention":                "Output patterns that mark an agent as needing attention.",
+	"harnesses.context":                  "Prompt context providers, as a name or a mapping with a limit.",
+	"harnesses.when":                     "Conditions for offering the harness. Harnesses for other projects or issue types are hidden; ones missing their binary or env are shown as unavailable.",
+	"harnesses.when.projects":            "Project name globs the harness is offered for.",
+	"harnesses.when.issue_types":         "Issue types the harness is offered for.",
+	"harnesses.when.installed":           "Require the harness's binary (the command's first word or a known alias) on PATH.",
+	"harnesses.when.env":                 "Environment variables that must be set.",
+	"harnesses.hooks":                    "Shell commands run around a launch, rendered like command_template and run in the work directory.",
+	"harnesses.hooks.pre_launch":         "Commands run before the launch. A failing command aborts it.",
+	"harnesses.hooks.post_exit":          "Commands run once the agent has ended, with BDB_AGENT_STATUS and BDB_EXIT_CODE set.",
+	"harnesses.max_concurrent_agents":    "Most agents of the harness running at once; further launches are queued. 0 for no limit.",
+	"templates":                          "Named template fragments, included in any harness template with {{template \"name\"}}. Prefix with @ to read one from a file.",
+	"launcher.target":                    "Where agents run: in tmux windows (foreground) or detached (background).",
+	"launcher.session":                   "tmux session agent windows are created in; 'current' uses bdb's own session.",
+	"defaults":                           "Selections made by quickdraw and blitzdraw modes.",
+	"general.autostart_dolt":             "Start a Dolt sql-server when none is running.",
+	"general.attention_notify":           "How to notify when an agent needs attention.",
+	"general.agent_state":                "Where running agents are recorded: the Beads database (dolt) or a local file.",
+	"general.max_concurrent_agents":      "Most agents running at once; further launches are queued until one ends. 0 for no limit.",
+	"general.max_concurrent_per_model":   "Most agents running at once per model, whatever the harness.",
+	"general.command_timeout":            "Longest a hook, verify_command or context provider may run, as a duration such as 90s or 10m. Default 10m.",
+	"workspaces":                         "Workspaces of projects. Only the default workspace is loaded.",
+	"workspaces.projects.dir":            "Project directory, absolute or relative to the config file.",
+	"workspaces.projects.hooks":          "Hooks run for any harness launched for the project: pre_launch before the harness's, post_exit after them.",
+	"workspaces.projects.env_file":       ".env file, relative to the project directory, whose variables are added to the env of harnesses launched for the project.",
+	"workspaces.projects.verify_command": "Shell command run in an agent's worktree once it ends, with BDB_TICKET_ID and BDB_AGENT_STATUS set. Exit status 0 passes.",
+	"workspaces.projects.verify_on_exit": "Run verify_command when an agent ends (default true); when false, only on v in the sidebar.",
+	"workspaces.projects.verify_comment": "Add the verify_command result as a comment on the agent's ticket.",
+}
+
+func contextProviderNames() []string {
+	return []string{
+		string(domain.ContextRepoDocs),
+		string(domain.ContextRelated),
+		string(domain.ContextDependencies),
+		string(domain.ContextGitLog),
+		string(domain.ContextChangedFiles),
+	}
+}
+
+// JSONSchema returns a JSON Schema of the config file, generated from the
+// YAML config types, for editor completion and validation.
+func JSONSchema() ([]byte, error) {
+	schema := typeSchema(reflect.TypeOf(yamlConfig{}), "")
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "Blunderbust configuration"
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// typeSchema returns the schema of the YAML type t found at path.
+func typeSchema(t reflect.Type, path string) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var schema map[string]any
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := make(map[string]any)
+		var required []string
+		for _, f := range yamlFields(t) {
+			properties[f.name] = typeSchema(f.typ, joinPath(path, f.name))
+			if !f.omitempty && f.typ.Kind() != reflect.Pointer {
+				required = append(required, f.name)
+			}
+		}
+		schema = map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		if t == reflect.TypeOf(yamlContextEntry{}) {
+			// A context entry may also be written as a bare provider name.
+			schema = map[string]any{"oneOf": []any{
+				map[string]any{"type": "string", "enum": schemaEnums[path+".provider"]},
+				schema,
+			}}
+		}
+	case reflect.Map:
+		schema = map[string]any{"type": "object", "additionalProperties": elemSchema(t.Elem(), path)}
+	case reflect.Slice:
+		schema = map[string]any{"type": "array", "items": elemSchema(t.Elem(), path)}
+	case reflect.Bool:
+		schema = map[string]any{"type": "boolean"}
+	case reflect.Int:
+		schema = map[string]any{"type": "integer", "minimum": 0}
+	default:
+		schema = map[string]any{"type": "string"}
+	}
+
+	if enum, ok := schemaEnums[path]; ok && t.Kind() == reflect.String {
+		schema["enum"] = enum
+	}
+	if desc, ok := schemaDescriptions[path]; ok {
+		schema["description"] = desc
+	}
+	return schema
+}
+
+// elemSchema returns the schema of the elements of a map or sequence at
+// path. The elements share the path, and its description, with their
+// container, which keeps it.
+func elemSchema(t reflect.Type, path string) map[string]any {
+	schema := typeSchema(t, path)
+	delete(schema, "description")
+	return schema
+}
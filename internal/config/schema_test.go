@@ -0,0 +1,64 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func TestJSONSchema_UpToDate(t *testing.T) {
+	schema, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+	committed, err := os.ReadFile("../../config.schema.json")
+	if err != nil {
+		t.Fatalf("Failed to read config.schema.json: %v", err)
+	}
+	if !bytes.Equal(schema, committed) {
+		t.Error("config.schema.json is out of date; run make schema")
+	}
+}
+
+func TestJSONSchema_Structure(t *testing.T) {
+	data, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+	var schema struct {
+		Required   []string `json:"required"`
+		Properties map[string]struct {
+			Items struct {
+				Required   []string                   `json:"required"`
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"items"`
+			Properties map[string]struct {
+				Enum []string `json:"enum"`
+			} `json:"properties"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Schema is not valid JSON: %v", err)
+	}
+
+	if len(schema.Required) != 1 || schema.Required[0] != "harnesses" {
+		t.Errorf("Expected harnesses to be required, got %v", schema.Required)
+	}
+	harness := schema.Properties["harnesses"].Items
+	if len(harness.Required) != 1 || harness.Required[0] != "name" {
+		t.Errorf("Expected only name to be required, as command_template may be inherited, got %v", harness.Required)
+	}
+	if _, ok := harness.Properties["prompt_templates"]; !ok {
+		t.Error("Expected prompt_templates in the harness schema")
+	}
+	if enum := schema.Properties["launcher"].Properties["target"].Enum; len(enum) != 2 {
+		t.Errorf("Expected launcher.target to be an enum, got %v", enum)
+	}
+}
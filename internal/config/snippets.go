@@ -0,0 +1,90 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// Prompt snippets are kept as one markdown file per snippet in
+// <config dir>/snippets/<harness>/, so saving one never rewrites config.yaml.
+const (
+	snippetDir = "snippets"
+	snippetExt = ".md"
+)
+
+var (
+	snippetNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+	unsafePathChars    = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+)
+
+// SnippetPath returns the file a harness's snippet is stored in.
+func SnippetPath(configDir, harness, name string) string {
+	return filepath.Join(snippetHarnessDir(configDir, harness), name+snippetExt)
+}
+
+// snippetHarnessDir returns the snippet directory of a harness. Characters
+// of the harness name that could leave the snippet directory, such as '/'
+// or a leading '.', are replaced with '_'.
+func snippetHarnessDir(configDir, harness string) string {
+	name := unsafePathChars.ReplaceAllString(harness, "_")
+	if !snippetNamePattern.MatchString(name) {
+		name = "_" + name
+	}
+	return filepath.Join(configDir, snippetDir, name)
+}
+
+// LoadSnippets reads the saved prompt snippets of a harness, sorted by name.
+// A missing snippet directory is not an error.
+func LoadSnippets(configDir, harness string) ([]domain.PromptSnippet, error) {
+	dir := snippetHarnessDir(configDir, harness)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read prompt snippets: %w", err)
+	}
+
+	var snippets []domain.PromptSnippet
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), snippetExt)
+		if e.IsDir() || !ok || !snippetNamePattern.MatchString(name) {
+			continue
+		}
+		text, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt snippet %s: %w", name, err)
+		}
+		snippets = append(snippets, domain.PromptSnippet{Name: name, Text: string(text)})
+	}
+	sort.Slice(snippets, func(i, j int) bool { return snippets[i].Name < snippets[j].Name })
+	return snippets, nil
+}
+
+// SaveSnippet writes a prompt snippet for a harness, replacing one of the
+// same name, and returns the file it was written to.
+func SaveSnippet(configDir, harness string, snippet domain.PromptSnippet) (string, error) {
+	if !snippetNamePattern.MatchString(snippet.Name) {
+		return "", fmt.Errorf("invalid snippet name %q: use letters, digits, '.', '_' and '-'", snippet.Name)
+	}
+	path := SnippetPath(configDir, harness, snippet.Name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create snippet directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(snippet.Text), 0o600); err != nil {
+		return "", fmt.Errorf("failed to save prompt snippet: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func TestSaveAndLoadSnippets(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := SaveSnippet(dir, "claude", domain.PromptSnippet{Name: "review", Text: "Review {{.TicketID}}"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if path != filepath.Join(dir, "snippets", "claude", "review.md") {
+		t.Errorf("Unexpected snippet path %q", path)
+	}
+	if _, err := SaveSnippet(dir, "claude", domain.PromptSnippet{Name: "another", Text: "First"}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if _, err := SaveSnippet(dir, "claude", domain.PromptSnippet{Name: "another", Text: "Second"}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	snippets, err := LoadSnippets(dir, "claude")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := []domain.PromptSnippet{{Name: "another", Text: "Second"}, {Name: "review", Text: "Review {{.TicketID}}"}}
+	if len(snippets) != len(want) || snippets[0] != want[0] || snippets[1] != want[1] {
+		t.Errorf("LoadSnippets() = %v, want %v", snippets, want)
+	}
+
+	if snippets, err := LoadSnippets(dir, "opencode"); err != nil || snippets != nil {
+		t.Errorf("Expected no snippets for a harness without a directory, got %v, %v", snippets, err)
+	}
+}
+
+func TestSaveSnippet_InvalidName(t *testing.T) {
+	for _, name := range []string{"", "../escape", "a/b", ".hidden"} {
+		if _, err := SaveSnippet(t.TempDir(), "claude", domain.PromptSnippet{Name: name}); err == nil {
+			t.Errorf("Expected an error for snippet name %q", name)
+		}
+	}
+}
+
+func TestSnippetPath_UnsafeHarnessName(t *testing.T) {
+	dir := t.TempDir()
+	for harness, want := range map[string]string{
+		"..":           "_..",
+		"../../escape": "_.._.._escape",
+		"team/claude":  "team_claude",
+		".hidden":      "_.hidden",
+	} {
+		if got := SnippetPath(dir, harness, "review"); got != filepath.Join(dir, "snippets", want, "review.md") {
+			t.Errorf("SnippetPath(%q) = %q, want it under snippets/%s", harness, got, want)
+		}
+	}
+}
+
+func TestYAMLLoader_Load_PromptSnippets(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("harnesses:\n  - name: claude\n    command_template: claude\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	if _, err := SaveSnippet(dir, "claude", domain.PromptSnippet{Name: "tests", Text: "Write tests"}); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	cfg, err := NewYAMLLoader().Load(configPath)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	snippets := cfg.Harnesses[0].PromptSnippets
+	if len(snippets) != 1 || snippets[0].Name != "tests" || snippets[0].Text != "Write tests" {
+		t.Errorf("Unexpected snippets: %v", snippets)
+	}
+}
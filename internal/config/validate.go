@@ -0,0 +1,464 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/megatherium/blunderbust/internal/discovery"
+	"github.com/megatherium/blunderbust/internal/exec"
+	"gopkg.in/yaml.v3"
+)
+
+// Problem is an issue found by Validate. Line and Column are 1-based, or
+// 0 when the problem is not tied to a position in the file.
+type Problem struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Line == 0 {
+		return p.Message
+	}
+	return fmt.Sprintf("%d:%d: %s", p.Line, p.Column, p.Message)
+}
+
+// validator collects the problems of one config file.
+type validator struct {
+	configDir string
+	src       *templateSource
+	problems  []Problem
+}
+
+func (v *validator) addf(node *yaml.Node, format string, args ...any) {
+	p := Problem{Message: fmt.Sprintf(format, args...)}
+	if node != nil {
+		p.Line, p.Column = node.Line, node.Column
+	}
+	v.problems = append(v.problems, p)
+}
+
+// Validate checks the config file at path and returns every problem found,
+// sorted by position: YAML syntax errors, unknown keys, duplicate harness
+// names, templates that do not parse, defaults naming missing harnesses,
+// models or agents, project directories that do not exist, and invalid
+// launcher settings. Anything else Load rejects is reported last. The
+// error is only set when the file cannot be read.
+func Validate(path string) ([]Problem, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	v := &validator{configDir: filepath.Dir(path)}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		v.addf(nil, "%v", err)
+		return v.problems, nil
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		v.addf(nil, "config must be a YAML mapping with a harnesses list")
+		return v.problems, nil
+	}
+	root := doc.Content[0]
+
+	v.checkKeys(root, reflect.TypeOf(yamlConfig{}), "")
+	var raw yamlConfig
+	if err := root.Decode(&raw); err != nil {
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			for _, msg := range typeErr.Errors {
+				v.addf(nil, "%s", strings.TrimPrefix(msg, "yaml: "))
+			}
+		} else {
+			v.addf(nil, "%v", err)
+		}
+		return v.sorted(), nil
+	}
+
+	v.src = &templateSource{dir: v.configDir}
+	if err := loadFragments(raw.Templates, v.src); err != nil {
+		v.addf(mappingValue(root, "templates", 0), "%v", err)
+	}
+	harnessesNode := fieldValue(root, "harnesses")
+	if harnesses, err := resolveExtends(raw.Harnesses, nil); err != nil {
+		var he *harnessError
+		if errors.As(err, &he) && harnessesNode != nil {
+			item := resolveAlias(harnessesNode.Content[he.index])
+			v.addf(mappingValue(item, "extends", 0), "%v", err)
+		} else {
+			v.addf(harnessesNode, "%v", err)
+		}
+	} else {
+		raw.Harnesses = harnesses
+	}
+
+	v.checkHarnesses(harnessesNode, raw.Harnesses)
+	v.checkDefaults(mappingValue(root, "defaults", 0), raw)
+	v.checkLauncher(mappingValue(root, "launcher", 0), raw.Launcher)
+	v.checkWorkspaces(fieldValue(root, "workspaces"), raw.Workspaces)
+
+	if len(v.problems) == 0 {
+		if _, err := NewYAMLLoader().Load(path); err != nil {
+			v.addf(nil, "%v", err)
+		}
+	}
+	return v.sorted(), nil
+}
+
+func (v *validator) sorted() []Problem {
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		a, b := v.problems[i], v.problems[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return v.problems
+}
+
+// checkKeys reports mapping keys that the YAML type t has no field for.
+func (v *validator) checkKeys(node *yaml.Node, t reflect.Type, path string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == mergeKey {
+				// Merged keys count as keys of this mapping.
+				for _, merged := range mergedMappings(value) {
+					v.checkKeys(merged, t, path)
+				}
+				continue
+			}
+			idx := slices.IndexFunc(fields, func(f yamlField) bool { return f.name == key.Value })
+			if idx < 0 {
+				names := make([]string, len(fields))
+				for j, f := range fields {
+					names[j] = f.name
+				}
+				v.addf(key, "unknown key %q%s (want %s)", key.Value, inPath(path), strings.Join(names, ", "))
+				continue
+			}
+			v.checkKeys(value, fields[idx].typ, joinPath(path, key.Value))
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			v.checkKeys(node.Content[i+1], t.Elem(), joinPath(path, node.Content[i].Value))
+		}
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			return
+		}
+		for i, item := range node.Content {
+			v.checkKeys(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+// checkHarnesses reports missing and duplicate names, missing command
+// templates and templates that do not parse.
+func (v *validator) checkHarnesses(node *yaml.Node, harnesses []yamlHarness) {
+	if node == nil || len(harnesses) == 0 {
+		v.addf(node, "config must define at least one harness")
+		return
+	}
+	seen := make(map[string]int)
+	for i, h := range harnesses {
+		item := resolveAlias(node.Content[i])
+		// The name may come from a merged mapping (<<: *base); the item
+		// itself stands in for its position then.
+		nameNode := mappingValue(item, "name", 0)
+		if nameNode == nil {
+			nameNode = item
+		}
+		switch {
+		case h.Name == "":
+			v.addf(item, "harness at index %d is missing required field: name", i)
+		case seen[h.Name] > 0:
+			v.addf(nameNode, "duplicate harness name %q (first defined on line %d)", h.Name, seen[h.Name])
+		default:
+			seen[h.Name] = nameNode.Line
+		}
+		label := fmt.Sprintf("harness %q", h.Name)
+		if h.Name == "" {
+			label = fmt.Sprintf("harness at index %d", i)
+		}
+
+		if h.CommandTemplate == "" && h.Extends == "" {
+			v.addf(item, "%s is missing required field: command_template", label)
+		}
+		v.checkTemplate(mappingValue(item, "command_template", 0), label+" command_template", h.CommandTemplate)
+		v.checkTemplate(mappingValue(item, "prompt_template", 0), label+" prompt_template", h.PromptTemplate)
+		v.checkPromptTemplates(mappingValue(item, "prompt_templates", 0), label)
+		v.checkHooks(mappingValue(item, "hooks", 0), label)
+		if h.MaxConcurrentAgents < 0 {
+			v.addf(mappingValue(item, "max_concurrent_agents", 0), "%s max_concurrent_agents must be 0 for no limit or more", label)
+		}
+	}
+}
+
+// checkHooks parses each command of a hooks mapping.
+func (v *validator) checkHooks(node *yaml.Node, label string) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, commands := node.Content[i], node.Content[i+1]
+		if commands.Kind != yaml.SequenceNode {
+			continue
+		}
+		for j, command := range commands.Content {
+			v.checkTemplate(command, fmt.Sprintf("%s hooks.%s[%d]", label, key.Value, j), command.Value)
+		}
+	}
+}
+
+// checkPromptTemplates parses each value of a prompt_templates mapping.
+func (v *validator) checkPromptTemplates(node *yaml.Node, label string) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		v.checkTemplate(value, fmt.Sprintf("%s prompt_templates.%s", label, key.Value), value.Value)
+	}
+}
+
+// checkTemplate loads a template value, following '@' references, and
+// parses it. Fragments it uses must exist.
+func (v *validator) checkTemplate(node *yaml.Node, label, value string) {
+	if node == nil || value == "" {
+		return
+	}
+	text, err := loadTemplateValue(value, v.src)
+	if err != nil {
+		v.addf(node, "%s: %v", label, err)
+		return
+	}
+	tmpl, err := template.New(label).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		v.addf(node, "%s does not parse: line %s", label, strings.TrimPrefix(err.Error(), "template: "+label+":"))
+		return
+	}
+	for _, m := range fragmentRef.FindAllStringSubmatch(text, -1) {
+		if tmpl.Lookup(m[2]) == nil {
+			v.addf(node, "%s uses unknown template fragment %q", label, m[2])
+		}
+	}
+}
+
+// checkDefaults reports defaults naming a harness that does not exist, or
+// a model or agent that the default harness (or, without one, any harness)
+// does not offer. Harnesses with discovered models accept any model.
+func (v *validator) checkDefaults(node *yaml.Node, raw yamlConfig) {
+	if node == nil || raw.Defaults == nil {
+		return
+	}
+	candidates := raw.Harnesses
+	if name := raw.Defaults.Harness; name != "" {
+		i := slices.IndexFunc(raw.Harnesses, func(h yamlHarness) bool { return h.Name == name })
+		if i < 0 {
+			v.addf(mappingValue(node, "harness", 0), "defaults.harness %q is not a configured harness", name)
+			return
+		}
+		candidates = raw.Harnesses[i : i+1]
+	}
+
+	if model := raw.Defaults.Model; model != "" {
+		offered := slices.ContainsFunc(candidates, func(h yamlHarness) bool {
+			return slices.Contains(h.Models, model) || slices.ContainsFunc(h.Models, isDiscoveredModels)
+		})
+		if !offered {
+			v.addf(mappingValue(node, "model", 0), "defaults.model %q is not in the models of %s", model, harnessLabel(raw.Defaults.Harness))
+		}
+	}
+	if agent := raw.Defaults.Agent; agent != "" {
+		offered := slices.ContainsFunc(candidates, func(h yamlHarness) bool { return slices.Contains(h.Agents, agent) })
+		if !offered {
+			v.addf(mappingValue(node, "agent", 0), "defaults.agent %q is not in the agents of %s", agent, harnessLabel(raw.Defaults.Harness))
+		}
+	}
+}
+
+func isDiscoveredModels(model string) bool {
+	return model == discovery.KeywordDiscoverActive || strings.HasPrefix(model, discovery.PrefixProvider)
+}
+
+func harnessLabel(name string) string {
+	if name == "" {
+		return "any harness"
+	}
+	return fmt.Sprintf("harness %q", name)
+}
+
+// checkLauncher reports invalid launcher.target and launcher.session values.
+func (v *validator) checkLauncher(node *yaml.Node, raw *yamlLauncherConfig) {
+	if node == nil || raw == nil {
+		return
+	}
+	if _, err := NewYAMLLoader().convertLauncherConfig(raw); err != nil {
+		field := mappingValue(node, "target", 0)
+		if strings.Contains(err.Error(), "launcher.session") {
+			field = mappingValue(node, "session", 0)
+		}
+		v.addf(field, "%v", err)
+	}
+}
+
+// checkWorkspaces reports workspaces other than default, which are not
+// loaded, and project directories that do not exist.
+func (v *validator) checkWorkspaces(node *yaml.Node, workspaces map[string]yamlWorkspace) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name := node.Content[i].Value
+		if name != "default" {
+			v.addf(node.Content[i], "workspace %q is ignored; only the default workspace is loaded", name)
+			continue
+		}
+		projects := fieldValue(node.Content[i+1], "projects")
+		for j, p := range workspaces[name].Projects {
+			// Projects merged in with << have no node of their own; the
+			// workspace stands in for them.
+			item := resolveAlias(node.Content[i+1])
+			if projects != nil && j < len(projects.Content) {
+				item = resolveAlias(projects.Content[j])
+			}
+			if p.Dir == "" {
+				v.addf(item, "project must specify a directory")
+				continue
+			}
+			dir := p.Dir
+			if !filepath.IsAbs(dir) {
+				dir = filepath.Join(v.configDir, dir)
+			}
+			info, err := os.Stat(dir)
+			switch {
+			case err != nil:
+				v.addf(mappingValue(item, "dir", 0), "project directory is not reachable: %v", err)
+			case !info.IsDir():
+				v.addf(mappingValue(item, "dir", 0), "project path is not a directory: %s", dir)
+			}
+			v.checkHooks(mappingValue(item, "hooks", 0), fmt.Sprintf("project %q", p.Dir))
+			if strings.TrimSpace(p.VerifyCommand) == "" {
+				for _, key := range []string{"verify_on_exit", "verify_comment"} {
+					if node := mappingValue(item, key, 0); node != nil {
+						v.addf(node, "%s has no effect without verify_command", key)
+					}
+				}
+			}
+			if p.EnvFile != "" {
+				envFile := p.EnvFile
+				if !filepath.IsAbs(envFile) {
+					envFile = filepath.Join(dir, envFile)
+				}
+				if _, err := exec.ReadEnvFile(envFile); err != nil {
+					v.addf(mappingValue(item, "env_file", 0), "%v", err)
+				}
+			}
+		}
+	}
+}
+
+// mergedMappings returns the mappings that the value of a << key merges
+// in: a single mapping or a sequence of them, possibly through aliases.
+func mergedMappings(value *yaml.Node) []*yaml.Node {
+	value = resolveAlias(value)
+	items := []*yaml.Node{value}
+	if value.Kind == yaml.SequenceNode {
+		items = value.Content
+	}
+	var merged []*yaml.Node
+	for _, item := range items {
+		if item = resolveAlias(item); item.Kind == yaml.MappingNode {
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
+
+// mergeKey is the YAML merge key, as in <<: *base.
+const mergeKey = "<<"
+
+// fieldValue returns the value of key in the mapping m, with aliases on
+// either side resolved, or nil when m is not a mapping or lacks key.
+func fieldValue(m *yaml.Node, key string) *yaml.Node {
+	if m = resolveAlias(m); m.Kind != yaml.MappingNode {
+		return nil
+	}
+	if value := mappingValue(m, key, 0); value != nil {
+		return resolveAlias(value)
+	}
+	return nil
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.AliasNode {
+		return node.Alias
+	}
+	return node
+}
+
+// yamlField is a field of a YAML config type.
+type yamlField struct {
+	name      string
+	typ       reflect.Type
+	omitempty bool
+}
+
+// yamlFields lists the fields of the struct type t by their YAML key.
+func yamlFields(t reflect.Type) []yamlField {
+	var fields []yamlField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("yaml")
+		if tag == "-" || !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields = append(fields, yamlField{name: name, typ: f.Type, omitempty: strings.Contains(opts, "omitempty")})
+	}
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func inPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	return " in " + path
+}
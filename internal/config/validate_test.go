@@ -0,0 +1,242 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func validateContent(t *testing.T, content string) []Problem {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	problems, err := Validate(path)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	return problems
+}
+
+func TestValidate_Valid(t *testing.T) {
+	problems := validateContent(t, `
+harnesses:
+  - name: claude
+    command_template: "claude --model {{.Model}}"
+    models: [discover:active]
+    context: [docs, {provider: git_log, limit: 5}]
+defaults:
+  harness: claude
+  model: sonnet
+`)
+	if len(problems) != 0 {
+		t.Errorf("Expected no problems, got %v", problems)
+	}
+}
+
+func TestValidate_Problems(t *testing.T) {
+	problems := validateContent(t, `harnesses:
+  - name: claude
+    command_template: "claude {{.Model"
+    promt_template: hi
+    models: [sonnet]
+  - name: claude
+    command_template: claude
+    prompt_templates:
+      bug: "@missing.md"
+launcher:
+  target: sideways
+defaults:
+  harness: claude
+  model: opus
+  agent: coder
+workspaces:
+  default:
+    projects:
+      - dir: ./nope
+        env_file: .env
+  other:
+    projects: []
+`)
+	want := []string{
+		`3:23: harness "claude" command_template does not parse: line 1: unclosed action`,
+		`4:5: unknown key "promt_template" in harnesses[0]`,
+		`6:11: duplicate harness name "claude" (first defined on line 2)`,
+		`9:12: harness "claude" prompt_templates.bug: failed to load template file: @missing.md (file not found)`,
+		`11:11: invalid launcher.target value: "sideways"`,
+		`14:10: defaults.model "opus" is not in the models of harness "claude"`,
+		`15:10: defaults.agent "coder" is not in the agents of harness "claude"`,
+		`19:14: project directory is not reachable`,
+		`20:19: failed to read env file`,
+		`21:3: workspace "other" is ignored`,
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("Expected %d problems, got %d: %v", len(want), len(problems), problems)
+	}
+	for i, w := range want {
+		if got := problems[i].String(); !strings.HasPrefix(got, w) {
+			t.Errorf("Problem %d = %q, want prefix %q", i, got, w)
+		}
+	}
+}
+
+func TestValidate_DefaultHarnessMissing(t *testing.T) {
+	problems := validateContent(t, "harnesses:\n  - name: claude\n    command_template: claude\ndefaults:\n  harness: aider\n")
+	if len(problems) != 1 || problems[0].String() != `5:12: defaults.harness "aider" is not a configured harness` {
+		t.Errorf("Unexpected problems: %v", problems)
+	}
+}
+
+func TestValidate_SyntaxError(t *testing.T) {
+	problems := validateContent(t, "harnesses:\n  - name: [\n")
+	if len(problems) != 1 || !strings.Contains(problems[0].Message, "line") {
+		t.Errorf("Expected one syntax error with its line, got %v", problems)
+	}
+}
+
+func TestValidate_LoadErrors(t *testing.T) {
+	problems := validateContent(t, "harnesses:\n  - name: claude\n    command_template: claude\ngeneral:\n  agent_state: cloud\n")
+	if len(problems) != 1 || !strings.Contains(problems[0].Message, "invalid general.agent_state") {
+		t.Errorf("Expected the loader error to be reported, got %v", problems)
+	}
+}
+
+func TestValidate_ExtendsAndFragments(t *testing.T) {
+	problems := validateContent(t, `templates:
+  ticket: "{{.TicketID}}"
+harnesses:
+  - name: claude
+    command_template: claude
+    prompt_template: "Work on {{template \"ticket\"}}"
+  - name: claude-opus
+    extends: claude
+  - name: aider-fast
+    extends: aider
+`)
+	want := []string{
+		`10:14: harness "aider-fast" extends unknown harness "aider"`,
+	}
+	if len(problems) != len(want) || problems[0].String() != want[0] {
+		t.Fatalf("Expected %v, got %v", want, problems)
+	}
+
+	problems = validateContent(t, `templates:
+  ticket: "{{.TicketID}}"
+harnesses:
+  - name: claude
+    command_template: claude
+    prompt_template: "Work on {{template \"ticket\"}} {{template \"rules\"}}"
+  - name: claude-opus
+    extends: claude
+`)
+	if len(problems) != 1 || problems[0].String() != `6:22: harness "claude" prompt_template uses unknown template fragment "rules"` {
+		t.Errorf("Expected the unknown fragment reported once, where it is written, got %v", problems)
+	}
+}
+
+func TestValidate_VerifyWithoutCommand(t *testing.T) {
+	problems := validateContent(t, `harnesses:
+  - name: claude
+    command_template: claude
+workspaces:
+  default:
+    projects:
+      - dir: .
+        verify_comment: true
+`)
+	if len(problems) != 1 || problems[0].String() != "8:25: verify_comment has no effect without verify_command" {
+		t.Errorf("Expected the stray verify_comment reported, got %v", problems)
+	}
+}
+
+func TestValidate_NegativeHarnessLimit(t *testing.T) {
+	problems := validateContent(t, `harnesses:
+  - name: claude
+    command_template: claude
+    max_concurrent_agents: -1
+`)
+	if len(problems) != 1 || problems[0].String() != `4:28: harness "claude" max_concurrent_agents must be 0 for no limit or more` {
+		t.Errorf("Expected the negative limit reported, got %v", problems)
+	}
+}
+
+func TestValidate_Hooks(t *testing.T) {
+	problems := validateContent(t, `harnesses:
+  - name: claude
+    command_template: claude
+    hooks:
+      pre_launch: [bd sync]
+      post_exit: ["notify {{.TicketID"]
+`)
+	if len(problems) != 1 || !strings.HasPrefix(problems[0].String(), `6:19: harness "claude" hooks.post_exit[0] does not parse`) {
+		t.Errorf("Expected the broken hook reported, got %v", problems)
+	}
+}
+
+func TestValidate_MergedHarnessName(t *testing.T) {
+	problems := validateContent(t, `x-base: &base
+  name: claude
+  command_template: claude
+harnesses:
+  - <<: *base
+    models: [sonnet]
+  - <<: *base
+`)
+	for _, p := range problems {
+		if strings.Contains(p.Message, "missing required field") {
+			t.Errorf("Unexpected problem: %v", p)
+		}
+	}
+	want := `7:5: duplicate harness name "claude" (first defined on line 5)`
+	if !slices.ContainsFunc(problems, func(p Problem) bool { return p.String() == want }) {
+		t.Errorf("Expected %q, got %v", want, problems)
+	}
+}
+
+func TestValidate_AliasedSequences(t *testing.T) {
+	problems := validateContent(t, `x-harnesses: &list
+  - name: claude
+    command_template: claude
+  - name: claude
+    command_template: claude
+x-projects: &projects
+  - dir: ./nope
+harnesses: *list
+workspaces:
+  default:
+    projects: *projects
+`)
+	for _, want := range []string{
+		`4:11: duplicate harness name "claude" (first defined on line 2)`,
+		`7:10: project directory is not reachable`,
+	} {
+		if !slices.ContainsFunc(problems, func(p Problem) bool { return strings.HasPrefix(p.String(), want) }) {
+			t.Errorf("Expected %q, got %v", want, problems)
+		}
+	}
+}
+
+func TestValidate_MergeKey(t *testing.T) {
+	problems := validateContent(t, `harnesses:
+  - &base
+    name: claude
+    command_template: claude
+  - <<: *base
+    name: opus
+  - <<: [{modles: [sonnet]}]
+    name: sonnet
+    command_template: claude
+`)
+	if len(problems) != 1 || !strings.HasPrefix(problems[0].String(), `7:11: unknown key "modles" in harnesses[2]`) {
+		t.Errorf("Expected only the misspelled merged key, got %v", problems)
+	}
+}
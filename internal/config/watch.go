@@ -0,0 +1,42 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"maps"
+	"os"
+)
+
+// FileStamp identifies the version of a file for change detection. A file
+// that does not exist has the zero stamp.
+type FileStamp struct {
+	ModTime int64 // UnixNano
+	Size    int64
+}
+
+// StatFiles stamps each of paths.
+func StatFiles(paths []string) map[string]FileStamp {
+	stamps := make(map[string]FileStamp, len(paths))
+	for _, path := range paths {
+		var stamp FileStamp
+		if info, err := os.Stat(path); err == nil {
+			stamp = FileStamp{ModTime: info.ModTime().UnixNano(), Size: info.Size()}
+		}
+		stamps[path] = stamp
+	}
+	return stamps
+}
+
+// FilesChanged reports whether any of the stamped files was written,
+// created or removed since stamps were taken.
+func FilesChanged(stamps map[string]FileStamp) bool {
+	paths := make([]string, 0, len(stamps))
+	for path := range stamps {
+		paths = append(paths, path)
+	}
+	return !maps.Equal(stamps, StatFiles(paths))
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFilesChanged(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "prompt.md")
+	missing := filepath.Join(dir, "later.md")
+	if err := os.WriteFile(path, []byte("v1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stamps := StatFiles([]string{path, missing})
+	if stamps[missing] != (FileStamp{}) {
+		t.Errorf("Expected a missing file to have the zero stamp, got %+v", stamps[missing])
+	}
+	if FilesChanged(stamps) {
+		t.Error("Expected no change right after stamping")
+	}
+
+	if err := os.WriteFile(path, []byte("version 2"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if !FilesChanged(stamps) {
+		t.Error("Expected a rewritten file to be detected")
+	}
+
+	stamps = StatFiles([]string{path, missing})
+	if err := os.WriteFile(missing, []byte("new"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if !FilesChanged(stamps) {
+		t.Error("Expected a created file to be detected")
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// convertWhen validates a harness's when: conditions.
+func convertWhen(raw *yamlWhen) (domain.HarnessConditions, error) {
+	if raw == nil {
+		return domain.HarnessConditions{}, nil
+	}
+	for _, pattern := range raw.Projects {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return domain.HarnessConditions{}, fmt.Errorf("invalid when.projects pattern %q: %w", pattern, err)
+		}
+	}
+	var issueTypes []string
+	for _, t := range raw.IssueTypes {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			issueTypes = append(issueTypes, t)
+		}
+	}
+	return domain.HarnessConditions{
+		Projects:   raw.Projects,
+		IssueTypes: issueTypes,
+		Installed:  raw.Installed,
+		Env:        raw.Env,
+	}, nil
+}
+
+// WhenFacts are what the when: conditions of a harness are checked against.
+// Empty Project and IssueType are not known yet and match any condition.
+type WhenFacts struct {
+	Project   string
+	IssueType string
+
+	// LookPath and LookupEnv default to exec.LookPath and os.LookupEnv.
+	LookPath  func(string) (string, error)
+	LookupEnv func(string) (string, bool)
+}
+
+// CheckWhen checks the when: conditions of h. A harness for another project
+// or issue type is hidden; a harness whose binary or env is missing is
+// shown but unavailable. reason says which condition failed, and is empty
+// when the harness can be launched.
+func CheckWhen(h domain.Harness, facts WhenFacts) (hidden bool, reason string) {
+	when := h.When
+	if facts.Project != "" && len(when.Projects) > 0 && !matchesAnyGlob(facts.Project, when.Projects) {
+		return true, fmt.Sprintf("only for projects %s", strings.Join(when.Projects, ", "))
+	}
+	issueType := strings.ToLower(facts.IssueType)
+	if issueType != "" && len(when.IssueTypes) > 0 && !slices.Contains(when.IssueTypes, issueType) {
+		return true, fmt.Sprintf("only for %s tickets", strings.Join(when.IssueTypes, ", "))
+	}
+
+	if when.Installed {
+		lookPath := facts.LookPath
+		if lookPath == nil {
+			lookPath = exec.LookPath
+		}
+		binaries := harnessBinaries(h)
+		installed := false
+		for _, binary := range binaries {
+			if _, err := lookPath(binary); err == nil {
+				installed = true
+				break
+			}
+		}
+		if !installed {
+			return false, fmt.Sprintf("%s not installed", strings.Join(binaries, " or "))
+		}
+	}
+
+	lookupEnv := facts.LookupEnv
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+	for _, name := range when.Env {
+		if v, ok := lookupEnv(name); !ok || v == "" {
+			return false, fmt.Sprintf("$%s not set", name)
+		}
+	}
+	return false, ""
+}
+
+// harnessBinaries returns the executables that satisfy when.installed: the
+// leading word of the command template, then the binary candidates of the
+// harness name.
+func harnessBinaries(h domain.Harness) []string {
+	var binaries []string
+	command, _, _ := strings.Cut(h.CommandTemplate, "{{")
+	if fields := strings.Fields(command); len(fields) > 0 {
+		binaries = append(binaries, fields[0])
+	}
+	for _, candidate := range HarnessBinaryCandidates(h.Name) {
+		if !slices.Contains(binaries, candidate) {
+			binaries = append(binaries, candidate)
+		}
+	}
+	return binaries
+}
+
+func matchesAnyGlob(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func TestCheckWhen(t *testing.T) {
+	facts := WhenFacts{
+		Project:   "web-app",
+		IssueType: "Bug",
+		LookPath: func(binary string) (string, error) {
+			if binary == "claude" {
+				return "/usr/bin/claude", nil
+			}
+			return "", errors.New("not found")
+		},
+		LookupEnv: func(name string) (string, bool) {
+			if name == "OPENAI_API_KEY" {
+				return "sk-1", true
+			}
+			return "", name == "EMPTY"
+		},
+	}
+
+	tests := []struct {
+		name       string
+		harness    domain.Harness
+		facts      WhenFacts
+		wantHidden bool
+		wantReason string
+	}{
+		{"no conditions", domain.Harness{Name: "x"}, facts, false, ""},
+		{"project glob matches", domain.Harness{When: domain.HarnessConditions{Projects: []string{"api", "web-*"}}}, facts, false, ""},
+		{"project glob fails", domain.Harness{When: domain.HarnessConditions{Projects: []string{"api"}}}, facts, true, "only for projects api"},
+		{"issue type matches", domain.Harness{When: domain.HarnessConditions{IssueTypes: []string{"bug"}}}, facts, false, ""},
+		{"issue type fails", domain.Harness{When: domain.HarnessConditions{IssueTypes: []string{"feature", "epic"}}}, facts, true, "only for feature, epic tickets"},
+		{"unknown ticket matches", domain.Harness{When: domain.HarnessConditions{IssueTypes: []string{"feature"}}}, WhenFacts{}, false, ""},
+		{
+			"installed by name",
+			domain.Harness{Name: "claude", CommandTemplate: "{{.Prompt}}", When: domain.HarnessConditions{Installed: true}},
+			facts, false, "",
+		},
+		{
+			"installed by command",
+			domain.Harness{Name: "claude-opus", CommandTemplate: "claude{{if .Model}} --model {{.Model}}{{end}}", When: domain.HarnessConditions{Installed: true}},
+			facts, false, "",
+		},
+		{
+			"not installed",
+			domain.Harness{Name: "kilocode", CommandTemplate: "kilo", When: domain.HarnessConditions{Installed: true}},
+			facts, false, "kilo or kilocode or kilocode-cli not installed",
+		},
+		{"env set", domain.Harness{When: domain.HarnessConditions{Env: []string{"OPENAI_API_KEY"}}}, facts, false, ""},
+		{"env empty", domain.Harness{When: domain.HarnessConditions{Env: []string{"OPENAI_API_KEY", "EMPTY"}}}, facts, false, "$EMPTY not set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hidden, reason := CheckWhen(tt.harness, tt.facts)
+			if hidden != tt.wantHidden || reason != tt.wantReason {
+				t.Errorf("CheckWhen() = %v, %q, want %v, %q", hidden, reason, tt.wantHidden, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestYAMLLoader_Load_When(t *testing.T) {
+	configPath := writeTestConfig(t, t.TempDir(), `
+harnesses:
+  - name: aider
+    command_template: aider
+    when:
+      projects: [api, "web-*"]
+      issue_types: [Bug, " feature"]
+      installed: true
+      env: [OPENAI_API_KEY]
+  - name: aider-fast
+    extends: aider
+`)
+	cfg, err := NewYAMLLoader().Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	when := cfg.Harnesses[0].When
+	if strings.Join(when.Projects, ",") != "api,web-*" || strings.Join(when.IssueTypes, ",") != "bug,feature" ||
+		!when.Installed || strings.Join(when.Env, ",") != "OPENAI_API_KEY" {
+		t.Errorf("Unexpected conditions: %+v", when)
+	}
+	if !cfg.Harnesses[1].When.Installed {
+		t.Errorf("Expected the conditions inherited, got %+v", cfg.Harnesses[1].When)
+	}
+
+	configPath = writeTestConfig(t, t.TempDir(), "harnesses:\n  - name: aider\n    command_template: aider\n    when:\n      projects: [\"[api\"]\n")
+	if _, err := NewYAMLLoader().Load(configPath); err == nil || !strings.Contains(err.Error(), `invalid when.projects pattern "[api"`) {
+		t.Errorf("Expected an invalid pattern error, got %v", err)
+	}
+}
@@ -6,9 +6,12 @@
 
 package config
 
+import "gopkg.in/yaml.v3"
+
 // yamlConfig is the raw YAML structure for unmarshaling.
 type yamlConfig struct {
 	Harnesses  []yamlHarness            `yaml:"harnesses"`
+	Templates  map[string]string        `yaml:"templates,omitempty"`
 	Launcher   *yamlLauncherConfig      `yaml:"launcher,omitempty"`
 	Defaults   *yamlDefaults            `yaml:"defaults,omitempty"`
 	General    *yamlGeneralConfig       `yaml:"general,omitempty"`
@@ -20,23 +23,93 @@ type yamlWorkspace struct {
 }
 
 type yamlProject struct {
-	Dir  string `yaml:"dir"`
-	Name string `yaml:"name,omitempty"`
+	Dir             string            `yaml:"dir"`
+	Name            string            `yaml:"name,omitempty"`
+	Tickets         *yamlTicketSource `yaml:"tickets,omitempty"`
+	PromptTemplates map[string]string `yaml:"prompt_templates,omitempty"`
+	EnvFile         string            `yaml:"env_file,omitempty"`
+	Hooks           *yamlHooks        `yaml:"hooks,omitempty"`
+	VerifyCommand   string            `yaml:"verify_command,omitempty"`
+	VerifyOnExit    *bool             `yaml:"verify_on_exit,omitempty"`
+	VerifyComment   bool              `yaml:"verify_comment,omitempty"`
+}
+
+// yamlTicketSource is the raw YAML structure for a project's ticket source.
+type yamlTicketSource struct {
+	Source   string `yaml:"source"`
+	Repo     string `yaml:"repo,omitempty"`
+	URL      string `yaml:"url,omitempty"`
+	TokenEnv string `yaml:"token_env,omitempty"`
 }
 
+const (
+	// DefaultLauncherSession is the dedicated tmux session agent windows are
+	// created in when launcher.session is not configured.
+	DefaultLauncherSession = "blunderbust"
+	// CurrentLauncherSession is the launcher.session value that opts out of a
+	// dedicated session and creates windows in bdb's own session.
+	CurrentLauncherSession = "current"
+)
+
 // yamlLauncherConfig is the raw YAML structure for launcher configuration.
 type yamlLauncherConfig struct {
-	Target string `yaml:"target,omitempty"`
+	Target  string `yaml:"target,omitempty"`
+	Session string `yaml:"session,omitempty"`
 }
 
 // yamlHarness is the raw YAML structure for a harness definition.
 type yamlHarness struct {
-	Name            string            `yaml:"name"`
-	CommandTemplate string            `yaml:"command_template"`
-	PromptTemplate  string            `yaml:"prompt_template,omitempty"`
-	Models          []string          `yaml:"models,omitempty"`
-	Agents          []string          `yaml:"agents,omitempty"`
-	Env             map[string]string `yaml:"env,omitempty"`
+	Name            string              `yaml:"name"`
+	Extends         string              `yaml:"extends,omitempty"`
+	CommandTemplate string              `yaml:"command_template,omitempty"`
+	PromptTemplate  string              `yaml:"prompt_template,omitempty"`
+	PromptTemplates map[string]string   `yaml:"prompt_templates,omitempty"`
+	Models          []string            `yaml:"models,omitempty"`
+	Agents          []string            `yaml:"agents,omitempty"`
+	Env             map[string]string   `yaml:"env,omitempty"`
+	Attention       []yamlAttentionRule `yaml:"attention,omitempty"`
+	Context         []yamlContextEntry  `yaml:"context,omitempty"`
+	When            *yamlWhen           `yaml:"when,omitempty"`
+	Hooks           *yamlHooks          `yaml:"hooks,omitempty"`
+
+	MaxConcurrentAgents int `yaml:"max_concurrent_agents,omitempty"`
+}
+
+// yamlHooks is the raw YAML structure for hooks.
+type yamlHooks struct {
+	PreLaunch []string `yaml:"pre_launch,omitempty"`
+	PostExit  []string `yaml:"post_exit,omitempty"`
+}
+
+// yamlWhen is the raw YAML structure for a harness's when: conditions.
+type yamlWhen struct {
+	Projects   []string `yaml:"projects,omitempty"`
+	IssueTypes []string `yaml:"issue_types,omitempty"`
+	Installed  bool     `yaml:"installed,omitempty"`
+	Env        []string `yaml:"env,omitempty"`
+}
+
+// yamlContextEntry enables a prompt context provider. It is written either
+// as a bare provider name or as a mapping with a limit.
+type yamlContextEntry struct {
+	Provider string `yaml:"provider"`
+	Limit    int    `yaml:"limit,omitempty"`
+}
+
+// yamlAttentionRule is the raw YAML structure for an attention rule.
+type yamlAttentionRule struct {
+	State   string `yaml:"state"`
+	Pattern string `yaml:"pattern"`
+}
+
+// UnmarshalYAML accepts "- git_log" as well as "- {provider: git_log, limit: 20}".
+func (e *yamlContextEntry) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		e.Provider = node.Value
+		return nil
+	}
+	type plain yamlContextEntry
+	return node.Decode((*plain)(e))
 }
 
 // yamlDefaults is the raw YAML structure for default settings.
@@ -48,7 +121,13 @@ type yamlDefaults struct {
 
 // yamlGeneralConfig is the raw YAML structure for general settings.
 type yamlGeneralConfig struct {
-	AutostartDolt *bool `yaml:"autostart_dolt,omitempty"`
+	AutostartDolt   *bool  `yaml:"autostart_dolt,omitempty"`
+	AttentionNotify string `yaml:"attention_notify,omitempty"`
+	AgentState      string `yaml:"agent_state,omitempty"`
+
+	MaxConcurrentAgents   int            `yaml:"max_concurrent_agents,omitempty"`
+	MaxConcurrentPerModel map[string]int `yaml:"max_concurrent_per_model,omitempty"`
+	CommandTimeout        string         `yaml:"command_timeout,omitempty"`
 }
 
 // YAMLLoader implements the Loader interface for YAML configuration files.
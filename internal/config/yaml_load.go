@@ -8,27 +8,117 @@ package config
 
 import (
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/megatherium/blunderbust/internal/domain"
 	"gopkg.in/yaml.v3"
 )
 
+// templateSource is the directory '@' template files are resolved against,
+// the files read from it so far, and the fragments of the templates section
+// expanded in the templates loaded.
+type templateSource struct {
+	dir       string
+	files     []string
+	fragments map[string]string
+}
+
+// fragmentRef matches a {{template "name"}} action, with an optional "."
+// argument and trim markers.
+var fragmentRef = regexp.MustCompile(`\{\{(-?)\s*template\s+"([^"]+)"\s*\.?\s*(-?)\}\}`)
+
+// expand replaces the {{template "name"}} actions that name a fragment
+// with its text, so that the fragment sees the same data as the template
+// using it. Trim markers are kept as empty actions. Other template actions
+// are left alone.
+func (src *templateSource) expand(text string) string {
+	if len(src.fragments) == 0 {
+		return text
+	}
+	return fragmentRef.ReplaceAllStringFunc(text, func(ref string) string {
+		m := fragmentRef.FindStringSubmatch(ref)
+		fragment, ok := src.fragments[m[2]]
+		if !ok {
+			return ref
+		}
+		if m[1] != "" {
+			fragment = `{{- ""}}` + fragment
+		}
+		if m[3] != "" {
+			fragment += `{{"" -}}`
+		}
+		return fragment
+	})
+}
+
+// loadFragments loads the templates section into src. Fragments may use
+// '@' files and other fragments; a fragment that includes itself, directly
+// or not, is an error.
+func loadFragments(raw map[string]string, src *templateSource) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	loaded := make(map[string]string, len(raw))
+	for name, value := range raw {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("templates: empty fragment name")
+		}
+		text, err := loadTemplateValue(value, src)
+		if err != nil {
+			return fmt.Errorf("templates.%s: %w", name, err)
+		}
+		loaded[name] = text
+	}
+
+	expanded := make(map[string]string, len(loaded))
+	var resolve func(name string, chain []string) error
+	resolve = func(name string, chain []string) error {
+		if _, ok := expanded[name]; ok {
+			return nil
+		}
+		if slices.Contains(chain, name) {
+			return fmt.Errorf("templates: fragment cycle %s", strings.Join(append(chain, name), " -> "))
+		}
+		for _, m := range fragmentRef.FindAllStringSubmatch(loaded[name], -1) {
+			if _, ok := loaded[m[2]]; ok {
+				if err := resolve(m[2], append(chain, name)); err != nil {
+					return err
+				}
+			}
+		}
+		expanded[name] = (&templateSource{fragments: expanded}).expand(loaded[name])
+		return nil
+	}
+	for _, name := range slices.Sorted(maps.Keys(loaded)) {
+		if err := resolve(name, nil); err != nil {
+			return err
+		}
+	}
+	src.fragments = expanded
+	return nil
+}
+
 // loadTemplateValue loads a template value from a file if it starts with '@'.
-// If the value doesn't start with '@', returns it as-is.
+// If the value doesn't start with '@', returns it as-is. Fragments are
+// expanded either way.
 // Returns an actionable error if the file cannot be read.
-func loadTemplateValue(value, configDir string) (string, error) {
+func loadTemplateValue(value string, src *templateSource) (string, error) {
 	if !strings.HasPrefix(value, "@") {
-		return value, nil
+		return src.expand(value), nil
 	}
 
 	filePath := strings.TrimPrefix(value, "@")
 	resolvedPath := filePath
 	if !filepath.IsAbs(filePath) {
-		resolvedPath = filepath.Join(configDir, filePath)
+		resolvedPath = filepath.Join(src.dir, filePath)
 	}
+	src.files = append(src.files, resolvedPath)
 
 	content, err := os.ReadFile(resolvedPath)
 	if err != nil {
@@ -38,7 +128,7 @@ func loadTemplateValue(value, configDir string) (string, error) {
 		return "", fmt.Errorf("failed to load template file: %s: %w", value, err)
 	}
 
-	return string(content), nil
+	return src.expand(string(content)), nil
 }
 
 // Load reads and parses a YAML configuration file.
@@ -57,8 +147,8 @@ func (l *YAMLLoader) Load(path string) (*domain.Config, error) {
 		return nil, fmt.Errorf("failed to parse YAML in %s: %w", path, err)
 	}
 
-	configDir := filepath.Dir(path)
-	return l.convertAndValidate(&raw, configDir)
+	src := &templateSource{dir: filepath.Dir(path), files: []string{path}}
+	return l.convertAndValidate(&raw, src)
 }
 
 // validateHarnessNames checks for duplicate harness names.
@@ -78,7 +168,7 @@ func (l *YAMLLoader) validateHarnessNames(harnesses []yamlHarness) error {
 }
 
 // parseWorkspace parses and validates workspace projects.
-func (l *YAMLLoader) parseWorkspace(defaultWorkspace yamlWorkspace, configDir string) ([]domain.Project, error) {
+func (l *YAMLLoader) parseWorkspace(defaultWorkspace yamlWorkspace, src *templateSource) ([]domain.Project, error) {
 	var projects []domain.Project
 	seenDirs := make(map[string]bool)
 
@@ -89,7 +179,7 @@ func (l *YAMLLoader) parseWorkspace(defaultWorkspace yamlWorkspace, configDir st
 
 		projectDir := p.Dir
 		if !filepath.IsAbs(projectDir) {
-			projectDir = filepath.Join(configDir, projectDir)
+			projectDir = filepath.Join(src.dir, projectDir)
 		}
 
 		info, err := os.Stat(projectDir)
@@ -113,16 +203,62 @@ func (l *YAMLLoader) parseWorkspace(defaultWorkspace yamlWorkspace, configDir st
 		if name == "" {
 			name = filepath.Base(p.Dir)
 		}
+		tickets, err := convertTicketSource(p.Tickets)
+		if err != nil {
+			return nil, fmt.Errorf("project %s: %w", name, err)
+		}
+		promptTemplates, err := convertPromptTemplates(p.PromptTemplates, src, true)
+		if err != nil {
+			return nil, fmt.Errorf("project %s: %w", name, err)
+		}
+		envFile := p.EnvFile
+		if envFile != "" && !filepath.IsAbs(envFile) {
+			envFile = filepath.Join(cleanDir, envFile)
+		}
 		projects = append(projects, domain.Project{
-			Dir:  cleanDir,
-			Name: name,
+			Dir:             cleanDir,
+			Name:            name,
+			Tickets:         tickets,
+			PromptTemplates: promptTemplates,
+			EnvFile:         envFile,
+			Hooks:           convertHooks(p.Hooks, src),
+			Verify: domain.Verify{
+				Command: strings.TrimSpace(p.VerifyCommand),
+				Manual:  p.VerifyOnExit != nil && !*p.VerifyOnExit,
+				Comment: p.VerifyComment,
+			},
 		})
 	}
 	return projects, nil
 }
 
+// convertTicketSource validates a project's tickets: section. A missing
+// section means the project's Beads database.
+func convertTicketSource(raw *yamlTicketSource) (domain.TicketSource, error) {
+	if raw == nil {
+		return domain.TicketSource{}, nil
+	}
+	src := domain.TicketSource{
+		Type:     strings.ToLower(raw.Source),
+		Repo:     raw.Repo,
+		URL:      strings.TrimRight(raw.URL, "/"),
+		TokenEnv: raw.TokenEnv,
+	}
+	switch src.Type {
+	case "", domain.TicketSourceBeads:
+		return domain.TicketSource{}, nil
+	case domain.TicketSourceGitHub, domain.TicketSourceGitLab:
+		if src.Repo == "" {
+			return src, fmt.Errorf("tickets.repo is required for source %q", src.Type)
+		}
+		return src, nil
+	default:
+		return src, fmt.Errorf("invalid tickets.source value: %q (must be 'beads', 'github' or 'gitlab')", raw.Source)
+	}
+}
+
 // convertAndValidate converts the raw YAML to domain types and validates.
-func (l *YAMLLoader) convertAndValidate(raw *yamlConfig, configDir string) (*domain.Config, error) {
+func (l *YAMLLoader) convertAndValidate(raw *yamlConfig, src *templateSource) (*domain.Config, error) {
 	if len(raw.Harnesses) == 0 {
 		return nil, fmt.Errorf("config must define at least one harness")
 	}
@@ -130,20 +266,31 @@ func (l *YAMLLoader) convertAndValidate(raw *yamlConfig, configDir string) (*dom
 		return nil, err
 	}
 
+	if err := loadFragments(raw.Templates, src); err != nil {
+		return nil, err
+	}
+	rawHarnesses, err := resolveExtends(raw.Harnesses, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	config := &domain.Config{
 		Harnesses: make([]domain.Harness, 0, len(raw.Harnesses)),
 	}
 
-	for i, rawHarness := range raw.Harnesses {
-		harness, err := l.convertHarness(rawHarness, i, configDir)
+	for i, rawHarness := range rawHarnesses {
+		harness, err := l.convertHarness(rawHarness, i, src)
 		if err != nil {
 			return nil, err
 		}
+		if harness.PromptSnippets, err = LoadSnippets(src.dir, harness.Name); err != nil {
+			return nil, fmt.Errorf("harness %q: %w", harness.Name, err)
+		}
 		config.Harnesses = append(config.Harnesses, *harness)
 	}
 
 	if defaultWorkspace, ok := raw.Workspaces["default"]; ok {
-		projects, err := l.parseWorkspace(defaultWorkspace, configDir)
+		projects, err := l.parseWorkspace(defaultWorkspace, src)
 		if err != nil {
 			return nil, err
 		}
@@ -160,7 +307,7 @@ func (l *YAMLLoader) convertAndValidate(raw *yamlConfig, configDir string) (*dom
 		}
 		config.Launcher = launcherConfig
 	} else {
-		config.Launcher = &domain.LauncherConfig{Target: "foreground"}
+		config.Launcher = &domain.LauncherConfig{Target: "foreground", Session: DefaultLauncherSession}
 	}
 
 	if raw.Defaults != nil {
@@ -175,11 +322,174 @@ func (l *YAMLLoader) convertAndValidate(raw *yamlConfig, configDir string) (*dom
 	if raw.General != nil && raw.General.AutostartDolt != nil {
 		autostart = *raw.General.AutostartDolt
 	}
-	config.General = &domain.GeneralConfig{AutostartDolt: autostart}
+	notify := domain.AttentionNotifyNone
+	if raw.General != nil && raw.General.AttentionNotify != "" {
+		notify = strings.ToLower(raw.General.AttentionNotify)
+		switch notify {
+		case domain.AttentionNotifyNone, domain.AttentionNotifyBell, domain.AttentionNotifyDisplayMessage:
+		default:
+			return nil, fmt.Errorf("invalid general.attention_notify value: %q (must be 'none', 'bell' or 'display-message')", raw.General.AttentionNotify)
+		}
+	}
+	agentState := domain.AgentStateDolt
+	if raw.General != nil && raw.General.AgentState != "" {
+		agentState = strings.ToLower(raw.General.AgentState)
+		switch agentState {
+		case domain.AgentStateDolt, domain.AgentStateLocal:
+		default:
+			return nil, fmt.Errorf("invalid general.agent_state value: %q (must be 'dolt' or 'local')", raw.General.AgentState)
+		}
+	}
+	config.General = &domain.GeneralConfig{AutostartDolt: autostart, AttentionNotify: notify, AgentState: agentState}
+	if raw.General != nil {
+		if raw.General.MaxConcurrentAgents < 0 {
+			return nil, fmt.Errorf("invalid general.max_concurrent_agents value: %d (must be 0 for no limit or more)", raw.General.MaxConcurrentAgents)
+		}
+		for model, limit := range raw.General.MaxConcurrentPerModel {
+			if limit < 0 {
+				return nil, fmt.Errorf("invalid general.max_concurrent_per_model value for %q: %d (must be 0 for no limit or more)", model, limit)
+			}
+		}
+		config.General.MaxConcurrentAgents = raw.General.MaxConcurrentAgents
+		config.General.MaxConcurrentPerModel = raw.General.MaxConcurrentPerModel
+	}
+	config.General.CommandTimeout = domain.DefaultCommandTimeout
+	if raw.General != nil && raw.General.CommandTimeout != "" {
+		timeout, err := time.ParseDuration(raw.General.CommandTimeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid general.command_timeout value: %q (must be a positive duration such as 90s or 10m)", raw.General.CommandTimeout)
+		}
+		config.General.CommandTimeout = timeout
+	}
+	config.Files = src.files
 
 	return config, nil
 }
 
+// harnessError is an error in the harness at index of a harness list.
+type harnessError struct {
+	index int
+	err   error
+}
+
+func (e *harnessError) Error() string { return e.err.Error() }
+func (e *harnessError) Unwrap() error { return e.err }
+
+// resolveExtends returns harnesses with each harness that extends another
+// merged over it, see mergeHarness. A harness may extend one defined later
+// in the list, but not itself, directly or not. A parent not in the list,
+// or a harness extending its own name, is looked up in base, whose
+// harnesses are already resolved; base may be nil.
+func resolveExtends(harnesses []yamlHarness, base map[string]yamlHarness) ([]yamlHarness, error) {
+	byName := make(map[string]int, len(harnesses))
+	for i, h := range harnesses {
+		byName[h.Name] = i
+	}
+	resolved := make([]yamlHarness, len(harnesses))
+	done := make([]bool, len(harnesses))
+
+	var resolve func(i int, chain []string) error
+	resolve = func(i int, chain []string) error {
+		if done[i] {
+			return nil
+		}
+		h := harnesses[i]
+		if h.Extends == "" {
+			resolved[i], done[i] = h, true
+			return nil
+		}
+		if slices.Contains(chain, h.Name) {
+			return fmt.Errorf("harness %q: extends cycle %s", h.Name, strings.Join(append(chain, h.Name), " -> "))
+		}
+		parent, ok := byName[h.Extends]
+		if !ok || parent == i {
+			baseParent, ok := base[h.Extends]
+			if !ok {
+				return fmt.Errorf("harness %q extends unknown harness %q", h.Name, h.Extends)
+			}
+			resolved[i], done[i] = mergeHarness(baseParent, h), true
+			return nil
+		}
+		if err := resolve(parent, append(chain, h.Name)); err != nil {
+			return err
+		}
+		resolved[i], done[i] = mergeHarness(resolved[parent], h), true
+		return nil
+	}
+	for i := range harnesses {
+		if err := resolve(i, nil); err != nil {
+			return nil, &harnessError{index: i, err: err}
+		}
+	}
+	return resolved, nil
+}
+
+// mergeHarness merges child over parent. Templates, attention rules and
+// context providers the child sets replace the parent's; env and
+// prompt_templates are merged key by key; models and agents are the
+// parent's followed by the child's additions.
+func mergeHarness(parent, child yamlHarness) yamlHarness {
+	merged := child
+	merged.Extends = ""
+	if merged.CommandTemplate == "" {
+		merged.CommandTemplate = parent.CommandTemplate
+	}
+	if merged.PromptTemplate == "" {
+		merged.PromptTemplate = parent.PromptTemplate
+	}
+	if merged.Attention == nil {
+		merged.Attention = parent.Attention
+	}
+	if merged.Context == nil {
+		merged.Context = parent.Context
+	}
+	if merged.When == nil {
+		merged.When = parent.When
+	}
+	if merged.Hooks == nil {
+		merged.Hooks = parent.Hooks
+	}
+	if merged.MaxConcurrentAgents == 0 {
+		merged.MaxConcurrentAgents = parent.MaxConcurrentAgents
+	}
+	merged.Env = mergeMaps(parent.Env, child.Env, func(k string) string { return k })
+	merged.PromptTemplates = mergeMaps(parent.PromptTemplates, child.PromptTemplates, func(k string) string {
+		return strings.ToLower(strings.TrimSpace(k))
+	})
+	merged.Models = mergeLists(parent.Models, child.Models)
+	merged.Agents = mergeLists(parent.Agents, child.Agents)
+	return merged
+}
+
+// mergeMaps returns child's entries over parent's, with keys compared
+// after normalizing them with key.
+func mergeMaps(parent, child map[string]string, key func(string) string) map[string]string {
+	if len(parent) == 0 {
+		return child
+	}
+	merged := make(map[string]string, len(parent)+len(child))
+	for k, v := range parent {
+		merged[key(k)] = v
+	}
+	for k, v := range child {
+		merged[key(k)] = v
+	}
+	return merged
+}
+
+func mergeLists(parent, child []string) []string {
+	if len(parent) == 0 {
+		return child
+	}
+	merged := slices.Clone(parent)
+	for _, item := range child {
+		if !slices.Contains(merged, item) {
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
+
 // convertLauncherConfig validates and converts launcher configuration.
 func (l *YAMLLoader) convertLauncherConfig(raw *yamlLauncherConfig) (*domain.LauncherConfig, error) {
 	target := strings.ToLower(raw.Target)
@@ -189,17 +499,27 @@ func (l *YAMLLoader) convertLauncherConfig(raw *yamlLauncherConfig) (*domain.Lau
 	if target != "foreground" && target != "background" {
 		return nil, fmt.Errorf("invalid launcher.target value: %q (must be 'foreground' or 'background')", raw.Target)
 	}
-	return &domain.LauncherConfig{Target: target}, nil
+
+	session := strings.TrimSpace(raw.Session)
+	switch {
+	case session == "":
+		session = DefaultLauncherSession
+	case strings.EqualFold(session, CurrentLauncherSession):
+		session = ""
+	case strings.ContainsAny(session, ":."):
+		return nil, fmt.Errorf("invalid launcher.session value: %q (must not contain ':' or '.')", raw.Session)
+	}
+	return &domain.LauncherConfig{Target: target, Session: session}, nil
 }
 
 // convertHarness validates and converts a single YAML harness to domain type.
-func (l *YAMLLoader) convertHarness(raw yamlHarness, index int, configDir string) (*domain.Harness, error) {
+func (l *YAMLLoader) convertHarness(raw yamlHarness, index int, src *templateSource) (*domain.Harness, error) {
 	harnessName := raw.Name
 	if harnessName == "" {
 		return nil, fmt.Errorf("harness at index %d is missing required field: name", index)
 	}
 
-	commandTemplate, err := loadTemplateValue(raw.CommandTemplate, configDir)
+	commandTemplate, err := loadTemplateValue(raw.CommandTemplate, src)
 	if err != nil {
 		return nil, fmt.Errorf("harness %q: %w", harnessName, err)
 	}
@@ -207,7 +527,12 @@ func (l *YAMLLoader) convertHarness(raw yamlHarness, index int, configDir string
 		return nil, fmt.Errorf("harness %q is missing required field: command_template", harnessName)
 	}
 
-	promptTemplate, err := loadTemplateValue(raw.PromptTemplate, configDir)
+	promptTemplate, err := loadTemplateValue(raw.PromptTemplate, src)
+	if err != nil {
+		return nil, fmt.Errorf("harness %q: %w", harnessName, err)
+	}
+
+	promptTemplates, err := convertPromptTemplates(raw.PromptTemplates, src, false)
 	if err != nil {
 		return nil, fmt.Errorf("harness %q: %w", harnessName, err)
 	}
@@ -227,12 +552,129 @@ func (l *YAMLLoader) convertHarness(raw yamlHarness, index int, configDir string
 		env = map[string]string{}
 	}
 
+	attentionRules, err := convertAttentionRules(raw.Attention)
+	if err != nil {
+		return nil, fmt.Errorf("harness %q: %w", harnessName, err)
+	}
+
+	contextProviders, err := convertContextProviders(raw.Context)
+	if err != nil {
+		return nil, fmt.Errorf("harness %q: %w", harnessName, err)
+	}
+
+	when, err := convertWhen(raw.When)
+	if err != nil {
+		return nil, fmt.Errorf("harness %q: %w", harnessName, err)
+	}
+
+	if raw.MaxConcurrentAgents < 0 {
+		return nil, fmt.Errorf("harness %q: invalid max_concurrent_agents value: %d (must be 0 for no limit or more)", harnessName, raw.MaxConcurrentAgents)
+	}
+
 	return &domain.Harness{
 		Name:            harnessName,
 		CommandTemplate: commandTemplate,
 		PromptTemplate:  promptTemplate,
+		PromptTemplates: promptTemplates,
 		SupportedModels: models,
 		SupportedAgents: agents,
 		Env:             env,
+		AttentionRules:  attentionRules,
+		Context:         contextProviders,
+		When:            when,
+		Hooks:           convertHooks(raw.Hooks, src),
+
+		MaxConcurrentAgents: raw.MaxConcurrentAgents,
 	}, nil
 }
+
+// convertHooks expands the template fragments of hooks. Unlike templates,
+// hook commands are never read from '@' files.
+func convertHooks(raw *yamlHooks, src *templateSource) domain.Hooks {
+	if raw == nil {
+		return domain.Hooks{}
+	}
+	expand := func(commands []string) []string {
+		var out []string
+		for _, c := range commands {
+			if c = strings.TrimSpace(c); c != "" {
+				out = append(out, src.expand(c))
+			}
+		}
+		return out
+	}
+	return domain.Hooks{PreLaunch: expand(raw.PreLaunch), PostExit: expand(raw.PostExit)}
+}
+
+// convertPromptTemplates loads a prompt_templates map keyed by issue type.
+// Values may reference files with '@', like prompt_template. The default
+// key is only meaningful where no prompt_template sits beside the map, so
+// it is accepted only if allowDefault is set.
+func convertPromptTemplates(raw map[string]string, src *templateSource, allowDefault bool) (domain.PromptTemplates, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	templates := make(domain.PromptTemplates, len(raw))
+	for key, value := range raw {
+		issueType := strings.ToLower(strings.TrimSpace(key))
+		if issueType == "" {
+			return nil, fmt.Errorf("prompt_templates: empty issue type")
+		}
+		if issueType == domain.DefaultPromptTemplateKey && !allowDefault {
+			return nil, fmt.Errorf("prompt_templates: use prompt_template instead of a %q entry", key)
+		}
+		if _, ok := templates[issueType]; ok {
+			return nil, fmt.Errorf("prompt_templates: issue type %q is listed twice", issueType)
+		}
+		tmpl, err := loadTemplateValue(value, src)
+		if err != nil {
+			return nil, fmt.Errorf("prompt_templates.%s: %w", key, err)
+		}
+		if tmpl == "" {
+			return nil, fmt.Errorf("prompt_templates.%s is empty", key)
+		}
+		templates[issueType] = tmpl
+	}
+	return templates, nil
+}
+
+// convertContextProviders validates a harness's context provider list.
+func convertContextProviders(raw []yamlContextEntry) ([]domain.ContextProvider, error) {
+	var providers []domain.ContextProvider
+	seen := make(map[domain.ContextProviderName]bool)
+	for i, e := range raw {
+		name, err := domain.ParseContextProviderName(e.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("context entry %d: %w", i, err)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("context entry %d: provider %q is listed twice", i, name)
+		}
+		if e.Limit < 0 {
+			return nil, fmt.Errorf("context entry %d: limit must not be negative", i)
+		}
+		seen[name] = true
+		providers = append(providers, domain.ContextProvider{Name: name, Limit: e.Limit})
+	}
+	return providers, nil
+}
+
+// convertAttentionRules parses state names and compiles rule patterns.
+func convertAttentionRules(raw []yamlAttentionRule) ([]domain.AttentionRule, error) {
+	rules := make([]domain.AttentionRule, 0, len(raw))
+	for i, r := range raw {
+		state, err := domain.ParseAttentionState(r.State)
+		if err != nil {
+			return nil, fmt.Errorf("attention rule %d: %w", i, err)
+		}
+		if r.Pattern == "" {
+			return nil, fmt.Errorf("attention rule %d is missing required field: pattern", i)
+		}
+		pattern, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("attention rule %d: invalid pattern: %w", i, err)
+		}
+		rules = append(rules, domain.AttentionRule{State: state, Pattern: pattern})
+	}
+	return rules, nil
+}
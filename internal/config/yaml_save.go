@@ -41,15 +41,43 @@ func (l *YAMLLoader) domainToYAML(cfg *domain.Config) yamlConfig {
 				Name:            harness.Name,
 				CommandTemplate: harness.CommandTemplate,
 				PromptTemplate:  harness.PromptTemplate,
+				PromptTemplates: harness.PromptTemplates,
 				Models:          harness.SupportedModels,
 				Agents:          harness.SupportedAgents,
 				Env:             harness.Env,
+
+				MaxConcurrentAgents: harness.MaxConcurrentAgents,
+			}
+			yamlCfg.Harnesses[i].Hooks = hooksToYAML(harness.Hooks)
+			if !harness.When.IsZero() {
+				yamlCfg.Harnesses[i].When = &yamlWhen{
+					Projects:   harness.When.Projects,
+					IssueTypes: harness.When.IssueTypes,
+					Installed:  harness.When.Installed,
+					Env:        harness.When.Env,
+				}
+			}
+			for _, p := range harness.Context {
+				yamlCfg.Harnesses[i].Context = append(yamlCfg.Harnesses[i].Context, yamlContextEntry{
+					Provider: string(p.Name),
+					Limit:    p.Limit,
+				})
+			}
+			for _, rule := range harness.AttentionRules {
+				yamlCfg.Harnesses[i].Attention = append(yamlCfg.Harnesses[i].Attention, yamlAttentionRule{
+					State:   rule.State.String(),
+					Pattern: rule.Pattern.String(),
+				})
 			}
 		}
 	}
 
 	if cfg.Launcher != nil {
-		yamlCfg.Launcher = &yamlLauncherConfig{Target: cfg.Launcher.Target}
+		session := cfg.Launcher.Session
+		if session == "" {
+			session = CurrentLauncherSession
+		}
+		yamlCfg.Launcher = &yamlLauncherConfig{Target: cfg.Launcher.Target, Session: session}
 	}
 
 	if cfg.Defaults != nil {
@@ -65,14 +93,42 @@ func (l *YAMLLoader) domainToYAML(cfg *domain.Config) yamlConfig {
 		yamlCfg.General = &yamlGeneralConfig{
 			AutostartDolt: &autostart,
 		}
+		if cfg.General.AttentionNotify != domain.AttentionNotifyNone {
+			yamlCfg.General.AttentionNotify = cfg.General.AttentionNotify
+		}
+		if cfg.General.AgentState != "" && cfg.General.AgentState != domain.AgentStateDolt {
+			yamlCfg.General.AgentState = cfg.General.AgentState
+		}
+		yamlCfg.General.MaxConcurrentAgents = cfg.General.MaxConcurrentAgents
+		yamlCfg.General.MaxConcurrentPerModel = cfg.General.MaxConcurrentPerModel
+		if t := cfg.General.CommandTimeout; t != 0 && t != domain.DefaultCommandTimeout {
+			yamlCfg.General.CommandTimeout = t.String()
+		}
 	}
 
 	if len(cfg.Workspace.Projects) > 0 {
 		projects := make([]yamlProject, len(cfg.Workspace.Projects))
 		for i, project := range cfg.Workspace.Projects {
 			projects[i] = yamlProject{
-				Dir:  project.Dir,
-				Name: project.Name,
+				Dir:             project.Dir,
+				Name:            project.Name,
+				PromptTemplates: project.PromptTemplates,
+				EnvFile:         project.EnvFile,
+				Hooks:           hooksToYAML(project.Hooks),
+				VerifyCommand:   project.Verify.Command,
+				VerifyComment:   project.Verify.Comment,
+			}
+			if project.Verify.Manual {
+				onExit := false
+				projects[i].VerifyOnExit = &onExit
+			}
+			if project.Tickets.IsRemote() {
+				projects[i].Tickets = &yamlTicketSource{
+					Source:   project.Tickets.Type,
+					Repo:     project.Tickets.Repo,
+					URL:      project.Tickets.URL,
+					TokenEnv: project.Tickets.TokenEnv,
+				}
 			}
 		}
 		yamlCfg.Workspaces = map[string]yamlWorkspace{
@@ -82,3 +138,11 @@ func (l *YAMLLoader) domainToYAML(cfg *domain.Config) yamlConfig {
 
 	return yamlCfg
 }
+
+// hooksToYAML returns nil for hooks without commands.
+func hooksToYAML(hooks domain.Hooks) *yamlHooks {
+	if len(hooks.PreLaunch) == 0 && len(hooks.PostExit) == 0 {
+		return nil
+	}
+	return &yamlHooks{PreLaunch: hooks.PreLaunch, PostExit: hooks.PostExit}
+}
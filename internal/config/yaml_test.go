@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -320,6 +321,11 @@ harnesses:
 	if opencode.PromptTemplate != promptTemplateContent {
 		t.Errorf("Unexpected prompt_template: %q", opencode.PromptTemplate)
 	}
+
+	wantFiles := []string{configPath, cmdTemplatePath, promptTemplatePath}
+	if !slices.Equal(config.Files, wantFiles) {
+		t.Errorf("Expected files %v, got %v", wantFiles, config.Files)
+	}
 }
 
 func TestYAMLLoader_Load_FileBasedTemplates_MissingFile(t *testing.T) {
@@ -688,6 +694,279 @@ harnesses:
 	if config.Launcher.Target != "foreground" {
 		t.Errorf("Expected default target 'foreground', got %q", config.Launcher.Target)
 	}
+	if config.Launcher.Session != DefaultLauncherSession {
+		t.Errorf("Expected default session %q, got %q", DefaultLauncherSession, config.Launcher.Session)
+	}
+}
+
+func TestYAMLLoader_Load_LauncherConfig_Session(t *testing.T) {
+	tests := []struct {
+		name    string
+		session string
+		want    string
+		wantErr bool
+	}{
+		{name: "custom", session: "agents", want: "agents"},
+		{name: "current", session: "current", want: ""},
+		{name: "empty", session: `""`, want: DefaultLauncherSession},
+		{name: "invalid", session: "bad:name", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yamlContent := `
+harnesses:
+  - name: opencode
+    command_template: "opencode"
+launcher:
+  session: ` + tt.session + `
+`
+			configPath := filepath.Join(t.TempDir(), "test.yaml")
+			if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			config, err := NewYAMLLoader().Load(configPath)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "invalid launcher.session value") {
+					t.Fatalf("Expected invalid session error, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if config.Launcher.Session != tt.want {
+				t.Errorf("Expected session %q, got %q", tt.want, config.Launcher.Session)
+			}
+		})
+	}
+}
+
+func TestYAMLLoader_Load_AttentionRules(t *testing.T) {
+	yamlContent := `
+harnesses:
+  - name: claude
+    command_template: "claude"
+    attention:
+      - state: approval
+        pattern: 'Do you want to proceed\?'
+      - state: idle
+        pattern: '(?m)^> $'
+general:
+  attention_notify: display-message
+`
+	configPath := filepath.Join(t.TempDir(), "test.yaml")
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	config, err := NewYAMLLoader().Load(configPath)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	rules := config.Harnesses[0].AttentionRules
+	if len(rules) != 2 {
+		t.Fatalf("Expected 2 attention rules, got %d", len(rules))
+	}
+	if rules[0].State != domain.AttentionApproval || !rules[0].Pattern.MatchString("Do you want to proceed?") {
+		t.Errorf("Unexpected first rule: %v %v", rules[0].State, rules[0].Pattern)
+	}
+	if rules[1].State != domain.AttentionIdle {
+		t.Errorf("Expected second rule to be idle, got %v", rules[1].State)
+	}
+	if config.General.AttentionNotify != domain.AttentionNotifyDisplayMessage {
+		t.Errorf("Expected attention_notify display-message, got %q", config.General.AttentionNotify)
+	}
+}
+
+func TestYAMLLoader_Load_AttentionRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown state",
+			content: "    attention:\n      - state: sleepy\n        pattern: x\n",
+			wantErr: "unknown attention state",
+		},
+		{
+			name:    "missing pattern",
+			content: "    attention:\n      - state: idle\n",
+			wantErr: "missing required field: pattern",
+		},
+		{
+			name:    "bad regex",
+			content: "    attention:\n      - state: error\n        pattern: '(unclosed'\n",
+			wantErr: "invalid pattern",
+		},
+		{
+			name:    "bad notify mode",
+			content: "general:\n  attention_notify: siren\n",
+			wantErr: "invalid general.attention_notify value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yamlContent := "harnesses:\n  - name: claude\n    command_template: claude\n" + tt.content
+			configPath := filepath.Join(t.TempDir(), "test.yaml")
+			if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			_, err := NewYAMLLoader().Load(configPath)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestYAMLLoader_Load_ContextProviders(t *testing.T) {
+	yamlContent := `
+harnesses:
+  - name: claude
+    command_template: "claude"
+    context:
+      - docs
+      - provider: git_log
+        limit: 5
+`
+	configPath := filepath.Join(t.TempDir(), "test.yaml")
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	config, err := NewYAMLLoader().Load(configPath)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	providers := config.Harnesses[0].Context
+	if len(providers) != 2 {
+		t.Fatalf("Expected 2 context providers, got %d", len(providers))
+	}
+	if providers[0].Name != domain.ContextRepoDocs || providers[0].EffectiveLimit() != 16*1024 {
+		t.Errorf("Unexpected first provider: %+v", providers[0])
+	}
+	if providers[1].Name != domain.ContextGitLog || providers[1].EffectiveLimit() != 5 {
+		t.Errorf("Unexpected second provider: %+v", providers[1])
+	}
+}
+
+func TestYAMLLoader_Load_ContextProviders_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown provider",
+			content: "    context: [slack]\n",
+			wantErr: "unknown context provider",
+		},
+		{
+			name:    "duplicate provider",
+			content: "    context: [docs, docs]\n",
+			wantErr: "listed twice",
+		},
+		{
+			name:    "negative limit",
+			content: "    context:\n      - provider: related\n        limit: -1\n",
+			wantErr: "limit",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yamlContent := "harnesses:\n  - name: claude\n    command_template: claude\n" + tt.content
+			configPath := filepath.Join(t.TempDir(), "test.yaml")
+			if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			_, err := NewYAMLLoader().Load(configPath)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestYAMLLoader_Load_AgentState(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr string
+	}{
+		{name: "default", content: "", want: domain.AgentStateDolt},
+		{name: "local", content: "general:\n  agent_state: Local\n", want: domain.AgentStateLocal},
+		{name: "invalid", content: "general:\n  agent_state: sqlite\n", wantErr: "invalid general.agent_state value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yamlContent := "harnesses:\n  - name: claude\n    command_template: claude\n" + tt.content
+			configPath := filepath.Join(t.TempDir(), "test.yaml")
+			if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			config, err := NewYAMLLoader().Load(configPath)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if config.General.AgentState != tt.want {
+				t.Errorf("Expected agent_state %q, got %q", tt.want, config.General.AgentState)
+			}
+		})
+	}
+}
+
+func TestYAMLLoader_Load_CommandTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    time.Duration
+		wantErr string
+	}{
+		{name: "default", content: "", want: domain.DefaultCommandTimeout},
+		{name: "set", content: "general:\n  command_timeout: 90s\n", want: 90 * time.Second},
+		{name: "invalid", content: "general:\n  command_timeout: soon\n", wantErr: "invalid general.command_timeout value"},
+		{name: "zero", content: "general:\n  command_timeout: 0s\n", wantErr: "invalid general.command_timeout value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yamlContent := "harnesses:\n  - name: claude\n    command_template: claude\n" + tt.content
+			configPath := filepath.Join(t.TempDir(), "test.yaml")
+			if err := os.WriteFile(configPath, []byte(yamlContent), 0644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			config, err := NewYAMLLoader().Load(configPath)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if config.General.CommandTimeout != tt.want {
+				t.Errorf("Expected command_timeout %v, got %v", tt.want, config.General.CommandTimeout)
+			}
+		})
+	}
 }
 
 func TestYAMLLoader_Load_LauncherConfig_EmptyTarget(t *testing.T) {
@@ -983,6 +1262,319 @@ harnesses:
 	}
 }
 
+func TestYAMLLoader_Load_ProjectTicketSource(t *testing.T) {
+	projectDir := t.TempDir()
+	tests := []struct {
+		name    string
+		tickets string
+		want    domain.TicketSource
+		wantErr string
+	}{
+		{name: "default", want: domain.TicketSource{}},
+		{
+			name:    "github",
+			tickets: "\n        tickets:\n          source: GitHub\n          repo: acme/app\n          token_env: ACME_TOKEN",
+			want:    domain.TicketSource{Type: domain.TicketSourceGitHub, Repo: "acme/app", TokenEnv: "ACME_TOKEN"},
+		},
+		{
+			name:    "gitlab with url",
+			tickets: "\n        tickets:\n          source: gitlab\n          repo: group/app\n          url: https://git.example.com/api/v4/",
+			want:    domain.TicketSource{Type: domain.TicketSourceGitLab, Repo: "group/app", URL: "https://git.example.com/api/v4"},
+		},
+		{
+			name:    "missing repo",
+			tickets: "\n        tickets:\n          source: github",
+			wantErr: "tickets.repo is required",
+		},
+		{
+			name:    "unknown source",
+			tickets: "\n        tickets:\n          source: jira",
+			wantErr: "invalid tickets.source value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yamlContent := fmt.Sprintf(`
+workspaces:
+  default:
+    projects:
+      - dir: %s%s
+harnesses:
+  - name: test
+    command_template: "test"
+`, projectDir, tt.tickets)
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configPath, []byte(yamlContent), 0o644); err != nil {
+				t.Fatalf("Failed to write test config: %v", err)
+			}
+
+			cfg, err := NewYAMLLoader().Load(configPath)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if got := cfg.Workspace.Projects[0].Tickets; got != tt.want {
+				t.Errorf("tickets = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYAMLLoader_Load_PromptTemplates(t *testing.T) {
+	tmpDir := t.TempDir()
+	projectDir := filepath.Join(tmpDir, "app")
+	if err := os.MkdirAll(projectDir, 0o755); err != nil {
+		t.Fatalf("Failed to create project directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "bug.md"), []byte("Reproduce {{.TicketID}} first"), 0o644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	yamlContent := `
+workspaces:
+  default:
+    projects:
+      - dir: app
+        prompt_templates:
+          default: "App work on {{.TicketID}}"
+harnesses:
+  - name: test
+    command_template: "test"
+    prompt_template: "Work on {{.TicketID}}"
+    prompt_templates:
+      Bug: "@./bug.md"
+      feature: "Build {{.TicketTitle}}"
+`
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	cfg, err := NewYAMLLoader().Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	harness := cfg.Harnesses[0].PromptTemplates
+	if harness["bug"] != "Reproduce {{.TicketID}} first" || harness["feature"] != "Build {{.TicketTitle}}" {
+		t.Errorf("Unexpected harness prompt_templates: %v", harness)
+	}
+	project := cfg.Workspace.Projects[0].PromptTemplates
+	if project["default"] != "App work on {{.TicketID}}" {
+		t.Errorf("Unexpected project prompt_templates: %v", project)
+	}
+}
+
+func TestYAMLLoader_Load_PromptTemplates_HarnessDefault(t *testing.T) {
+	yamlContent := `
+harnesses:
+  - name: test
+    command_template: "test"
+    prompt_templates:
+      default: "Work on {{.TicketID}}"
+`
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+
+	_, err := NewYAMLLoader().Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "use prompt_template instead") {
+		t.Fatalf("Expected a default entry to be rejected, got: %v", err)
+	}
+}
+
+func writeTestConfig(t *testing.T, dir, content string) string {
+	t.Helper()
+	configPath := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write test config: %v", err)
+	}
+	return configPath
+}
+
+func TestYAMLLoader_Load_Extends(t *testing.T) {
+	configPath := writeTestConfig(t, t.TempDir(), `
+harnesses:
+  - name: opencode-deep
+    extends: opencode-fast
+    models: [o3]
+    prompt_templates:
+      BUG: "Find the root cause of {{.TicketID}}"
+  - name: opencode-fast
+    extends: opencode
+    command_template: "opencode --fast --model {{.Model}}"
+    env:
+      OPENCODE_LOG_LEVEL: debug
+  - name: opencode
+    command_template: "opencode --model {{.Model}}"
+    prompt_template: "Work on {{.TicketID}}"
+    prompt_templates:
+      bug: "Reproduce {{.TicketID}}"
+      feature: "Build {{.TicketTitle}}"
+    models: [claude-sonnet, gpt-5]
+    agents: [coder]
+    env:
+      OPENCODE_LOG_LEVEL: info
+      OPENCODE_THEME: dark
+`)
+	cfg, err := NewYAMLLoader().Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	deep := cfg.Harnesses[0]
+	if deep.Name != "opencode-deep" || deep.CommandTemplate != "opencode --fast --model {{.Model}}" {
+		t.Errorf("Expected the command_template of the nearest ancestor, got %+v", deep)
+	}
+	if deep.PromptTemplate != "Work on {{.TicketID}}" {
+		t.Errorf("Expected the inherited prompt_template, got %q", deep.PromptTemplate)
+	}
+	if got := strings.Join(deep.SupportedModels, ","); got != "claude-sonnet,gpt-5,o3" {
+		t.Errorf("Expected the models to be added to the parent's, got %s", got)
+	}
+	if len(deep.SupportedAgents) != 1 || deep.SupportedAgents[0] != "coder" {
+		t.Errorf("Expected the inherited agents, got %v", deep.SupportedAgents)
+	}
+	if deep.Env["OPENCODE_LOG_LEVEL"] != "debug" || deep.Env["OPENCODE_THEME"] != "dark" {
+		t.Errorf("Expected env merged key by key, got %v", deep.Env)
+	}
+	if deep.PromptTemplates["bug"] != "Find the root cause of {{.TicketID}}" || deep.PromptTemplates["feature"] != "Build {{.TicketTitle}}" {
+		t.Errorf("Expected prompt_templates merged by issue type, got %v", deep.PromptTemplates)
+	}
+
+	base := cfg.Harnesses[2]
+	if base.Env["OPENCODE_LOG_LEVEL"] != "info" || len(base.SupportedModels) != 2 {
+		t.Errorf("Extending must not change the parent, got %+v", base)
+	}
+}
+
+func TestYAMLLoader_Load_ExtendsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name:    "unknown parent",
+			config:  "harnesses:\n  - name: a\n    extends: b\n",
+			wantErr: `harness "a" extends unknown harness "b"`,
+		},
+		{
+			name:    "cycle",
+			config:  "harnesses:\n  - name: a\n    extends: b\n  - name: b\n    extends: a\n",
+			wantErr: `extends cycle a -> b -> a`,
+		},
+		{
+			name:    "nothing to inherit",
+			config:  "harnesses:\n  - name: a\n    command_template: a\n  - name: b\n    extends: c\n  - name: c\n    extends: a\n    command_template: \"\"\n",
+			wantErr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewYAMLLoader().Load(writeTestConfig(t, t.TempDir(), tt.config))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Load() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Load() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestYAMLLoader_Load_TemplateFragments(t *testing.T) {
+	tmpDir := t.TempDir()
+	rulesPath := filepath.Join(tmpDir, "rules.md")
+	if err := os.WriteFile(rulesPath, []byte("Run the tests before {{.TicketID}} is done."), 0o644); err != nil {
+		t.Fatalf("Failed to write fragment: %v", err)
+	}
+	configPath := writeTestConfig(t, tmpDir, `
+templates:
+  rules: "@rules.md"
+  ticket: "{{.TicketID}}: {{.TicketTitle}}"
+  footer: |-
+    {{template "rules"}}
+harnesses:
+  - name: claude
+    command_template: claude {{- template "flags" .}} "{{.Prompt}}"
+    prompt_template: |-
+      Work on {{template "ticket" .}}
+      {{template "footer"}}
+    prompt_templates:
+      bug: "Fix {{template \"ticket\"}}"
+`)
+	cfg, err := NewYAMLLoader().Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	harness := cfg.Harnesses[0]
+	if want := "Work on {{.TicketID}}: {{.TicketTitle}}\nRun the tests before {{.TicketID}} is done."; harness.PromptTemplate != want {
+		t.Errorf("Expected fragments expanded in prompt_template, got %q", harness.PromptTemplate)
+	}
+	if harness.PromptTemplates["bug"] != "Fix {{.TicketID}}: {{.TicketTitle}}" {
+		t.Errorf("Expected fragments expanded in prompt_templates, got %q", harness.PromptTemplates["bug"])
+	}
+	if !strings.Contains(harness.CommandTemplate, `{{- template "flags" .}}`) {
+		t.Errorf("Expected an unknown name to be left to text/template, got %q", harness.CommandTemplate)
+	}
+	if !slices.Contains(cfg.Files, rulesPath) {
+		t.Errorf("Expected the fragment file among the config files, got %v", cfg.Files)
+	}
+
+	prompt, _, err := NewRenderer().RenderPrompt(harness, nil, domain.TemplateContext{TicketID: "bb-1", TicketTitle: "Fix it"})
+	if err != nil {
+		t.Fatalf("RenderPrompt() error = %v", err)
+	}
+	if prompt != "Work on bb-1: Fix it\nRun the tests before bb-1 is done." {
+		t.Errorf("Unexpected rendered prompt: %q", prompt)
+	}
+}
+
+func TestYAMLLoader_Load_TemplateFragments_TrimMarkers(t *testing.T) {
+	configPath := writeTestConfig(t, t.TempDir(), `
+templates:
+  id: "{{.TicketID}}"
+harnesses:
+  - name: claude
+    command_template: "claude [ {{- template \"id\" -}} ]"
+`)
+	cfg, err := NewYAMLLoader().Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	cmd, err := NewRenderer().RenderCommand(cfg.Harnesses[0], domain.TemplateContext{TicketID: "bb-1"})
+	if err != nil {
+		t.Fatalf("RenderCommand() error = %v", err)
+	}
+	if cmd != "claude [bb-1]" {
+		t.Errorf("Expected trim markers to be kept, got %q", cmd)
+	}
+}
+
+func TestYAMLLoader_Load_TemplateFragments_Cycle(t *testing.T) {
+	configPath := writeTestConfig(t, t.TempDir(), `
+templates:
+  a: "{{template \"b\"}}"
+  b: "{{template \"a\"}}"
+harnesses:
+  - name: claude
+    command_template: claude
+`)
+	_, err := NewYAMLLoader().Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "fragment cycle a -> b -> a") {
+		t.Errorf("Expected a fragment cycle error, got %v", err)
+	}
+}
+
 func TestYAMLLoader_SaveAndLoad(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "test_save.yaml")
@@ -1093,3 +1685,176 @@ func TestYAMLLoader_SaveEmptyConfig(t *testing.T) {
 		t.Errorf("Expected 1 harness, got %d", len(loadedCfg.Harnesses))
 	}
 }
+
+func TestYAMLLoader_Load_ProjectEnvFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(tmpDir, "api"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	configPath := writeTestConfig(t, tmpDir, `
+harnesses:
+  - name: claude
+    command_template: claude
+workspaces:
+  default:
+    projects:
+      - dir: ./api
+        env_file: .env.local
+`)
+	cfg, err := NewYAMLLoader().Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if want := filepath.Join(tmpDir, "api", ".env.local"); cfg.Workspace.Projects[0].EnvFile != want {
+		t.Errorf("Expected env_file relative to the project, got %q", cfg.Workspace.Projects[0].EnvFile)
+	}
+}
+
+func TestYAMLLoader_Load_Hooks(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(tmpDir, "api"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	configPath := writeTestConfig(t, tmpDir, `
+templates:
+  sync: bd sync
+harnesses:
+  - name: claude
+    command_template: claude
+    hooks:
+      pre_launch: ['{{template "sync"}}', "  "]
+      post_exit: [go test ./...]
+  - name: claude-opus
+    extends: claude
+workspaces:
+  default:
+    projects:
+      - dir: ./api
+        hooks:
+          pre_launch: [cp ../.env .]
+`)
+	cfg, err := NewYAMLLoader().Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	hooks := cfg.Harnesses[0].Hooks
+	if len(hooks.PreLaunch) != 1 || hooks.PreLaunch[0] != "bd sync" || len(hooks.PostExit) != 1 {
+		t.Errorf("Expected fragments expanded and blank hooks dropped, got %+v", hooks)
+	}
+	if len(cfg.Harnesses[1].Hooks.PostExit) != 1 {
+		t.Errorf("Expected the hooks inherited, got %+v", cfg.Harnesses[1].Hooks)
+	}
+	if p := cfg.Workspace.Projects[0].Hooks; len(p.PreLaunch) != 1 || p.PreLaunch[0] != "cp ../.env ." {
+		t.Errorf("Unexpected project hooks: %+v", p)
+	}
+}
+
+func TestYAMLLoader_Load_Verify(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, dir := range []string{"api", "web"} {
+		if err := os.Mkdir(filepath.Join(tmpDir, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	configPath := writeTestConfig(t, tmpDir, `
+harnesses:
+  - name: claude
+    command_template: claude
+workspaces:
+  default:
+    projects:
+      - dir: ./api
+        verify_command: "  go test ./...  "
+        verify_comment: true
+      - dir: ./web
+        verify_command: npm test
+        verify_on_exit: false
+`)
+	loader := NewYAMLLoader()
+	cfg, err := loader.Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := []domain.Verify{
+		{Command: "go test ./...", Comment: true},
+		{Command: "npm test", Manual: true},
+	}
+	for i, w := range want {
+		if got := cfg.Workspace.Projects[i].Verify; got != w {
+			t.Errorf("Project %d verify = %+v, want %+v", i, got, w)
+		}
+	}
+
+	if err := loader.Save(configPath, cfg); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	saved, err := loader.Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() after Save() error = %v", err)
+	}
+	for i, w := range want {
+		if got := saved.Workspace.Projects[i].Verify; got != w {
+			t.Errorf("Saved project %d verify = %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestYAMLLoader_Load_ConcurrencyLimits(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := writeTestConfig(t, tmpDir, `
+harnesses:
+  - name: claude
+    command_template: claude
+    max_concurrent_agents: 2
+  - name: claude-fast
+    extends: claude
+general:
+  max_concurrent_agents: 4
+  max_concurrent_per_model:
+    opus: 1
+`)
+	loader := NewYAMLLoader()
+	cfg, err := loader.Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	check := func(label string, cfg *domain.Config) {
+		t.Helper()
+		for i, h := range cfg.Harnesses {
+			if h.MaxConcurrentAgents != 2 {
+				t.Errorf("%s harness %d max_concurrent_agents = %d, want 2", label, i, h.MaxConcurrentAgents)
+			}
+		}
+		if cfg.General.MaxConcurrentAgents != 4 {
+			t.Errorf("%s max_concurrent_agents = %d, want 4", label, cfg.General.MaxConcurrentAgents)
+		}
+		if got := cfg.General.MaxConcurrentPerModel["opus"]; got != 1 {
+			t.Errorf("%s max_concurrent_per_model[opus] = %d, want 1", label, got)
+		}
+	}
+	check("Loaded", cfg)
+
+	if err := loader.Save(configPath, cfg); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	saved, err := loader.Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() after Save() error = %v", err)
+	}
+	check("Saved", saved)
+}
+
+func TestYAMLLoader_Load_NegativeConcurrencyLimit(t *testing.T) {
+	configPath := writeTestConfig(t, t.TempDir(), `
+harnesses:
+  - name: claude
+    command_template: claude
+general:
+  max_concurrent_per_model:
+    opus: -1
+`)
+	_, err := NewYAMLLoader().Load(configPath)
+	if err == nil || !strings.Contains(err.Error(), "max_concurrent_per_model") {
+		t.Errorf("Expected max_concurrent_per_model error, got %v", err)
+	}
+}
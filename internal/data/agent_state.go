@@ -0,0 +1,87 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package data
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// DefaultRunningAgentMaxAge is how long a running agent may go unseen before
+// DeleteStaleRunningAgents removes it.
+const DefaultRunningAgentMaxAge = time.Hour
+
+// AgentStateStore persists launched agents and the history of finished runs.
+// Implementations: the Beads Dolt database (dolt.Store) and a local file
+// under the state directory (localstate.Store).
+type AgentStateStore interface {
+	// Host is the identity rows are recorded and validated against.
+	Host() domain.HostIdentity
+
+	UpsertRunningAgent(ctx context.Context, a domain.PersistedRunningAgent) error
+	ListRunningAgentsByProjects(ctx context.Context, projectDirs []string) ([]domain.PersistedRunningAgent, error)
+	FinishRunningAgent(ctx context.Context, launcherID string, pid int, status domain.AgentStatus, exitCode *int) error
+	ValidateAndPruneRunningAgents(ctx context.Context, projectDirs []string, inspector ProcessInspector) ([]domain.PersistedRunningAgent, error)
+	DeleteStaleRunningAgents(ctx context.Context, maxAge time.Duration) error
+
+	RecordAgentSession(ctx context.Context, s domain.AgentSession) error
+	ListAgentSessions(ctx context.Context, projectDirs []string, limit int) ([]domain.AgentSession, error)
+}
+
+// ProcessInspector provides process existence and command lookup.
+type ProcessInspector interface {
+	PIDExists(pid int) bool
+	CommandForPID(ctx context.Context, pid int) (string, error)
+}
+
+// HostProcessInspector inspects processes on the local machine.
+type HostProcessInspector struct{}
+
+// PIDExists reports whether a process with the PID exists.
+func (HostProcessInspector) PIDExists(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, syscall.Signal(0))
+	return err == nil || err == syscall.EPERM
+}
+
+// CommandForPID returns the command line of the process.
+func (HostProcessInspector) CommandForPID(ctx context.Context, pid int) (string, error) {
+	cmd := exec.CommandContext(ctx, "ps", "-p", strconv.Itoa(pid), "-o", "command=")
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// AgentProcessAlive reports whether a running agent's process still exists
+// and still runs its harness, so a recycled PID is not mistaken for the agent.
+func AgentProcessAlive(ctx context.Context, a domain.PersistedRunningAgent, inspector ProcessInspector) bool {
+	if !inspector.PIDExists(a.PID) {
+		return false
+	}
+
+	cmd, err := inspector.CommandForPID(ctx, a.PID)
+	if err != nil {
+		return false
+	}
+
+	candidates := config.HarnessBinaryCandidates(a.HarnessName)
+	if a.HarnessBinary != "" {
+		candidates = append(candidates, a.HarnessBinary)
+	}
+	return config.CommandMatchesAnyBinary(cmd, candidates)
+}
@@ -8,7 +8,8 @@
 //
 // This package contains interfaces and implementations for accessing
 // ticket/issue data from various sources, primarily the Dolt database
-// used by Beads. It isolates the rest of the application from database
+// used by Beads, or the issues.jsonl export of projects without one
+// (package jsonl). It isolates the rest of the application from database
 // specifics and provides fakes for testing.
 //
 // The primary interface is TicketStore, which abstracts ticket retrieval.
@@ -2,78 +2,20 @@ package dolt
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"os/exec"
-	"strconv"
 	"strings"
-	"syscall"
 	"time"
 
-	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/data"
 	"github.com/megatherium/blunderbust/internal/domain"
 )
 
-const defaultRunningAgentMaxAge = time.Hour
+// Verify interface compliance at compile time.
+var _ data.AgentStateStore = (*Store)(nil)
 
-// ProcessInspector provides process existence and command lookup.
-type ProcessInspector interface {
-	PIDExists(pid int) bool
-	CommandForPID(ctx context.Context, pid int) (string, error)
-}
-
-type hostProcessInspector struct{}
-
-func (hostProcessInspector) PIDExists(pid int) bool {
-	if pid <= 0 {
-		return false
-	}
-	err := syscall.Kill(pid, syscall.Signal(0))
-	return err == nil || err == syscall.EPERM
-}
-
-func (hostProcessInspector) CommandForPID(ctx context.Context, pid int) (string, error) {
-	cmd := exec.CommandContext(ctx, "ps", "-p", strconv.Itoa(pid), "-o", "command=")
-	out, err := cmd.Output()
-	if err != nil {
-		return "", err
-	}
-	return strings.TrimSpace(string(out)), nil
-}
-
-// EnsureRunningAgentsTable ensures the running_agents table exists.
-func (s *Store) EnsureRunningAgentsTable(ctx context.Context) error {
-	const query = `
-CREATE TABLE IF NOT EXISTS running_agents (
-    id INT PRIMARY KEY AUTO_INCREMENT,
-    project_dir VARCHAR(255) NOT NULL,
-    worktree_path VARCHAR(255) NOT NULL,
-    pid INT NOT NULL,
-    launcher_type INT NOT NULL,
-    launcher_id VARCHAR(100),
-    ticket VARCHAR(100),
-    ticket_title TEXT,
-    harness_name VARCHAR(50) NOT NULL,
-    harness_binary VARCHAR(100),
-    model VARCHAR(50),
-    agent VARCHAR(50),
-    started_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    last_seen DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
-    UNIQUE KEY uniq_running_agent (project_dir, worktree_path, pid),
-    INDEX idx_running_agents_project_dir (project_dir),
-    INDEX idx_running_agents_last_seen (last_seen)
-) `
-	_, err := s.db.ExecContext(ctx, query)
-	if err != nil {
-		return fmt.Errorf("failed to ensure running_agents table: %w", err)
-	}
-	if err := ensureRunningAgentsTicketTitleColumn(ctx, s); err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// UpsertRunningAgent inserts or updates one running agent row.
+// UpsertRunningAgent inserts or updates one running agent row. Rows without
+// a machine ID are recorded as belonging to the store's host.
 func (s *Store) UpsertRunningAgent(ctx context.Context, a domain.PersistedRunningAgent) error {
 	if s.closed {
 		return fmt.Errorf("store is closed")
@@ -84,20 +26,39 @@ func (s *Store) UpsertRunningAgent(ctx context.Context, a domain.PersistedRunnin
 	if a.LauncherID == "" {
 		a.LauncherID = "unknown"
 	}
+	if a.MachineID == "" {
+		host := s.Host()
+		a.Hostname, a.MachineID, a.Owner = host.Hostname, host.MachineID, host.User
+	}
 	const query = `
 INSERT INTO running_agents (
-	project_dir, worktree_path, pid, launcher_type, launcher_id, ticket, ticket_title,
-	harness_name, harness_binary, model, agent, started_at, last_seen
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	project_dir, worktree_path, pid, launcher_type, launcher_id, window_id, session_name,
+	ticket, ticket_title, harness_name, harness_binary, model, agent,
+	rendered_command, rendered_prompt, pre_launch, post_exit, hook_dir,
+	hostname, machine_id, owner, started_at, last_seen
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 ON DUPLICATE KEY UPDATE
 	launcher_type = VALUES(launcher_type),
 	launcher_id = VALUES(launcher_id),
+	window_id = VALUES(window_id),
+	session_name = VALUES(session_name),
 	ticket = VALUES(ticket),
 	ticket_title = VALUES(ticket_title),
 	harness_name = VALUES(harness_name),
 	harness_binary = VALUES(harness_binary),
 	model = VALUES(model),
 	agent = VALUES(agent),
+	rendered_command = VALUES(rendered_command),
+	rendered_prompt = VALUES(rendered_prompt),
+	pre_launch = VALUES(pre_launch),
+	post_exit = VALUES(post_exit),
+	hook_dir = VALUES(hook_dir),
+	hostname = VALUES(hostname),
+	machine_id = VALUES(machine_id),
+	owner = VALUES(owner),
+	status = 0,
+	exit_code = NULL,
+	ended_at = NULL,
 	last_seen = CURRENT_TIMESTAMP`
 	_, err := s.db.ExecContext(ctx, query,
 		a.ProjectDir,
@@ -105,12 +66,22 @@ ON DUPLICATE KEY UPDATE
 		a.PID,
 		a.LauncherType,
 		a.LauncherID,
+		a.WindowID,
+		a.SessionName,
 		a.Ticket,
 		a.TicketTitle,
 		a.HarnessName,
 		a.HarnessBinary,
 		a.Model,
 		a.Agent,
+		a.RenderedCommand,
+		a.RenderedPrompt,
+		encodeHooks(a.PreLaunch),
+		encodeHooks(a.PostExit),
+		a.HookDir,
+		a.Hostname,
+		a.MachineID,
+		a.Owner,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to upsert running agent: %w", err)
@@ -119,6 +90,25 @@ ON DUPLICATE KEY UPDATE
 	return nil
 }
 
+// encodeHooks stores hooks as a JSON array; no hooks are stored as NULL.
+func encodeHooks(hooks []string) any {
+	if len(hooks) == 0 {
+		return nil
+	}
+	b, _ := json.Marshal(hooks)
+	return string(b)
+}
+
+// decodeHooks reads hooks stored by encodeHooks.
+func decodeHooks(s string) ([]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var hooks []string
+	err := json.Unmarshal([]byte(s), &hooks)
+	return hooks, err
+}
+
 // ListRunningAgentsByProjects returns running agents for the given project directories.
 func (s *Store) ListRunningAgentsByProjects(ctx context.Context, projectDirs []string) ([]domain.PersistedRunningAgent, error) {
 	if s.closed {
@@ -137,8 +127,11 @@ func (s *Store) ListRunningAgentsByProjects(ctx context.Context, projectDirs []s
 
 	query := fmt.Sprintf(`
 SELECT
-	id, project_dir, worktree_path, pid, launcher_type, launcher_id, ticket, ticket_title,
-	harness_name, harness_binary, model, agent, started_at, last_seen
+	id, project_dir, worktree_path, pid, launcher_type, launcher_id, window_id, session_name,
+	ticket, ticket_title, harness_name, harness_binary, model, agent, status, exit_code,
+	COALESCE(rendered_command, ''), COALESCE(rendered_prompt, ''),
+	COALESCE(pre_launch, ''), COALESCE(post_exit, ''), hook_dir, hostname, machine_id, owner,
+	started_at, ended_at, last_seen
 FROM running_agents
 WHERE project_dir IN (%s)
 ORDER BY started_at DESC`, strings.Join(placeholders, ", "))
@@ -152,6 +145,7 @@ ORDER BY started_at DESC`, strings.Join(placeholders, ", "))
 	var agents []domain.PersistedRunningAgent
 	for rows.Next() {
 		var a domain.PersistedRunningAgent
+		var preLaunch, postExit string
 		if err := rows.Scan(
 			&a.ID,
 			&a.ProjectDir,
@@ -159,17 +153,36 @@ ORDER BY started_at DESC`, strings.Join(placeholders, ", "))
 			&a.PID,
 			(*int)(&a.LauncherType),
 			&a.LauncherID,
+			&a.WindowID,
+			&a.SessionName,
 			&a.Ticket,
 			&a.TicketTitle,
 			&a.HarnessName,
 			&a.HarnessBinary,
 			&a.Model,
 			&a.Agent,
+			(*int)(&a.Status),
+			&a.ExitCode,
+			&a.RenderedCommand,
+			&a.RenderedPrompt,
+			&preLaunch,
+			&postExit,
+			&a.HookDir,
+			&a.Hostname,
+			&a.MachineID,
+			&a.Owner,
 			&a.StartedAt,
+			&a.EndedAt,
 			&a.LastSeen,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan running agent row: %w", err)
 		}
+		if a.PreLaunch, err = decodeHooks(preLaunch); err != nil {
+			return nil, fmt.Errorf("invalid pre_launch of running agent %d: %w", a.ID, err)
+		}
+		if a.PostExit, err = decodeHooks(postExit); err != nil {
+			return nil, fmt.Errorf("invalid post_exit of running agent %d: %w", a.ID, err)
+		}
 		agents = append(agents, a)
 	}
 
@@ -180,26 +193,33 @@ ORDER BY started_at DESC`, strings.Join(placeholders, ", "))
 	return agents, nil
 }
 
-func ensureRunningAgentsTicketTitleColumn(ctx context.Context, s *Store) error {
-	_, err := s.db.ExecContext(ctx, `ALTER TABLE running_agents ADD COLUMN ticket_title TEXT`)
-	if err == nil {
-		return nil
+// FinishRunningAgent records the final status of an agent identified by its
+// launcher ID and PID on this host. The row is kept as history rather than deleted.
+// exitCode may be nil when the exit status was not observed.
+func (s *Store) FinishRunningAgent(ctx context.Context, launcherID string, pid int, status domain.AgentStatus, exitCode *int) error {
+	if s.closed {
+		return fmt.Errorf("store is closed")
 	}
-	// Column already exists on upgraded/newer schemas. Dolt/MySQL variants:
-	// - "Duplicate column name 'ticket_title'"
-	// - `Column "ticket_title" already exists`
-	errMsg := strings.ToLower(err.Error())
-	if strings.Contains(errMsg, "duplicate column name") ||
-		(strings.Contains(errMsg, "ticket_title") && strings.Contains(errMsg, "already exists")) {
-		return nil
+	const query = `
+UPDATE running_agents
+SET status = ?, exit_code = ?, ended_at = CURRENT_TIMESTAMP, last_seen = CURRENT_TIMESTAMP
+WHERE launcher_id = ? AND pid = ? AND status = ? AND machine_id IN (?, '')`
+	_, err := s.db.ExecContext(ctx, query, int(status), exitCode, launcherID, pid, int(domain.AgentRunning), s.Host().MachineID)
+	if err != nil {
+		return fmt.Errorf("failed to finish running agent %s: %w", launcherID, err)
 	}
-	return fmt.Errorf("failed to ensure running_agents.ticket_title column: %w", err)
+	return nil
 }
 
-// ValidateAndPruneRunningAgents validates running agents and removes invalid rows.
-func (s *Store) ValidateAndPruneRunningAgents(ctx context.Context, projectDirs []string, inspector ProcessInspector) ([]domain.PersistedRunningAgent, error) {
+// ValidateAndPruneRunningAgents validates running agents and returns the live ones.
+// Rows that already finished are left untouched as history; rows whose process
+// is gone are marked completed instead of being deleted.
+//
+// Only agents running on this host can be checked against the process table.
+// Agents on other hosts sharing the database are returned as-is, unvalidated.
+func (s *Store) ValidateAndPruneRunningAgents(ctx context.Context, projectDirs []string, inspector data.ProcessInspector) ([]domain.PersistedRunningAgent, error) {
 	if inspector == nil {
-		inspector = hostProcessInspector{}
+		inspector = data.HostProcessInspector{}
 	}
 
 	agents, err := s.ListRunningAgentsByProjects(ctx, projectDirs)
@@ -207,13 +227,20 @@ func (s *Store) ValidateAndPruneRunningAgents(ctx context.Context, projectDirs [
 		return nil, err
 	}
 
+	host := s.Host()
 	valid := make([]domain.PersistedRunningAgent, 0, len(agents))
 	for _, a := range agents {
-		isValid, err := s.validateRunningAgent(ctx, a, inspector)
-		if err != nil {
-			return nil, err
+		if a.Status != domain.AgentRunning {
+			continue
+		}
+		if !a.RunsOn(host) {
+			valid = append(valid, a)
+			continue
 		}
-		if !isValid {
+		if !data.AgentProcessAlive(ctx, a, inspector) {
+			if err := s.markRunningAgentEnded(ctx, a); err != nil {
+				return nil, err
+			}
 			continue
 		}
 
@@ -226,47 +253,46 @@ func (s *Store) ValidateAndPruneRunningAgents(ctx context.Context, projectDirs [
 	return valid, nil
 }
 
-func (s *Store) validateRunningAgent(ctx context.Context, a domain.PersistedRunningAgent, inspector ProcessInspector) (bool, error) {
-	if !inspector.PIDExists(a.PID) {
-		return false, s.deleteRunningAgentByID(ctx, a.ID)
-	}
-
-	cmd, err := inspector.CommandForPID(ctx, a.PID)
-	if err != nil {
-		return false, s.deleteRunningAgentByID(ctx, a.ID)
-	}
-
-	candidates := config.HarnessBinaryCandidates(a.HarnessName)
-	if a.HarnessBinary != "" {
-		candidates = append(candidates, a.HarnessBinary)
-	}
-
-	if !config.CommandMatchesAnyBinary(cmd, candidates) {
-		return false, s.deleteRunningAgentByID(ctx, a.ID)
-	}
-
-	return true, nil
-}
-
-// DeleteStaleRunningAgents deletes rows older than maxAge by last_seen.
+// DeleteStaleRunningAgents deletes still-running rows older than maxAge by last_seen.
+// Finished rows are history and are not affected, and neither are rows of
+// other hosts, whose last_seen is only refreshed by their own instance.
 func (s *Store) DeleteStaleRunningAgents(ctx context.Context, maxAge time.Duration) error {
 	if maxAge <= 0 {
-		maxAge = defaultRunningAgentMaxAge
+		maxAge = data.DefaultRunningAgentMaxAge
 	}
 	cutoff := time.Now().UTC().Add(-maxAge)
-	_, err := s.db.ExecContext(ctx, `DELETE FROM running_agents WHERE last_seen < ?`, cutoff)
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM running_agents WHERE status = ? AND last_seen < ? AND machine_id IN (?, '')`,
+		int(domain.AgentRunning), cutoff, s.Host().MachineID)
 	if err != nil {
 		return fmt.Errorf("failed deleting stale running agents: %w", err)
 	}
 	return nil
 }
 
-func (s *Store) deleteRunningAgentByID(ctx context.Context, id int) error {
-	_, err := s.db.ExecContext(ctx, `DELETE FROM running_agents WHERE id = ?`, id)
+// HasAgentState reports whether the database has the running_agents and
+// agent_sessions tables, so their rows can be imported into another
+// agent state backend.
+func (s *Store) HasAgentState(ctx context.Context) (bool, error) {
+	for _, table := range []string{"running_agents", "agent_sessions"} {
+		exists, err := s.hasTable(ctx, table)
+		if err != nil || !exists {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// markRunningAgentEnded marks an agent whose process disappeared while
+// nobody was watching as completed and records it in the session history.
+// The exit code is unknown and the end time is approximated by the last time
+// the agent was seen alive.
+func (s *Store) markRunningAgentEnded(ctx context.Context, a domain.PersistedRunningAgent) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE running_agents SET status = ?, ended_at = last_seen WHERE id = ?`, int(domain.AgentCompleted), a.ID)
 	if err != nil {
-		return fmt.Errorf("failed deleting running agent id=%d: %w", id, err)
+		return fmt.Errorf("failed marking running agent id=%d ended: %w", a.ID, err)
 	}
-	return nil
+	return s.RecordAgentSession(ctx, a.EndedSession(domain.AgentCompleted))
 }
 
 func (s *Store) touchRunningAgentByID(ctx context.Context, id int) error {
@@ -28,47 +28,7 @@ func (f fakeInspector) CommandForPID(_ context.Context, pid int) (string, error)
 	return f.commands[pid], nil
 }
 
-func TestStore_EnsureRunningAgentsTable(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	if err != nil {
-		t.Fatalf("failed to create mock: %v", err)
-	}
-	defer db.Close()
-
-	store := &Store{db: db}
-	mock.ExpectExec("CREATE TABLE IF NOT EXISTS running_agents").
-		WillReturnResult(sqlmock.NewResult(0, 0))
-	mock.ExpectExec("ALTER TABLE running_agents ADD COLUMN ticket_title TEXT").
-		WillReturnError(errors.New("Error 1060: Duplicate column name 'ticket_title'"))
-
-	if err := store.EnsureRunningAgentsTable(context.Background()); err != nil {
-		t.Fatalf("expected nil error, got %v", err)
-	}
-	if err := mock.ExpectationsWereMet(); err != nil {
-		t.Fatalf("unfulfilled expectations: %v", err)
-	}
-}
-
-func TestStore_EnsureRunningAgentsTable_ColumnAlreadyExistsVariant(t *testing.T) {
-	db, mock, err := sqlmock.New()
-	if err != nil {
-		t.Fatalf("failed to create mock: %v", err)
-	}
-	defer db.Close()
-
-	store := &Store{db: db}
-	mock.ExpectExec("CREATE TABLE IF NOT EXISTS running_agents").
-		WillReturnResult(sqlmock.NewResult(0, 0))
-	mock.ExpectExec("ALTER TABLE running_agents ADD COLUMN ticket_title TEXT").
-		WillReturnError(errors.New(`Error 1105 (HY000): Column "ticket_title" already exists`))
-
-	if err := store.EnsureRunningAgentsTable(context.Background()); err != nil {
-		t.Fatalf("expected nil error, got %v", err)
-	}
-	if err := mock.ExpectationsWereMet(); err != nil {
-		t.Fatalf("unfulfilled expectations: %v", err)
-	}
-}
+var testHost = domain.HostIdentity{Hostname: "laptop", MachineID: "m-1", User: "alice"}
 
 func TestStore_UpsertRunningAgent(t *testing.T) {
 	db, mock, err := sqlmock.New()
@@ -77,23 +37,29 @@ func TestStore_UpsertRunningAgent(t *testing.T) {
 	}
 	defer db.Close()
 
-	store := &Store{db: db}
+	store := &Store{db: db, host: &testHost}
 	agent := domain.PersistedRunningAgent{
-		ProjectDir:    "/repo",
-		WorktreePath:  "/repo",
-		PID:           1234,
-		LauncherType:  domain.LauncherTypeTmux,
-		LauncherID:    "bb-1",
-		Ticket:        "bb-1",
-		TicketTitle:   "Test ticket",
-		HarnessName:   "kilocode",
-		HarnessBinary: "kilo",
-		Model:         "m",
-		Agent:         "a",
+		ProjectDir:      "/repo",
+		WorktreePath:    "/repo",
+		PID:             1234,
+		LauncherType:    domain.LauncherTypeTmux,
+		LauncherID:      "bb-1",
+		WindowID:        "@3",
+		SessionName:     "blunderbust",
+		Ticket:          "bb-1",
+		TicketTitle:     "Test ticket",
+		HarnessName:     "kilocode",
+		HarnessBinary:   "kilo",
+		Model:           "m",
+		Agent:           "a",
+		RenderedCommand: "kilo run",
+		RenderedPrompt:  "Work on bb-1",
+		PostExit:        []string{"make clean"},
+		HookDir:         "/repo",
 	}
 
 	mock.ExpectExec("INSERT INTO running_agents").
-		WithArgs("/repo", "/repo", 1234, domain.LauncherTypeTmux, "bb-1", "bb-1", "Test ticket", "kilocode", "kilo", "m", "a").
+		WithArgs("/repo", "/repo", 1234, domain.LauncherTypeTmux, "bb-1", "@3", "blunderbust", "bb-1", "Test ticket", "kilocode", "kilo", "m", "a", "kilo run", "Work on bb-1", nil, `["make clean"]`, "/repo", "laptop", "m-1", "alice").
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	if err := store.UpsertRunningAgent(context.Background(), agent); err != nil {
@@ -111,17 +77,20 @@ func TestStore_ListRunningAgentsByProjects(t *testing.T) {
 	}
 	defer db.Close()
 
-	store := &Store{db: db}
+	store := &Store{db: db, host: &testHost}
 	now := time.Now().UTC()
 	rows := sqlmock.NewRows([]string{
-		"id", "project_dir", "worktree_path", "pid", "launcher_type", "launcher_id", "ticket", "ticket_title",
-		"harness_name", "harness_binary", "model", "agent", "started_at", "last_seen",
-	}).AddRow(1, "/repo", "/repo", 555, int(domain.LauncherTypeTmux), "bb-1", "bb-1", "Title 1", "kilocode", "kilo", "m", "a", now, now)
+		"id", "project_dir", "worktree_path", "pid", "launcher_type", "launcher_id", "window_id", "session_name", "ticket", "ticket_title",
+		"harness_name", "harness_binary", "model", "agent", "status", "exit_code", "rendered_command", "rendered_prompt", "pre_launch", "post_exit", "hook_dir", "hostname", "machine_id", "owner", "started_at", "ended_at", "last_seen",
+	}).AddRow(1, "/repo", "/repo", 555, int(domain.LauncherTypeTmux), "bb-1", "@1", "blunderbust", "bb-1", "Title 1", "kilocode", "kilo", "m", "a", 0, nil, "kilo run", "Work on bb-1", `["git pull"]`, `["make clean"]`, "/repo", "laptop", "m-1", "alice", now, nil, now)
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
 SELECT
-	id, project_dir, worktree_path, pid, launcher_type, launcher_id, ticket, ticket_title,
-	harness_name, harness_binary, model, agent, started_at, last_seen
+	id, project_dir, worktree_path, pid, launcher_type, launcher_id, window_id, session_name,
+	ticket, ticket_title, harness_name, harness_binary, model, agent, status, exit_code,
+	COALESCE(rendered_command, ''), COALESCE(rendered_prompt, ''),
+	COALESCE(pre_launch, ''), COALESCE(post_exit, ''), hook_dir, hostname, machine_id, owner,
+	started_at, ended_at, last_seen
 FROM running_agents
 WHERE project_dir IN (?)
 ORDER BY started_at DESC`)).
@@ -138,6 +107,15 @@ ORDER BY started_at DESC`)).
 	if got[0].TicketTitle != "Title 1" {
 		t.Fatalf("expected ticket title to be restored, got %q", got[0].TicketTitle)
 	}
+	if got[0].WindowID != "@1" || got[0].SessionName != "blunderbust" {
+		t.Fatalf("expected window ID and session to be restored, got %q/%q", got[0].WindowID, got[0].SessionName)
+	}
+	if got[0].RenderedCommand != "kilo run" || got[0].RenderedPrompt != "Work on bb-1" {
+		t.Fatalf("expected rendered command and prompt to be restored, got %q/%q", got[0].RenderedCommand, got[0].RenderedPrompt)
+	}
+	if len(got[0].PreLaunch) != 1 || len(got[0].PostExit) != 1 || got[0].PostExit[0] != "make clean" || got[0].HookDir != "/repo" {
+		t.Fatalf("expected hooks to be restored, got %q/%q in %q", got[0].PreLaunch, got[0].PostExit, got[0].HookDir)
+	}
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Fatalf("unfulfilled expectations: %v", err)
 	}
@@ -150,15 +128,17 @@ func TestStore_ValidateAndPruneRunningAgents(t *testing.T) {
 	}
 	defer db.Close()
 
-	store := &Store{db: db}
+	store := &Store{db: db, host: &testHost}
 	now := time.Now().UTC()
 	rows := sqlmock.NewRows([]string{
-		"id", "project_dir", "worktree_path", "pid", "launcher_type", "launcher_id", "ticket", "ticket_title",
-		"harness_name", "harness_binary", "model", "agent", "started_at", "last_seen",
+		"id", "project_dir", "worktree_path", "pid", "launcher_type", "launcher_id", "window_id", "session_name", "ticket", "ticket_title",
+		"harness_name", "harness_binary", "model", "agent", "status", "exit_code", "rendered_command", "rendered_prompt", "pre_launch", "post_exit", "hook_dir", "hostname", "machine_id", "owner", "started_at", "ended_at", "last_seen",
 	}).
-		AddRow(1, "/repo", "/repo", 101, int(domain.LauncherTypeTmux), "bb-1", "bb-1", "Title 1", "kilocode", "kilo", "m", "a", now, now).
-		AddRow(2, "/repo", "/repo", 202, int(domain.LauncherTypeTmux), "bb-2", "bb-2", "Title 2", "codex", "codex", "m", "a", now, now).
-		AddRow(3, "/repo", "/repo", 303, int(domain.LauncherTypeTmux), "bb-3", "bb-3", "Title 3", "codex", "codex", "m", "a", now, now)
+		AddRow(1, "/repo", "/repo", 101, int(domain.LauncherTypeTmux), "bb-1", "@1", "blunderbust", "bb-1", "Title 1", "kilocode", "kilo", "m", "a", 0, nil, "", "", "", "", "", "laptop", "m-1", "alice", now, nil, now).
+		AddRow(2, "/repo", "/repo", 202, int(domain.LauncherTypeTmux), "bb-2", "@2", "blunderbust", "bb-2", "Title 2", "codex", "codex", "m", "a", 0, nil, "", "", "", "", "", "laptop", "m-1", "alice", now, nil, now).
+		AddRow(3, "/repo", "/repo", 303, int(domain.LauncherTypeTmux), "bb-3", "", "", "bb-3", "Title 3", "codex", "codex", "m", "a", 0, nil, "", "", "", "", "", "laptop", "m-1", "alice", now, nil, now).
+		AddRow(4, "/repo", "/repo", 404, int(domain.LauncherTypeTmux), "bb-4", "@4", "blunderbust", "bb-4", "Title 4", "codex", "codex", "m", "a", int(domain.AgentFailed), 2, "", "", "", "", "", "laptop", "m-1", "alice", now, now, now).
+		AddRow(5, "/repo", "/repo", 505, int(domain.LauncherTypeTmux), "bb-5", "@5", "blunderbust", "bb-5", "Title 5", "codex", "codex", "m", "a", 0, nil, "", "", "", "", "", "desktop", "m-2", "bob", now, nil, now)
 
 	mock.ExpectQuery("FROM running_agents").
 		WithArgs("/repo").
@@ -166,12 +146,16 @@ func TestStore_ValidateAndPruneRunningAgents(t *testing.T) {
 	mock.ExpectExec("UPDATE running_agents SET last_seen = CURRENT_TIMESTAMP WHERE id = \\?").
 		WithArgs(1).
 		WillReturnResult(sqlmock.NewResult(0, 1))
-	mock.ExpectExec("DELETE FROM running_agents WHERE id = \\?").
-		WithArgs(2).
+	mock.ExpectExec("UPDATE running_agents SET status = \\?, ended_at = last_seen WHERE id = \\?").
+		WithArgs(int(domain.AgentCompleted), 2).
 		WillReturnResult(sqlmock.NewResult(0, 1))
-	mock.ExpectExec("DELETE FROM running_agents WHERE id = \\?").
-		WithArgs(3).
+	mock.ExpectExec("INSERT INTO agent_sessions").
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec("UPDATE running_agents SET status = \\?, ended_at = last_seen WHERE id = \\?").
+		WithArgs(int(domain.AgentCompleted), 3).
 		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO agent_sessions").
+		WillReturnResult(sqlmock.NewResult(3, 1))
 
 	inspector := fakeInspector{
 		exists: map[int]bool{
@@ -191,9 +175,14 @@ func TestStore_ValidateAndPruneRunningAgents(t *testing.T) {
 	if err != nil {
 		t.Fatalf("expected nil error, got %v", err)
 	}
-	if len(valid) != 1 || valid[0].ID != 1 {
+	// Row 5 runs on another host: it is returned without being validated,
+	// touched or marked ended.
+	if len(valid) != 2 || valid[0].ID != 1 || valid[1].ID != 5 {
 		t.Fatalf("unexpected valid rows: %+v", valid)
 	}
+	if valid[1].RunsOn(testHost) || valid[1].Owner != "bob" {
+		t.Fatalf("expected remote row to keep its host and owner, got %+v", valid[1])
+	}
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Fatalf("unfulfilled expectations: %v", err)
 	}
@@ -206,9 +195,9 @@ func TestStore_DeleteStaleRunningAgents(t *testing.T) {
 	}
 	defer db.Close()
 
-	store := &Store{db: db}
-	mock.ExpectExec("DELETE FROM running_agents WHERE last_seen < \\?").
-		WithArgs(sqlmock.AnyArg()).
+	store := &Store{db: db, host: &testHost}
+	mock.ExpectExec("DELETE FROM running_agents WHERE status = \\? AND last_seen < \\? AND machine_id IN \\(\\?, ''\\)").
+		WithArgs(int(domain.AgentRunning), sqlmock.AnyArg(), "m-1").
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	if err := store.DeleteStaleRunningAgents(context.Background(), time.Hour); err != nil {
@@ -218,3 +207,24 @@ func TestStore_DeleteStaleRunningAgents(t *testing.T) {
 		t.Fatalf("unfulfilled expectations: %v", err)
 	}
 }
+
+func TestStore_FinishRunningAgent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db, host: &testHost}
+	exitCode := 3
+	mock.ExpectExec("UPDATE running_agents").
+		WithArgs(int(domain.AgentFailed), 3, "bb-1", 1234, int(domain.AgentRunning), "m-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	if err := store.FinishRunningAgent(context.Background(), "bb-1", 1234, domain.AgentFailed, &exitCode); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
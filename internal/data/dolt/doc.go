@@ -60,6 +60,11 @@
 // Both modes query the ready_issues view which filters for unblocked,
 // non-deferred, non-ephemeral issues.
 //
-// Note: This package provides read-only access. Blunderbust never writes
-// to the beads database.
+// # Schema Migrations
+//
+// Blunderbust never writes to the Beads tables, but it owns running_agents and
+// agent_sessions in the same database. Their schema is versioned by ordered,
+// idempotent migrations recorded in blunderbust_schema_version; each one is
+// applied in its own Dolt commit. NewStore applies pending migrations unless
+// AppOptions.SkipMigrations is set; see Migrate and PendingMigrations.
 package dolt
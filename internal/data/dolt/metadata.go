@@ -73,6 +73,13 @@ func (m *Metadata) ServerReadyTimeout() time.Duration {
 	return 10 * time.Second
 }
 
+// MetadataExists reports whether beadsDir has a metadata.json, i.e. whether
+// the project has a Dolt database.
+func MetadataExists(beadsDir string) bool {
+	_, err := os.Stat(filepath.Join(beadsDir, "metadata.json"))
+	return err == nil
+}
+
 // LoadMetadata reads and parses the metadata.json file from the given beads directory.
 // Returns actionable errors for common failure scenarios.
 func LoadMetadata(beadsDir string) (*Metadata, error) {
@@ -0,0 +1,406 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// schemaVersionTable records which migrations have been applied.
+const schemaVersionTable = "blunderbust_schema_version"
+
+// Migration is one ordered schema change to the tables Blunderbust owns in
+// the Beads database. Migrations must be idempotent: databases created before
+// versioning already have some of the changes, and a migration interrupted
+// before its version was recorded is re-run in full.
+type Migration struct {
+	Version     int
+	Description string
+
+	// tables are staged in the Dolt commit that records the migration.
+	tables []string
+	apply  func(ctx context.Context, conn migrationConn) error
+}
+
+// migrationConn is the subset of *sql.Conn (and *sql.DB) migrations use.
+type migrationConn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// migrations lists every schema change in the order it is applied. Append
+// new migrations with the next version; never edit or reorder released ones.
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "create running_agents",
+		tables:      []string{"running_agents"},
+		apply:       createRunningAgentsTable,
+	},
+	{
+		Version:     2,
+		Description: "add running_agents columns from unversioned releases",
+		tables:      []string{"running_agents"},
+		apply: func(ctx context.Context, conn migrationConn) error {
+			return addColumns(ctx, conn, "running_agents", runningAgentsAddedColumns)
+		},
+	},
+	{
+		Version:     3,
+		Description: "create agent_sessions",
+		tables:      []string{"agent_sessions"},
+		apply:       createAgentSessionsTable,
+	},
+	{
+		Version:     4,
+		Description: "record the host of running agents",
+		tables:      []string{"running_agents"},
+		apply:       addRunningAgentsHostColumns,
+	},
+	{
+		Version:     5,
+		Description: "record the hooks of running agents",
+		tables:      []string{"running_agents"},
+		apply: func(ctx context.Context, conn migrationConn) error {
+			return addColumns(ctx, conn, "running_agents", []addedColumn{
+				{"pre_launch", "TEXT"},
+				{"post_exit", "TEXT"},
+				{"hook_dir", "VARCHAR(512) NOT NULL DEFAULT ''"},
+			})
+		},
+	},
+}
+
+// Migrations returns all known migrations in order.
+func Migrations() []Migration {
+	return append([]Migration(nil), migrations...)
+}
+
+// SchemaVersion returns the highest applied migration version, or 0 when the
+// database has never been migrated.
+func (s *Store) SchemaVersion(ctx context.Context) (int, error) {
+	applied, err := s.appliedMigrations(ctx)
+	if err != nil {
+		return 0, err
+	}
+	version := 0
+	for v := range applied {
+		version = max(version, v)
+	}
+	return version, nil
+}
+
+// PendingMigrations returns the migrations that have not been applied yet,
+// without changing the database.
+func (s *Store) PendingMigrations(ctx context.Context) ([]Migration, error) {
+	if s.closed {
+		return nil, fmt.Errorf("store is closed")
+	}
+	applied, err := s.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, m := range migrations {
+		if !applied[m.Version] {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Migrate applies pending migrations in order and returns the ones applied.
+// Each migration and its version row are recorded in their own Dolt commit,
+// so a failure leaves the database at the last completed version.
+func (s *Store) Migrate(ctx context.Context) ([]Migration, error) {
+	if s.closed {
+		return nil, fmt.Errorf("store is closed")
+	}
+
+	// A single connection keeps the DDL, version row and Dolt commit of a
+	// migration in the same session.
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migration connection: %w", err)
+	}
+	defer conn.Close()
+
+	const createVersionTable = `
+CREATE TABLE IF NOT EXISTS ` + schemaVersionTable + ` (
+    version INT PRIMARY KEY,
+    description VARCHAR(255) NOT NULL,
+    applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+) `
+	if _, err := conn.ExecContext(ctx, createVersionTable); err != nil {
+		return nil, fmt.Errorf("failed to ensure %s table: %w", schemaVersionTable, err)
+	}
+
+	pending, err := s.PendingMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]Migration, 0, len(pending))
+	for _, m := range pending {
+		if err := m.apply(ctx, conn); err != nil {
+			return applied, fmt.Errorf("migration %d (%s): %w", m.Version, m.Description, err)
+		}
+		// INSERT IGNORE: another instance sharing the server may have
+		// applied the same migration concurrently.
+		if _, err := conn.ExecContext(ctx,
+			`INSERT IGNORE INTO `+schemaVersionTable+` (version, description) VALUES (?, ?)`,
+			m.Version, m.Description); err != nil {
+			return applied, fmt.Errorf("migration %d (%s): failed to record version: %w", m.Version, m.Description, err)
+		}
+		if err := doltCommitMigration(ctx, conn, m); err != nil {
+			return applied, fmt.Errorf("migration %d (%s): %w", m.Version, m.Description, err)
+		}
+		applied = append(applied, m)
+	}
+	return applied, nil
+}
+
+// appliedMigrations returns the recorded migration versions. A database
+// without the version table has none.
+func (s *Store) appliedMigrations(ctx context.Context) (map[int]bool, error) {
+	exists, err := s.hasTable(ctx, schemaVersionTable)
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[int]bool)
+	if !exists {
+		return applied, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `SELECT version FROM `+schemaVersionTable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schema versions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			return nil, fmt.Errorf("failed to scan schema version: %w", err)
+		}
+		applied[v] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating schema versions: %w", err)
+	}
+	return applied, nil
+}
+
+// hasTable reports whether the current database has the named table.
+func (s *Store) hasTable(ctx context.Context, name string) (bool, error) {
+	var tables int
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?`,
+		name).Scan(&tables); err != nil {
+		return false, fmt.Errorf("failed to check for %s table: %w", name, err)
+	}
+	return tables > 0, nil
+}
+
+// doltCommitMigration commits the migration's tables and the version row.
+func doltCommitMigration(ctx context.Context, conn migrationConn, m Migration) error {
+	tables := append([]string{schemaVersionTable}, m.tables...)
+	msg := fmt.Sprintf("blunderbust: schema migration %d: %s", m.Version, m.Description)
+	return doltCommit(ctx, conn, msg, tables...)
+}
+
+// doltCommit stages tables and commits them with msg. Only these tables are
+// committed so unrelated changes in the Beads database are left alone: other
+// tables that were already staged are unstaged for the commit and staged
+// again afterwards.
+func doltCommit(ctx context.Context, conn migrationConn, msg string, tables ...string) (err error) {
+	var staged string
+	if err := conn.QueryRowContext(ctx,
+		`SELECT COALESCE(GROUP_CONCAT(table_name), '') FROM dolt_status WHERE staged = 1`).Scan(&staged); err != nil {
+		return fmt.Errorf("failed to read staged tables: %w", err)
+	}
+	var others []string
+	for _, t := range strings.Split(staged, ",") {
+		if t != "" && !slices.Contains(tables, t) && !slices.Contains(others, t) {
+			others = append(others, t)
+		}
+	}
+	if len(others) > 0 {
+		if err := callTables(ctx, conn, "DOLT_RESET", others); err != nil {
+			return fmt.Errorf("failed to unstage other tables: %w", err)
+		}
+		defer func() {
+			if restageErr := callTables(ctx, conn, "DOLT_ADD", others); restageErr != nil && err == nil {
+				err = fmt.Errorf("failed to restage other tables: %w", restageErr)
+			}
+		}()
+	}
+
+	if err := callTables(ctx, conn, "DOLT_ADD", tables); err != nil {
+		return fmt.Errorf("failed to stage tables: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, "CALL DOLT_COMMIT('-m', ?)", msg); err != nil {
+		// The changes may already be committed, e.g. by a concurrent run.
+		if strings.Contains(strings.ToLower(err.Error()), "nothing to commit") {
+			return nil
+		}
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
+}
+
+// callTables calls the Dolt procedure with the table names as arguments.
+func callTables(ctx context.Context, conn migrationConn, procedure string, tables []string) error {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(tables)), ", ")
+	args := make([]any, len(tables))
+	for i, t := range tables {
+		args[i] = t
+	}
+	_, err := conn.ExecContext(ctx, "CALL "+procedure+"("+placeholders+")", args...)
+	return err
+}
+
+// addedColumn is a column added to a table after it was first created.
+type addedColumn struct {
+	name       string
+	definition string
+}
+
+// addColumns adds each column, skipping those that already exist.
+func addColumns(ctx context.Context, conn migrationConn, table string, columns []addedColumn) error {
+	for _, col := range columns {
+		if err := addColumn(ctx, conn, table, col.name, col.definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func addColumn(ctx context.Context, conn migrationConn, table, column, definition string) error {
+	_, err := conn.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	if err == nil {
+		return nil
+	}
+	// Column already exists on upgraded/newer schemas. Dolt/MySQL variants:
+	// - "Duplicate column name 'ticket_title'"
+	// - `Column "ticket_title" already exists`
+	errMsg := strings.ToLower(err.Error())
+	if strings.Contains(errMsg, "duplicate column name") ||
+		(strings.Contains(errMsg, column) && strings.Contains(errMsg, "already exists")) {
+		return nil
+	}
+	return fmt.Errorf("failed to add %s.%s column: %w", table, column, err)
+}
+
+func createRunningAgentsTable(ctx context.Context, conn migrationConn) error {
+	const query = `
+CREATE TABLE IF NOT EXISTS running_agents (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    project_dir VARCHAR(255) NOT NULL,
+    worktree_path VARCHAR(255) NOT NULL,
+    pid INT NOT NULL,
+    launcher_type INT NOT NULL,
+    launcher_id VARCHAR(100),
+    window_id VARCHAR(32) NOT NULL DEFAULT '',
+    session_name VARCHAR(100) NOT NULL DEFAULT '',
+    ticket VARCHAR(100),
+    ticket_title TEXT,
+    harness_name VARCHAR(50) NOT NULL,
+    harness_binary VARCHAR(100),
+    model VARCHAR(50),
+    agent VARCHAR(50),
+    status INT NOT NULL DEFAULT 0,
+    exit_code INT,
+    started_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ended_at DATETIME,
+    rendered_command TEXT,
+    rendered_prompt TEXT,
+    last_seen DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uniq_running_agent (project_dir, worktree_path, pid),
+    INDEX idx_running_agents_project_dir (project_dir),
+    INDEX idx_running_agents_last_seen (last_seen)
+) `
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create running_agents table: %w", err)
+	}
+	return nil
+}
+
+// runningAgentsAddedColumns lists columns added to running_agents before
+// migrations were versioned, in the order they were introduced.
+var runningAgentsAddedColumns = []addedColumn{
+	{"ticket_title", "TEXT"},
+	{"window_id", "VARCHAR(32) NOT NULL DEFAULT ''"},
+	{"session_name", "VARCHAR(100) NOT NULL DEFAULT ''"},
+	{"status", "INT NOT NULL DEFAULT 0"},
+	{"exit_code", "INT"},
+	{"ended_at", "DATETIME"},
+	{"rendered_command", "TEXT"},
+	{"rendered_prompt", "TEXT"},
+}
+
+func createAgentSessionsTable(ctx context.Context, conn migrationConn) error {
+	const query = `
+CREATE TABLE IF NOT EXISTS agent_sessions (
+    id INT PRIMARY KEY AUTO_INCREMENT,
+    project_dir VARCHAR(255) NOT NULL,
+    worktree_path VARCHAR(255) NOT NULL,
+    launcher_id VARCHAR(100),
+    ticket VARCHAR(100),
+    ticket_title TEXT,
+    harness_name VARCHAR(50) NOT NULL,
+    model VARCHAR(50),
+    agent VARCHAR(50),
+    status INT NOT NULL,
+    exit_code INT,
+    started_at DATETIME NOT NULL,
+    ended_at DATETIME NOT NULL,
+    output_log_path VARCHAR(512),
+    INDEX idx_agent_sessions_project_dir (project_dir),
+    INDEX idx_agent_sessions_started_at (started_at)
+) `
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create agent_sessions table: %w", err)
+	}
+	return nil
+}
+
+// addRunningAgentsHostColumns records which machine runs each agent and
+// makes PIDs unique per machine rather than per database.
+func addRunningAgentsHostColumns(ctx context.Context, conn migrationConn) error {
+	if err := addColumns(ctx, conn, "running_agents", []addedColumn{
+		{"hostname", "VARCHAR(255) NOT NULL DEFAULT ''"},
+		{"machine_id", "VARCHAR(64) NOT NULL DEFAULT ''"},
+		{"owner", "VARCHAR(100) NOT NULL DEFAULT ''"},
+	}); err != nil {
+		return err
+	}
+
+	var columns, scoped int
+	if err := conn.QueryRowContext(ctx, `
+SELECT COUNT(*), COALESCE(SUM(column_name = 'machine_id'), 0) FROM information_schema.statistics
+WHERE table_schema = DATABASE() AND table_name = 'running_agents' AND index_name = 'uniq_running_agent'`).
+		Scan(&columns, &scoped); err != nil {
+		return fmt.Errorf("failed to inspect uniq_running_agent: %w", err)
+	}
+	if scoped > 0 {
+		return nil
+	}
+	if columns > 0 {
+		if _, err := conn.ExecContext(ctx, `ALTER TABLE running_agents DROP INDEX uniq_running_agent`); err != nil {
+			return fmt.Errorf("failed to drop uniq_running_agent: %w", err)
+		}
+	}
+	if _, err := conn.ExecContext(ctx,
+		`ALTER TABLE running_agents ADD UNIQUE INDEX uniq_running_agent (project_dir, worktree_path, pid, machine_id)`); err != nil {
+		return fmt.Errorf("failed to recreate uniq_running_agent: %w", err)
+	}
+	return nil
+}
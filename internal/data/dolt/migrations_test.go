@@ -0,0 +1,269 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestMigrations_Ordered(t *testing.T) {
+	for i, m := range Migrations() {
+		if m.Version != i+1 {
+			t.Fatalf("migration %d has version %d, want %d", i, m.Version, i+1)
+		}
+		if m.Description == "" || m.apply == nil || len(m.tables) == 0 {
+			t.Fatalf("migration %d is incomplete: %+v", m.Version, m)
+		}
+	}
+}
+
+func TestAddColumns(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("ALTER TABLE running_agents ADD COLUMN ticket_title TEXT").
+		WillReturnError(errors.New("Error 1060: Duplicate column name 'ticket_title'"))
+	mock.ExpectExec("ALTER TABLE running_agents ADD COLUMN window_id").
+		WillReturnError(errors.New(`Error 1105 (HY000): Column "window_id" already exists`))
+	for _, col := range runningAgentsAddedColumns[2:] {
+		mock.ExpectExec("ALTER TABLE running_agents ADD COLUMN " + col.name).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+
+	if err := addColumns(context.Background(), db, "running_agents", runningAgentsAddedColumns); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestAddColumns_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("ALTER TABLE running_agents ADD COLUMN ticket_title TEXT").
+		WillReturnError(errors.New("table not found: running_agents"))
+
+	if err := addColumns(context.Background(), db, "running_agents", runningAgentsAddedColumns); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+// expectStagedTables expects doltCommit to look up the tables already
+// staged, given as Dolt lists them: comma-separated.
+func expectStagedTables(mock sqlmock.Sqlmock, staged string) {
+	mock.ExpectQuery("FROM dolt_status").
+		WillReturnRows(sqlmock.NewRows([]string{"tables"}).AddRow(staged))
+}
+
+func expectAppliedVersions(mock sqlmock.Sqlmock, versions ...int) {
+	if len(versions) == 0 {
+		mock.ExpectQuery("FROM information_schema.tables").
+			WithArgs(schemaVersionTable).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		return
+	}
+	mock.ExpectQuery("FROM information_schema.tables").
+		WithArgs(schemaVersionTable).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	rows := sqlmock.NewRows([]string{"version"})
+	for _, v := range versions {
+		rows.AddRow(v)
+	}
+	mock.ExpectQuery("SELECT version FROM " + schemaVersionTable).WillReturnRows(rows)
+}
+
+func TestStore_PendingMigrations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db}
+	expectAppliedVersions(mock)
+	expectAppliedVersions(mock, 1, 2)
+
+	pending, err := store.PendingMigrations(context.Background())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(pending) != len(migrations) {
+		t.Fatalf("expected all migrations pending on an unversioned database, got %d", len(pending))
+	}
+
+	pending, err = store.PendingMigrations(context.Background())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(pending) != len(migrations)-2 || pending[0].Version != 3 {
+		t.Fatalf("unexpected pending migrations: %+v", pending)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_Migrate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db}
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS " + schemaVersionTable).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectAppliedVersions(mock, 1, 2, 3)
+
+	for _, col := range []string{"hostname", "machine_id", "owner"} {
+		mock.ExpectExec("ALTER TABLE running_agents ADD COLUMN " + col).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectQuery("FROM information_schema.statistics").
+		WillReturnRows(sqlmock.NewRows([]string{"columns", "scoped"}).AddRow(3, 0))
+	mock.ExpectExec("ALTER TABLE running_agents DROP INDEX uniq_running_agent").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE running_agents ADD UNIQUE INDEX uniq_running_agent").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT IGNORE INTO "+schemaVersionTable).
+		WithArgs(4, "record the host of running agents").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectStagedTables(mock, "")
+	mock.ExpectExec("CALL DOLT_ADD").
+		WithArgs(schemaVersionTable, "running_agents").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CALL DOLT_COMMIT").
+		WithArgs("blunderbust: schema migration 4: record the host of running agents").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	for _, col := range []string{"pre_launch", "post_exit", "hook_dir"} {
+		mock.ExpectExec("ALTER TABLE running_agents ADD COLUMN " + col).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec("INSERT IGNORE INTO "+schemaVersionTable).
+		WithArgs(5, "record the hooks of running agents").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectStagedTables(mock, "")
+	mock.ExpectExec("CALL DOLT_ADD").
+		WithArgs(schemaVersionTable, "running_agents").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CALL DOLT_COMMIT").
+		WithArgs("blunderbust: schema migration 5: record the hooks of running agents").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	applied, err := store.Migrate(context.Background())
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(applied) != 2 || applied[0].Version != 4 || applied[1].Version != 5 {
+		t.Fatalf("unexpected applied migrations: %+v", applied)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_Migrate_StopsAtFailure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db}
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS " + schemaVersionTable).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	expectAppliedVersions(mock, 1)
+	mock.ExpectExec("ALTER TABLE running_agents ADD COLUMN ticket_title").
+		WillReturnError(errors.New("lock timeout"))
+
+	applied, err := store.Migrate(context.Background())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if len(applied) != 0 {
+		t.Fatalf("expected nothing applied, got %+v", applied)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDoltCommitMigration_NothingToCommit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	m := migrations[2]
+	expectStagedTables(mock, "")
+	mock.ExpectExec("CALL DOLT_ADD").
+		WithArgs(schemaVersionTable, "agent_sessions").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CALL DOLT_COMMIT").
+		WillReturnError(errors.New("nothing to commit"))
+
+	if err := doltCommitMigration(context.Background(), db, m); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
+
+func TestAddRunningAgentsHostColumns_AlreadyScoped(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	for _, col := range []string{"hostname", "machine_id", "owner"} {
+		mock.ExpectExec("ALTER TABLE running_agents ADD COLUMN " + col).
+			WillReturnError(errors.New("Error 1060: Duplicate column name '" + col + "'"))
+	}
+	mock.ExpectQuery("FROM information_schema.statistics").
+		WillReturnRows(sqlmock.NewRows([]string{"columns", "scoped"}).AddRow(4, 1))
+
+	if err := addRunningAgentsHostColumns(context.Background(), db); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDoltCommit_KeepsOtherStagedTables(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	expectStagedTables(mock, "issues,notes")
+	mock.ExpectExec(`CALL DOLT_RESET\(\?\)`).WithArgs("notes").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CALL DOLT_ADD\(\?\)`).WithArgs("issues").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CALL DOLT_COMMIT").WithArgs("msg").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`CALL DOLT_ADD\(\?\)`).WithArgs("notes").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := doltCommit(context.Background(), db, "msg", "issues"); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
@@ -63,11 +63,6 @@ func newServerStore(ctx context.Context, beadsDir string, metadata *Metadata, au
 		autostart: autostart,
 	}
 
-	if err := store.EnsureRunningAgentsTable(ctx); err != nil {
-		_ = db.Close()
-		return nil, err
-	}
-
 	return store, nil
 }
 
@@ -0,0 +1,119 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// RecordAgentSession appends one finished agent run to the history table.
+func (s *Store) RecordAgentSession(ctx context.Context, a domain.AgentSession) error {
+	if s.closed {
+		return fmt.Errorf("store is closed")
+	}
+	if a.ProjectDir == "" || a.HarnessName == "" || a.StartedAt.IsZero() || a.EndedAt.IsZero() {
+		return fmt.Errorf("invalid agent session data")
+	}
+	if a.WorktreePath == "" {
+		a.WorktreePath = a.ProjectDir
+	}
+	const query = `
+INSERT INTO agent_sessions (
+	project_dir, worktree_path, launcher_id, ticket, ticket_title, harness_name, model, agent,
+	status, exit_code, started_at, ended_at, output_log_path
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := s.db.ExecContext(ctx, query,
+		a.ProjectDir,
+		a.WorktreePath,
+		a.LauncherID,
+		a.Ticket,
+		a.TicketTitle,
+		a.HarnessName,
+		a.Model,
+		a.Agent,
+		int(a.Status),
+		a.ExitCode,
+		a.StartedAt.UTC(),
+		a.EndedAt.UTC(),
+		a.OutputLogPath,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record agent session: %w", err)
+	}
+	return nil
+}
+
+// ListAgentSessions returns the most recent agent sessions, newest first.
+// An empty projectDirs lists sessions for all projects; limit <= 0 means no limit.
+func (s *Store) ListAgentSessions(ctx context.Context, projectDirs []string, limit int) ([]domain.AgentSession, error) {
+	if s.closed {
+		return nil, fmt.Errorf("store is closed")
+	}
+
+	var where string
+	args := make([]any, 0, len(projectDirs)+1)
+	if len(projectDirs) > 0 {
+		placeholders := make([]string, 0, len(projectDirs))
+		for _, dir := range projectDirs {
+			placeholders = append(placeholders, "?")
+			args = append(args, dir)
+		}
+		where = fmt.Sprintf("\nWHERE project_dir IN (%s)", strings.Join(placeholders, ", "))
+	}
+	var limitClause string
+	if limit > 0 {
+		limitClause = "\nLIMIT ?"
+		args = append(args, limit)
+	}
+
+	query := `
+SELECT
+	id, project_dir, worktree_path, launcher_id, ticket, ticket_title, harness_name, model, agent,
+	status, exit_code, started_at, ended_at, output_log_path
+FROM agent_sessions` + where + `
+ORDER BY started_at DESC` + limitClause
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query agent sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []domain.AgentSession
+	for rows.Next() {
+		var a domain.AgentSession
+		if err := rows.Scan(
+			&a.ID,
+			&a.ProjectDir,
+			&a.WorktreePath,
+			&a.LauncherID,
+			&a.Ticket,
+			&a.TicketTitle,
+			&a.HarnessName,
+			&a.Model,
+			&a.Agent,
+			(*int)(&a.Status),
+			&a.ExitCode,
+			&a.StartedAt,
+			&a.EndedAt,
+			&a.OutputLogPath,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan agent session row: %w", err)
+		}
+		sessions = append(sessions, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating agent session rows: %w", err)
+	}
+
+	return sessions, nil
+}
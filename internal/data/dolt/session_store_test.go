@@ -0,0 +1,115 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func TestStore_RecordAgentSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db}
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	end := start.Add(90 * time.Second)
+	exitCode := 1
+
+	mock.ExpectExec("INSERT INTO agent_sessions").
+		WithArgs("/repo", "/repo", "bb-1", "bb-1", "Title", "codex", "m", "a",
+			int(domain.AgentFailed), 1, start, end, "/logs/bb-1.log").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err = store.RecordAgentSession(context.Background(), domain.AgentSession{
+		ProjectDir:    "/repo",
+		LauncherID:    "bb-1",
+		Ticket:        "bb-1",
+		TicketTitle:   "Title",
+		HarnessName:   "codex",
+		Model:         "m",
+		Agent:         "a",
+		Status:        domain.AgentFailed,
+		ExitCode:      &exitCode,
+		StartedAt:     start,
+		EndedAt:       end,
+		OutputLogPath: "/logs/bb-1.log",
+	})
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_RecordAgentSession_Invalid(t *testing.T) {
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db}
+	if err := store.RecordAgentSession(context.Background(), domain.AgentSession{HarnessName: "codex"}); err == nil {
+		t.Fatal("expected error for session without project and times")
+	}
+}
+
+func TestStore_ListAgentSessions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db}
+	now := time.Now().UTC()
+	rows := sqlmock.NewRows([]string{
+		"id", "project_dir", "worktree_path", "launcher_id", "ticket", "ticket_title", "harness_name", "model", "agent",
+		"status", "exit_code", "started_at", "ended_at", "output_log_path",
+	}).
+		AddRow(2, "/repo", "/repo", "bb-2", "bb-2", "Two", "codex", "m", "a", int(domain.AgentFailed), 2, now, now, "").
+		AddRow(1, "/repo", "/repo", "bb-1", "bb-1", "One", "codex", "m", "a", int(domain.AgentCompleted), nil, now, now, "")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`
+SELECT
+	id, project_dir, worktree_path, launcher_id, ticket, ticket_title, harness_name, model, agent,
+	status, exit_code, started_at, ended_at, output_log_path
+FROM agent_sessions
+WHERE project_dir IN (?)
+ORDER BY started_at DESC
+LIMIT ?`)).
+		WithArgs("/repo", 10).
+		WillReturnRows(rows)
+
+	got, err := store.ListAgentSessions(context.Background(), []string{"/repo"}, 10)
+	if err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(got))
+	}
+	if got[0].ExitCode == nil || *got[0].ExitCode != 2 || got[0].Status != domain.AgentFailed {
+		t.Fatalf("unexpected first session: %+v", got[0])
+	}
+	if got[1].ExitCode != nil {
+		t.Fatalf("expected nil exit code for second session, got %v", *got[1].ExitCode)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unfulfilled expectations: %v", err)
+	}
+}
@@ -27,6 +27,9 @@ type Store struct {
 	beadsDir  string
 	metadata  *Metadata
 	autostart bool
+
+	// host overrides the local host identity (used by tests).
+	host *domain.HostIdentity
 }
 
 // Verify interface compliance at compile time.
@@ -93,7 +96,26 @@ func handleServerMode(ctx context.Context, beadsDir string, metadata *Metadata,
 // For embedded mode: opens .beads/dolt/ using the embedded driver.
 // For server mode: connects to the configured dolt sql-server.
 // If autostart is true and the server is not running, it will attempt to start it.
+// Pending schema migrations are applied unless opts.SkipMigrations is set.
 func NewStore(ctx context.Context, opts domain.AppOptions, autostart bool) (*Store, error) {
+	store, err := openStore(ctx, opts, autostart)
+	if err != nil || opts.SkipMigrations {
+		return store, err
+	}
+	return store.migrateOnOpen(ctx)
+}
+
+// migrateOnOpen applies pending migrations to a freshly opened store, closing
+// it on failure.
+func (s *Store) migrateOnOpen(ctx context.Context) (*Store, error) {
+	if _, err := s.Migrate(ctx); err != nil {
+		_ = s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func openStore(ctx context.Context, opts domain.AppOptions, autostart bool) (*Store, error) {
 	beadsDir := opts.BeadsDir
 	if beadsDir == "" {
 		beadsDir = ".beads"
@@ -166,7 +188,11 @@ func (s *Store) TryStartServer(ctx context.Context) (*Store, error) {
 	}
 
 	// Create new store with fresh connection
-	return newServerStore(ctx, s.beadsDir, s.metadata, s.autostart)
+	store, err := newServerStore(ctx, s.beadsDir, s.metadata, s.autostart)
+	if err != nil {
+		return nil, err
+	}
+	return store.migrateOnOpen(ctx)
 }
 
 // ListTickets queries the ready_issues view and returns tickets matching the filter.
@@ -299,3 +325,12 @@ func scanTickets(rows *sql.Rows) ([]domain.Ticket, error) {
 
 	return tickets, nil
 }
+
+// Host returns the identity that running agents are recorded and validated
+// against.
+func (s *Store) Host() domain.HostIdentity {
+	if s.host != nil {
+		return *s.host
+	}
+	return data.LocalHost()
+}
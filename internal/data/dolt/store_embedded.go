@@ -71,10 +71,5 @@ func newEmbeddedStore(ctx context.Context, beadsDir string, metadata *Metadata,
 		autostart: autostart,
 	}
 
-	if err := store.EnsureRunningAgentsTable(ctx); err != nil {
-		_ = db.Close()
-		return nil, err
-	}
-
 	return store, nil
 }
@@ -0,0 +1,134 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// Verify interface compliance at compile time.
+var _ data.TicketDetailStore = (*Store)(nil)
+
+const (
+	ticketDetailQuery = `SELECT id, title, description, design, acceptance_criteria, notes, status, priority, issue_type, assignee, created_at, updated_at FROM issues WHERE id = ?`
+	labelsQuery       = `SELECT label FROM labels WHERE issue_id = ? ORDER BY label`
+	dependenciesQuery = `SELECT d.depends_on_id, i.title, i.status, d.type FROM dependencies d LEFT JOIN issues i ON i.id = d.depends_on_id WHERE d.issue_id = ? ORDER BY d.depends_on_id`
+	dependentsQuery   = `SELECT d.issue_id, i.title, i.status, d.type FROM dependencies d LEFT JOIN issues i ON i.id = d.issue_id WHERE d.depends_on_id = ? ORDER BY d.issue_id`
+	commentsQuery     = `SELECT author, text, created_at FROM comments WHERE issue_id = ? ORDER BY created_at, id`
+)
+
+// TicketDetail loads a ticket from the issues table, whatever its status,
+// together with its labels, dependencies in both directions and comments.
+func (s *Store) TicketDetail(ctx context.Context, id string) (*domain.TicketDetail, error) {
+	if s.closed {
+		return nil, fmt.Errorf("store is closed")
+	}
+
+	var d domain.TicketDetail
+	var design, acceptance, notes, assignee sql.NullString
+	err := s.db.QueryRowContext(ctx, ticketDetailQuery, id).Scan(
+		&d.ID,
+		&d.Title,
+		&d.Description,
+		&design,
+		&acceptance,
+		&notes,
+		&d.Status,
+		&d.Priority,
+		&d.IssueType,
+		&assignee,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", data.ErrTicketNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ticket %s: %w", id, err)
+	}
+	d.Design = design.String
+	d.AcceptanceCriteria = acceptance.String
+	d.Notes = notes.String
+	d.Assignee = assignee.String
+
+	if d.Labels, err = s.ticketLabels(ctx, id); err != nil {
+		return nil, err
+	}
+	if d.Dependencies, err = s.ticketDependencies(ctx, dependenciesQuery, id); err != nil {
+		return nil, err
+	}
+	if d.Dependents, err = s.ticketDependencies(ctx, dependentsQuery, id); err != nil {
+		return nil, err
+	}
+	if d.Comments, err = s.ticketComments(ctx, id); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (s *Store) ticketLabels(ctx context.Context, id string) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, labelsQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query labels: %w", err)
+	}
+	defer rows.Close()
+
+	var labels []string
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			return nil, fmt.Errorf("failed to scan label row: %w", err)
+		}
+		labels = append(labels, label)
+	}
+	return labels, rows.Err()
+}
+
+func (s *Store) ticketDependencies(ctx context.Context, query, id string) ([]domain.TicketDependency, error) {
+	rows, err := s.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dependencies: %w", err)
+	}
+	defer rows.Close()
+
+	var deps []domain.TicketDependency
+	for rows.Next() {
+		var dep domain.TicketDependency
+		var title, status sql.NullString
+		if err := rows.Scan(&dep.ID, &title, &status, &dep.Type); err != nil {
+			return nil, fmt.Errorf("failed to scan dependency row: %w", err)
+		}
+		dep.Title = title.String
+		dep.Status = status.String
+		deps = append(deps, dep)
+	}
+	return deps, rows.Err()
+}
+
+func (s *Store) ticketComments(ctx context.Context, id string) ([]domain.TicketComment, error) {
+	rows, err := s.db.QueryContext(ctx, commentsQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query comments: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []domain.TicketComment
+	for rows.Next() {
+		var c domain.TicketComment
+		if err := rows.Scan(&c.Author, &c.Text, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan comment row: %w", err)
+		}
+		comments = append(comments, c)
+	}
+	return comments, rows.Err()
+}
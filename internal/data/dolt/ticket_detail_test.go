@@ -0,0 +1,84 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/megatherium/blunderbust/internal/data"
+)
+
+func TestStore_TicketDetail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db, mode: EmbeddedMode}
+	created := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(ticketDetailQuery)).WithArgs("bb-002").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "title", "description", "design", "acceptance_criteria", "notes", "status", "priority", "issue_type", "assignee", "created_at", "updated_at",
+		}).AddRow("bb-002", "Detail pane", "Show it", "Use glamour", nil, "", "in_progress", 1, "feature", "alice", created, created))
+	mock.ExpectQuery(regexp.QuoteMeta(labelsQuery)).WithArgs("bb-002").
+		WillReturnRows(sqlmock.NewRows([]string{"label"}).AddRow("tui").AddRow("ux"))
+	mock.ExpectQuery(regexp.QuoteMeta(dependenciesQuery)).WithArgs("bb-002").
+		WillReturnRows(sqlmock.NewRows([]string{"depends_on_id", "title", "status", "type"}).
+			AddRow("bb-001", "Store API", "closed", "blocks").
+			AddRow("ext-9", nil, nil, "related"))
+	mock.ExpectQuery(regexp.QuoteMeta(dependentsQuery)).WithArgs("bb-002").
+		WillReturnRows(sqlmock.NewRows([]string{"issue_id", "title", "status", "type"}))
+	mock.ExpectQuery(regexp.QuoteMeta(commentsQuery)).WithArgs("bb-002").
+		WillReturnRows(sqlmock.NewRows([]string{"author", "text", "created_at"}).AddRow("bob", "Looks good", created))
+
+	d, err := store.TicketDetail(context.Background(), "bb-002")
+	if err != nil {
+		t.Fatalf("TicketDetail failed: %v", err)
+	}
+	if d.Title != "Detail pane" || d.Design != "Use glamour" || d.AcceptanceCriteria != "" || d.Assignee != "alice" {
+		t.Errorf("unexpected ticket fields: %+v", d)
+	}
+	if len(d.Labels) != 2 || d.Labels[0] != "tui" {
+		t.Errorf("unexpected labels: %v", d.Labels)
+	}
+	if len(d.Dependencies) != 2 || d.Dependencies[0].Status != "closed" || d.Dependencies[1].Title != "" {
+		t.Errorf("unexpected dependencies: %+v", d.Dependencies)
+	}
+	if len(d.Dependents) != 0 {
+		t.Errorf("expected no dependents, got %+v", d.Dependents)
+	}
+	if len(d.Comments) != 1 || d.Comments[0].Author != "bob" {
+		t.Errorf("unexpected comments: %+v", d.Comments)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_TicketDetail_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db, mode: EmbeddedMode}
+	mock.ExpectQuery(regexp.QuoteMeta(ticketDetailQuery)).WithArgs("nope").WillReturnError(sql.ErrNoRows)
+
+	_, err = store.TicketDetail(context.Background(), "nope")
+	if !errors.Is(err, data.ErrTicketNotFound) {
+		t.Errorf("expected ErrTicketNotFound, got %v", err)
+	}
+}
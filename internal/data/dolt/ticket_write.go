@@ -0,0 +1,293 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// Verify interface compliance at compile time.
+var (
+	_ data.TicketWriter    = (*Store)(nil)
+	_ data.TicketCommenter = (*Store)(nil)
+)
+
+const (
+	// Hash IDs start at minIDLength characters and grow on collision.
+	minIDLength = 4
+	maxIDLength = 8
+	idAlphabet  = "0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
+// CreateTicket inserts an open ticket with its dependencies and records it in
+// a Dolt commit. Children get their parent's ID with the next ".N" suffix,
+// like bd; other tickets get a random ID with the project's issue prefix.
+func (s *Store) CreateTicket(ctx context.Context, t data.NewTicket) (string, error) {
+	if s.closed {
+		return "", fmt.Errorf("store is closed")
+	}
+	if strings.TrimSpace(t.Title) == "" {
+		return "", fmt.Errorf("ticket title is required")
+	}
+	if t.Priority < domain.MinTicketPriority || t.Priority > domain.MaxTicketPriority {
+		return "", fmt.Errorf("invalid priority %d", t.Priority)
+	}
+	if t.IssueType == "" {
+		t.IssueType = "task"
+	}
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to open connection: %w", err)
+	}
+	defer conn.Close()
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, dep := range append([]string{t.ParentID}, t.DependsOn...) {
+		if dep == "" {
+			continue
+		}
+		exists, err := ticketExists(ctx, tx, dep)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return "", fmt.Errorf("%w: %s", data.ErrTicketNotFound, dep)
+		}
+	}
+
+	id, err := newTicketID(ctx, tx, t.ParentID)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO issues (id, title, description, status, priority, issue_type, created_at, updated_at) VALUES (?, ?, ?, 'open', ?, ?, ?, ?)`,
+		id, strings.TrimSpace(t.Title), t.Description, t.Priority, t.IssueType, now, now); err != nil {
+		return "", fmt.Errorf("failed to insert ticket: %w", err)
+	}
+
+	actor := ticketActor()
+	addDependency := func(dependsOn, depType string) error {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO dependencies (issue_id, depends_on_id, type, created_at, created_by) VALUES (?, ?, ?, ?, ?)`,
+			id, dependsOn, depType, now, actor); err != nil {
+			return fmt.Errorf("failed to add dependency on %s: %w", dependsOn, err)
+		}
+		return nil
+	}
+	if t.ParentID != "" {
+		if err := addDependency(t.ParentID, "parent-child"); err != nil {
+			return "", err
+		}
+	}
+	for _, dep := range t.DependsOn {
+		if dep != "" {
+			if err := addDependency(dep, "blocks"); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("failed to commit ticket: %w", err)
+	}
+	if err := doltCommit(ctx, conn, fmt.Sprintf("blunderbust: create %s: %s", id, t.Title), "issues", "dependencies"); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// UpdateTicket changes the given fields and records the change in a Dolt
+// commit. Closing a ticket sets closed_at; reopening it clears it.
+func (s *Store) UpdateTicket(ctx context.Context, id string, u data.TicketUpdate) error {
+	if s.closed {
+		return fmt.Errorf("store is closed")
+	}
+	if u.IsEmpty() {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	var sets, changes []string
+	var args []any
+	if u.Priority != nil {
+		if *u.Priority < domain.MinTicketPriority || *u.Priority > domain.MaxTicketPriority {
+			return fmt.Errorf("invalid priority %d", *u.Priority)
+		}
+		sets, args = append(sets, "priority = ?"), append(args, *u.Priority)
+		changes = append(changes, fmt.Sprintf("priority P%d", *u.Priority))
+	}
+	if u.Status != nil {
+		if !slices.Contains(domain.TicketStatuses, *u.Status) {
+			return fmt.Errorf("invalid status %q", *u.Status)
+		}
+		sets, args = append(sets, "status = ?"), append(args, *u.Status)
+		if *u.Status == "closed" {
+			sets, args = append(sets, "closed_at = ?"), append(args, now)
+		} else {
+			sets = append(sets, "closed_at = NULL")
+		}
+		changes = append(changes, "status "+*u.Status)
+	}
+	if u.Assignee != nil {
+		assignee := strings.TrimSpace(*u.Assignee)
+		sets, args = append(sets, "assignee = ?"), append(args, sql.NullString{String: assignee, Valid: assignee != ""})
+		if assignee == "" {
+			changes = append(changes, "unassigned")
+		} else {
+			changes = append(changes, "assignee "+assignee)
+		}
+	}
+	sets, args = append(sets, "updated_at = ?"), append(args, now, id)
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open connection: %w", err)
+	}
+	defer conn.Close()
+
+	res, err := conn.ExecContext(ctx, "UPDATE issues SET "+strings.Join(sets, ", ")+" WHERE id = ?", args...)
+	if err != nil {
+		return fmt.Errorf("failed to update ticket %s: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%w: %s", data.ErrTicketNotFound, id)
+	}
+	return doltCommit(ctx, conn, fmt.Sprintf("blunderbust: update %s: %s", id, strings.Join(changes, ", ")), "issues")
+}
+
+// AddComment adds a comment by the current actor to ticket id and records
+// it in a Dolt commit.
+func (s *Store) AddComment(ctx context.Context, id, text string) error {
+	if s.closed {
+		return fmt.Errorf("store is closed")
+	}
+	if strings.TrimSpace(text) == "" {
+		return fmt.Errorf("comment text is required")
+	}
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to open connection: %w", err)
+	}
+	defer conn.Close()
+
+	res, err := conn.ExecContext(ctx,
+		`INSERT INTO comments (issue_id, author, text, created_at) SELECT id, ?, ?, ? FROM issues WHERE id = ?`,
+		ticketActor(), text, time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("failed to add comment to %s: %w", id, err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%w: %s", data.ErrTicketNotFound, id)
+	}
+	return doltCommit(ctx, conn, fmt.Sprintf("blunderbust: comment on %s", id), "comments")
+}
+
+func ticketExists(ctx context.Context, q migrationConn, id string) (bool, error) {
+	var n int
+	if err := q.QueryRowContext(ctx, `SELECT COUNT(*) FROM issues WHERE id = ?`, id).Scan(&n); err != nil {
+		return false, fmt.Errorf("failed to look up ticket %s: %w", id, err)
+	}
+	return n > 0, nil
+}
+
+// newTicketID returns an unused ID: the next child number of parentID, or a
+// random hash ID with the issue prefix.
+func newTicketID(ctx context.Context, q migrationConn, parentID string) (string, error) {
+	if parentID != "" {
+		var children int
+		if err := q.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM dependencies WHERE depends_on_id = ? AND type = 'parent-child'`,
+			parentID).Scan(&children); err != nil {
+			return "", fmt.Errorf("failed to count children of %s: %w", parentID, err)
+		}
+		for n := children + 1; ; n++ {
+			id := fmt.Sprintf("%s.%d", parentID, n)
+			exists, err := ticketExists(ctx, q, id)
+			if err != nil || !exists {
+				return id, err
+			}
+		}
+	}
+
+	prefix, err := issuePrefix(ctx, q)
+	if err != nil {
+		return "", err
+	}
+	for length := minIDLength; length <= maxIDLength; length++ {
+		suffix, err := randomID(length)
+		if err != nil {
+			return "", err
+		}
+		id := prefix + "-" + suffix
+		exists, err := ticketExists(ctx, q, id)
+		if err != nil || !exists {
+			return id, err
+		}
+	}
+	return "", fmt.Errorf("failed to generate a unique ticket ID")
+}
+
+// issuePrefix returns the issue prefix from the Beads config table, falling
+// back to the prefix of the newest issue.
+func issuePrefix(ctx context.Context, q migrationConn) (string, error) {
+	var prefix string
+	err := q.QueryRowContext(ctx, "SELECT value FROM config WHERE `key` = 'issue_prefix'").Scan(&prefix)
+	if prefix = strings.TrimSuffix(strings.TrimSpace(prefix), "-"); err == nil && prefix != "" {
+		return prefix, nil
+	}
+
+	var id string
+	if err := q.QueryRowContext(ctx, `SELECT id FROM issues ORDER BY created_at DESC LIMIT 1`).Scan(&id); err == nil {
+		if i := strings.LastIndex(id, "-"); i > 0 {
+			return id[:i], nil
+		}
+	}
+	return "", fmt.Errorf("cannot determine the issue prefix; set it with 'bd init --prefix'")
+}
+
+func randomID(length int) (string, error) {
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(idAlphabet))))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate ticket ID: %w", err)
+		}
+		b[i] = idAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
+
+// ticketActor returns the name recorded on new dependencies: BD_ACTOR, as
+// bd uses, else the login name.
+func ticketActor() string {
+	for _, env := range []string{"BD_ACTOR", "USER", "USERNAME"} {
+		if v := os.Getenv(env); v != "" {
+			return v
+		}
+	}
+	return "blunderbust"
+}
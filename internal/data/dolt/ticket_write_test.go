@@ -0,0 +1,191 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package dolt
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/megatherium/blunderbust/internal/data"
+)
+
+func TestStore_CreateTicket(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+	t.Setenv("BD_ACTOR", "tester")
+
+	store := &Store{db: db, mode: EmbeddedMode}
+	exists := regexp.QuoteMeta(`SELECT COUNT(*) FROM issues WHERE id = ?`)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(exists).WithArgs("bb-blocker").WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+	mock.ExpectQuery("SELECT value FROM config").WillReturnRows(sqlmock.NewRows([]string{"value"}).AddRow("bb"))
+	mock.ExpectQuery(exists).WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+	mock.ExpectQuery(exists).WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(0))
+	mock.ExpectExec("INSERT INTO issues").
+		WithArgs(sqlmock.AnyArg(), "Follow-up", "Details", 1, "bug", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO dependencies").
+		WithArgs(sqlmock.AnyArg(), "bb-blocker", "blocks", sqlmock.AnyArg(), "tester").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectStagedTables(mock, "")
+	mock.ExpectExec("CALL DOLT_ADD").WithArgs("issues", "dependencies").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CALL DOLT_COMMIT").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	id, err := store.CreateTicket(context.Background(), data.NewTicket{
+		Title: "Follow-up", IssueType: "bug", Priority: 1, Description: "Details", DependsOn: []string{"bb-blocker"},
+	})
+	if err != nil {
+		t.Fatalf("CreateTicket failed: %v", err)
+	}
+	if !regexp.MustCompile(`^bb-[0-9a-z]{5}$`).MatchString(id) {
+		t.Errorf("expected a longer hash ID after a collision, got %q", id)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_CreateTicket_Child(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db, mode: EmbeddedMode}
+	exists := regexp.QuoteMeta(`SELECT COUNT(*) FROM issues WHERE id = ?`)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(exists).WithArgs("bb-epic").WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM dependencies").WithArgs("bb-epic").
+		WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(2))
+	mock.ExpectQuery(exists).WithArgs("bb-epic.3").WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(0))
+	mock.ExpectExec("INSERT INTO issues").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO dependencies").
+		WithArgs("bb-epic.3", "bb-epic", "parent-child", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectStagedTables(mock, "")
+	mock.ExpectExec("CALL DOLT_ADD").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CALL DOLT_COMMIT").WithArgs("blunderbust: create bb-epic.3: Child").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	id, err := store.CreateTicket(context.Background(), data.NewTicket{Title: "Child", Priority: 2, ParentID: "bb-epic"})
+	if err != nil {
+		t.Fatalf("CreateTicket failed: %v", err)
+	}
+	if id != "bb-epic.3" {
+		t.Errorf("expected the next child ID bb-epic.3, got %q", id)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+}
+
+func TestStore_CreateTicket_UnknownDependency(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db, mode: EmbeddedMode}
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT COUNT").WithArgs("bb-nope").WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(0))
+	mock.ExpectRollback()
+
+	_, err = store.CreateTicket(context.Background(), data.NewTicket{Title: "X", DependsOn: []string{"bb-nope"}})
+	if !errors.Is(err, data.ErrTicketNotFound) {
+		t.Errorf("expected ErrTicketNotFound, got %v", err)
+	}
+	if _, err := store.CreateTicket(context.Background(), data.NewTicket{Title: "  "}); err == nil {
+		t.Error("expected an error for an empty title")
+	}
+}
+
+func TestStore_UpdateTicket(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+
+	store := &Store{db: db, mode: EmbeddedMode}
+	priority, status, assignee := 0, "closed", ""
+
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE issues SET priority = ?, status = ?, closed_at = ?, assignee = ?, updated_at = ? WHERE id = ?")).
+		WithArgs(0, "closed", sqlmock.AnyArg(), nil, sqlmock.AnyArg(), "bb-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectStagedTables(mock, "")
+	mock.ExpectExec("CALL DOLT_ADD").WithArgs("issues").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CALL DOLT_COMMIT").WithArgs("blunderbust: update bb-1: priority P0, status closed, unassigned").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = store.UpdateTicket(context.Background(), "bb-1", data.TicketUpdate{Priority: &priority, Status: &status, Assignee: &assignee})
+	if err != nil {
+		t.Fatalf("UpdateTicket failed: %v", err)
+	}
+
+	mock.ExpectExec("UPDATE issues SET status = \\?, closed_at = NULL").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	reopen := "open"
+	err = store.UpdateTicket(context.Background(), "bb-404", data.TicketUpdate{Status: &reopen})
+	if !errors.Is(err, data.ErrTicketNotFound) {
+		t.Errorf("expected ErrTicketNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+
+	bogus := "done"
+	if err := store.UpdateTicket(context.Background(), "bb-1", data.TicketUpdate{Status: &bogus}); err == nil || !strings.Contains(err.Error(), "invalid status") {
+		t.Errorf("expected an invalid status error, got %v", err)
+	}
+}
+
+func TestStore_AddComment(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to create mock: %v", err)
+	}
+	defer db.Close()
+	t.Setenv("BD_ACTOR", "tester")
+
+	store := &Store{db: db, mode: EmbeddedMode}
+	insert := regexp.QuoteMeta(`INSERT INTO comments (issue_id, author, text, created_at) SELECT id, ?, ?, ? FROM issues WHERE id = ?`)
+
+	mock.ExpectExec(insert).WithArgs("tester", "Tests pass", sqlmock.AnyArg(), "bb-1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectStagedTables(mock, "")
+	mock.ExpectExec("CALL DOLT_ADD").WithArgs("comments").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CALL DOLT_COMMIT").WithArgs("blunderbust: comment on bb-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := store.AddComment(context.Background(), "bb-1", "Tests pass"); err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	mock.ExpectExec(insert).WillReturnResult(sqlmock.NewResult(0, 0))
+	if err := store.AddComment(context.Background(), "bb-404", "Tests pass"); !errors.Is(err, data.ErrTicketNotFound) {
+		t.Errorf("expected ErrTicketNotFound, got %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unfulfilled expectations: %v", err)
+	}
+
+	if err := store.AddComment(context.Background(), "bb-1", " "); err == nil {
+		t.Error("expected an error for an empty comment")
+	}
+}
@@ -17,6 +17,8 @@ type fakeGitClient struct {
 	worktrees  map[string][]data.WorktreeEntry
 	mainBranch map[string]string
 	dirty      map[string]bool
+	commits    map[string][]string
+	changed    map[string][]string
 	errors     map[string]error
 }
 
@@ -29,6 +31,8 @@ func NewFakeGitClient() *fakeGitClient {
 		worktrees:  make(map[string][]data.WorktreeEntry),
 		mainBranch: make(map[string]string),
 		dirty:      make(map[string]bool),
+		commits:    make(map[string][]string),
+		changed:    make(map[string][]string),
 		errors:     make(map[string]error),
 	}
 }
@@ -56,6 +60,36 @@ func (f *fakeGitClient) CheckDirty(ctx context.Context, path string) bool {
 	return f.dirty[path]
 }
 
+// RecentCommits returns up to n of the configured commits for the given path.
+func (f *fakeGitClient) RecentCommits(ctx context.Context, path string, n int) ([]string, error) {
+	if err := f.getError("recentcommits", path); err != nil {
+		return nil, err
+	}
+	commits := f.commits[path]
+	if len(commits) > n {
+		commits = commits[:n]
+	}
+	return commits, nil
+}
+
+// ChangedFiles returns the configured changed files for the given path.
+func (f *fakeGitClient) ChangedFiles(ctx context.Context, path, base string) ([]string, error) {
+	if err := f.getError("changedfiles", path); err != nil {
+		return nil, err
+	}
+	return f.changed[path], nil
+}
+
+// SetCommits configures the commits, newest first, for a specific path.
+func (f *fakeGitClient) SetCommits(path string, commits []string) {
+	f.commits[path] = commits
+}
+
+// SetChangedFiles configures the changed files for a specific path.
+func (f *fakeGitClient) SetChangedFiles(path string, files []string) {
+	f.changed[path] = files
+}
+
 // SetWorktrees configures the worktrees for a specific repo root.
 func (f *fakeGitClient) SetWorktrees(repoRoot string, entries []data.WorktreeEntry) {
 	f.worktrees[repoRoot] = entries
@@ -199,3 +199,16 @@ func TestFakeGitClient_ErrorWildcard(t *testing.T) {
 		t.Errorf("expected wildcard error, got %v", err)
 	}
 }
+
+func TestFakeGitClient_RecentCommits_Limit(t *testing.T) {
+	client := NewFakeGitClient()
+	client.SetCommits("/repo", []string{"c3 third", "c2 second", "c1 first"})
+
+	commits, err := client.RecentCommits(context.Background(), "/repo", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(commits) != 2 || commits[0] != "c3 third" {
+		t.Errorf("expected the 2 newest commits, got %v", commits)
+	}
+}
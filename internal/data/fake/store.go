@@ -8,6 +8,7 @@ package fake
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -18,10 +19,18 @@ import (
 // TicketStore is an in-memory fake implementing data.TicketStore.
 type TicketStore struct {
 	Tickets []domain.Ticket
+	// Details holds extended records keyed by ticket ID. Tickets without an
+	// entry are returned with only their Ticket fields set.
+	Details map[string]domain.TicketDetail
 }
 
 // Verify interface compliance at compile time.
-var _ data.TicketStore = (*TicketStore)(nil)
+var (
+	_ data.TicketStore       = (*TicketStore)(nil)
+	_ data.TicketDetailStore = (*TicketStore)(nil)
+	_ data.TicketWriter      = (*TicketStore)(nil)
+	_ data.TicketCommenter   = (*TicketStore)(nil)
+)
 
 // ListTickets returns tickets matching the given filter.
 func (s *TicketStore) ListTickets(_ context.Context, filter data.TicketFilter) ([]domain.Ticket, error) {
@@ -45,6 +54,97 @@ func (s *TicketStore) ListTickets(_ context.Context, filter data.TicketFilter) (
 	return results, nil
 }
 
+// TicketDetail returns the ticket with the given ID, merged with its entry in
+// Details if there is one.
+func (s *TicketStore) TicketDetail(_ context.Context, id string) (*domain.TicketDetail, error) {
+	for _, t := range s.Tickets {
+		if t.ID != id {
+			continue
+		}
+		d := s.Details[id]
+		d.Ticket = t
+		return &d, nil
+	}
+	return nil, fmt.Errorf("%w: %s", data.ErrTicketNotFound, id)
+}
+
+// CreateTicket appends an open ticket with the next free "bb-NNN" ID.
+// Dependencies are recorded in Details.
+func (s *TicketStore) CreateTicket(_ context.Context, t data.NewTicket) (string, error) {
+	if strings.TrimSpace(t.Title) == "" {
+		return "", fmt.Errorf("ticket title is required")
+	}
+	id := ""
+	for n := len(s.Tickets) + 1; id == "" || s.find(id) >= 0; n++ {
+		id = fmt.Sprintf("bb-%03d", n)
+	}
+
+	now := time.Now()
+	s.Tickets = append(s.Tickets, domain.Ticket{
+		ID: id, Title: strings.TrimSpace(t.Title), Description: t.Description, Status: "open",
+		Priority: t.Priority, IssueType: t.IssueType, CreatedAt: now, UpdatedAt: now,
+	})
+
+	var deps []domain.TicketDependency
+	if t.ParentID != "" {
+		deps = append(deps, domain.TicketDependency{ID: t.ParentID, Type: "parent-child"})
+	}
+	for _, dep := range t.DependsOn {
+		deps = append(deps, domain.TicketDependency{ID: dep, Type: "blocks"})
+	}
+	if len(deps) > 0 {
+		if s.Details == nil {
+			s.Details = make(map[string]domain.TicketDetail)
+		}
+		s.Details[id] = domain.TicketDetail{Dependencies: deps}
+	}
+	return id, nil
+}
+
+// UpdateTicket changes the given fields of a ticket in place.
+func (s *TicketStore) UpdateTicket(_ context.Context, id string, u data.TicketUpdate) error {
+	i := s.find(id)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", data.ErrTicketNotFound, id)
+	}
+	t := &s.Tickets[i]
+	if u.Priority != nil {
+		t.Priority = *u.Priority
+	}
+	if u.Status != nil {
+		t.Status = *u.Status
+	}
+	if u.Assignee != nil {
+		t.Assignee = *u.Assignee
+	}
+	t.UpdatedAt = time.Now()
+	return nil
+}
+
+// AddComment appends a comment by "blunderbust" to the ticket's entry in
+// Details.
+func (s *TicketStore) AddComment(_ context.Context, id, text string) error {
+	if s.find(id) < 0 {
+		return fmt.Errorf("%w: %s", data.ErrTicketNotFound, id)
+	}
+	if s.Details == nil {
+		s.Details = make(map[string]domain.TicketDetail)
+	}
+	d := s.Details[id]
+	d.Comments = append(d.Comments, domain.TicketComment{Author: "blunderbust", Text: text, CreatedAt: time.Now()})
+	s.Details[id] = d
+	return nil
+}
+
+func (s *TicketStore) find(id string) int {
+	for i := range s.Tickets {
+		if s.Tickets[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
 // LatestUpdate returns the maximum updated_at timestamp from the ticket collection.
 // Returns a zero time.Time if no tickets exist.
 func (s *TicketStore) LatestUpdate(_ context.Context) (time.Time, error) {
@@ -68,5 +168,14 @@ func NewWithSampleData() *TicketStore {
 			{ID: "bb-004", Title: "Build TUI skeleton", Status: "open", Priority: 1, IssueType: "feature", CreatedAt: now.Add(-6 * time.Hour), UpdatedAt: now},
 			{ID: "bb-005", Title: "Implement tmux launcher", Status: "open", Priority: 2, IssueType: "task", CreatedAt: now.Add(-3 * time.Hour), UpdatedAt: now},
 		},
+		Details: map[string]domain.TicketDetail{
+			"bb-004": {
+				Design:             "Use Bubble Tea with a **matrix** of lists: tickets, harnesses, models and agents.",
+				AcceptanceCriteria: "- Tickets are listed by priority\n- Enter on the agent column launches",
+				Labels:             []string{"tui"},
+				Dependencies:       []domain.TicketDependency{{ID: "bb-002", Title: "Define core domain types", Status: "open", Type: "blocks"}},
+				Comments:           []domain.TicketComment{{Author: "demo", Text: "Keep the layout responsive.", CreatedAt: now.Add(-time.Hour)}},
+			},
+		},
 	}
 }
@@ -8,6 +8,7 @@ package fake
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -147,3 +148,69 @@ func TestFakeStore_LatestUpdate_Empty(t *testing.T) {
 		t.Errorf("expected zero time, got %v", latest)
 	}
 }
+
+func TestFakeStore_TicketDetail(t *testing.T) {
+	store := &TicketStore{
+		Tickets: []domain.Ticket{{ID: "bb-001", Title: "First"}, {ID: "bb-002", Title: "Second"}},
+		Details: map[string]domain.TicketDetail{"bb-002": {Design: "Plan"}},
+	}
+
+	d, err := store.TicketDetail(context.Background(), "bb-002")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Title != "Second" || d.Design != "Plan" {
+		t.Errorf("expected merged ticket and detail, got %+v", d)
+	}
+
+	d, err = store.TicketDetail(context.Background(), "bb-001")
+	if err != nil || d.Title != "First" {
+		t.Errorf("expected ticket without details, got %+v, %v", d, err)
+	}
+
+	if _, err := store.TicketDetail(context.Background(), "bb-999"); !errors.Is(err, data.ErrTicketNotFound) {
+		t.Errorf("expected ErrTicketNotFound, got %v", err)
+	}
+}
+
+func TestFakeStore_CreateAndUpdateTicket(t *testing.T) {
+	store := NewWithSampleData()
+
+	id, err := store.CreateTicket(context.Background(), data.NewTicket{Title: "Follow-up", IssueType: "bug", Priority: 1, ParentID: "bb-004"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "bb-006" {
+		t.Errorf("expected bb-006, got %q", id)
+	}
+	d, err := store.TicketDetail(context.Background(), id)
+	if err != nil || len(d.Dependencies) != 1 || d.Dependencies[0].Type != "parent-child" {
+		t.Errorf("expected a parent-child dependency, got %+v, %v", d, err)
+	}
+
+	status := "in_progress"
+	if err := store.UpdateTicket(context.Background(), id, data.TicketUpdate{Status: &status}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := store.Tickets[len(store.Tickets)-1].Status; got != status {
+		t.Errorf("expected status %q, got %q", status, got)
+	}
+	if err := store.UpdateTicket(context.Background(), "bb-999", data.TicketUpdate{Status: &status}); !errors.Is(err, data.ErrTicketNotFound) {
+		t.Errorf("expected ErrTicketNotFound, got %v", err)
+	}
+}
+
+func TestFakeStore_AddComment(t *testing.T) {
+	store := NewWithSampleData()
+
+	if err := store.AddComment(context.Background(), "bb-001", "Tests pass"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d, err := store.TicketDetail(context.Background(), "bb-001")
+	if err != nil || len(d.Comments) == 0 || d.Comments[len(d.Comments)-1].Text != "Tests pass" {
+		t.Errorf("expected the comment added, got %+v, %v", d, err)
+	}
+	if err := store.AddComment(context.Background(), "bb-999", "x"); !errors.Is(err, data.ErrTicketNotFound) {
+		t.Errorf("expected ErrTicketNotFound, got %v", err)
+	}
+}
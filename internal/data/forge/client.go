@@ -0,0 +1,151 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// maxResponseSize bounds a single API response body.
+const maxResponseSize = 32 * 1024 * 1024
+
+// client performs authenticated GET requests against a REST API, caching
+// responses by URL so they can be revalidated with their ETag.
+type client struct {
+	service    string // "GitHub" or "GitLab", for error messages
+	httpClient *http.Client
+	authorize  func(req *http.Request)
+
+	mu    sync.Mutex
+	cache map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	etag string
+	body []byte
+	next string
+}
+
+func newClient(service string, authorize func(req *http.Request)) *client {
+	return &client{
+		service:    service,
+		httpClient: http.DefaultClient,
+		authorize:  authorize,
+		cache:      make(map[string]cachedResponse),
+	}
+}
+
+// get fetches url and returns the body and the URL of the next page, if any.
+// A 304 Not Modified answer returns the cached response.
+func (c *client) get(ctx context.Context, url string) (body []byte, next string, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid %s API URL %q: %w", c.service, url, err)
+	}
+	c.authorize(req)
+
+	c.mu.Lock()
+	cached, ok := c.cache[url]
+	c.mu.Unlock()
+	if ok {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s API request failed: %w", c.service, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && ok {
+		return cached.body, cached.next, nil
+	}
+	body, err = io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s API response: %w", c.service, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", c.apiError(resp, body)
+	}
+
+	next = nextLink(resp.Header.Get("Link"))
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		c.mu.Lock()
+		c.cache[url] = cachedResponse{etag: etag, body: body, next: next}
+		c.mu.Unlock()
+	}
+	return body, next, nil
+}
+
+// getPages fetches url and up to maxPages-1 following pages, passing each
+// body to fn. more reports whether pages were left unread.
+func (c *client) getPages(ctx context.Context, url string, maxPages int, fn func(body []byte) error) (more bool, err error) {
+	for page := 0; url != "" && page < maxPages; page++ {
+		body, next, err := c.get(ctx, url)
+		if err != nil {
+			return false, err
+		}
+		if err := fn(body); err != nil {
+			return false, fmt.Errorf("failed to decode %s API response: %w", c.service, err)
+		}
+		url = next
+	}
+	return url != "", nil
+}
+
+// apiError describes a failed request, including the API's own message.
+func (c *client) apiError(resp *http.Response, body []byte) error {
+	var payload struct {
+		Message any    `json:"message"`
+		Error   string `json:"error"`
+	}
+	msg := ""
+	if json.Unmarshal(body, &payload) == nil {
+		switch {
+		case payload.Message != nil:
+			msg = fmt.Sprint(payload.Message)
+		case payload.Error != "":
+			msg = payload.Error
+		}
+	}
+
+	err := fmt.Errorf("%s API: GET %s: %s", c.service, resp.Request.URL.Redacted(), resp.Status)
+	if msg != "" {
+		err = fmt.Errorf("%w: %s", err, msg)
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		err = fmt.Errorf("%w (check the API token)", err)
+	}
+	return err
+}
+
+// nextLink returns the rel="next" URL of an RFC 8288 Link header, as sent by
+// both GitHub and GitLab for paginated listings.
+func nextLink(header string) string {
+	for _, part := range strings.Split(header, ",") {
+		segments := strings.Split(part, ";")
+		if len(segments) < 2 {
+			continue
+		}
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		for _, param := range segments[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return target[1 : len(target)-1]
+			}
+		}
+	}
+	return ""
+}
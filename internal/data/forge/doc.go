@@ -0,0 +1,27 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Package forge implements the TicketStore interface on top of the GitHub
+// and GitLab issue REST APIs, for projects that track work there rather
+// than in Beads.
+//
+// Open issues are mapped to domain.Ticket: the issue number becomes the
+// ticket ID ("gh-42" or "gl-42"), the body the description, and labels the
+// issue type and priority (see ticketTypeAndPriority). Pull requests, which
+// GitHub's issue API also returns, are skipped.
+//
+// Requests authenticate with a token read from an environment variable,
+// follow Link-header pagination, and send If-None-Match with the ETag of the
+// previous response so unchanged listings cost a 304. LatestUpdate is
+// additionally rate limited to one request per PollInterval.
+//
+// Usage
+//
+//	store, err := forge.NewStore(domain.TicketSource{
+//		Type: domain.TicketSourceGitHub,
+//		Repo: "owner/name",
+//	})
+package forge
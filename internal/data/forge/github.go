@@ -0,0 +1,150 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package forge
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// DefaultGitHubURL is the API base URL of github.com.
+const DefaultGitHubURL = "https://api.github.com"
+
+type github struct {
+	baseURL string
+	repo    string
+}
+
+type githubIssue struct {
+	Number    int       `json:"number"`
+	HTMLURL   string    `json:"html_url"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	State     string    `json:"state"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Labels    []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignee *struct {
+		Login string `json:"login"`
+	} `json:"assignee"`
+	PullRequest json.RawMessage `json:"pull_request"`
+}
+
+type githubComment struct {
+	User *struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewGitHubStore returns a store for the issues of repo ("owner/name").
+// baseURL defaults to DefaultGitHubURL; GitHub Enterprise uses
+// https://HOST/api/v3. An empty token sends unauthenticated requests.
+func NewGitHubStore(repo, baseURL, token string) *Store {
+	if baseURL == "" {
+		baseURL = DefaultGitHubURL
+	}
+	c := newClient("GitHub", func(req *http.Request) {
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	})
+	return newStore(github{baseURL: strings.TrimRight(baseURL, "/"), repo: repo}, c)
+}
+
+func (g github) openIssuesURL() string {
+	return fmt.Sprintf("%s/repos/%s/issues?state=open&per_page=%d", g.baseURL, g.repo, perPage)
+}
+
+func (g github) latestURL() string {
+	return fmt.Sprintf("%s/repos/%s/issues?state=all&sort=updated&direction=desc&per_page=1", g.baseURL, g.repo)
+}
+
+func (g github) decode(body []byte) ([]issue, error) {
+	var raw []githubIssue
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	issues := make([]issue, 0, len(raw))
+	for _, r := range raw {
+		issues = append(issues, r.issue())
+	}
+	return issues, nil
+}
+
+func (g github) idPrefix() string {
+	return "gh-"
+}
+
+func (g github) issueURL(number string) string {
+	return fmt.Sprintf("%s/repos/%s/issues/%s", g.baseURL, g.repo, number)
+}
+
+func (g github) commentsURL(number string) string {
+	return fmt.Sprintf("%s/repos/%s/issues/%s/comments?per_page=%d", g.baseURL, g.repo, number, perPage)
+}
+
+func (g github) decodeDetail(body []byte) (*domain.TicketDetail, error) {
+	var r githubIssue
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, err
+	}
+	return &domain.TicketDetail{Ticket: r.issue().ticket, URL: r.HTMLURL, Labels: r.labelNames()}, nil
+}
+
+func (g github) decodeComments(body []byte) ([]domain.TicketComment, error) {
+	var raw []githubComment
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+	comments := make([]domain.TicketComment, 0, len(raw))
+	for _, r := range raw {
+		c := domain.TicketComment{Text: r.Body, CreatedAt: r.CreatedAt}
+		if r.User != nil {
+			c.Author = r.User.Login
+		}
+		comments = append(comments, c)
+	}
+	return comments, nil
+}
+
+func (r githubIssue) labelNames() []string {
+	labels := make([]string, len(r.Labels))
+	for i, l := range r.Labels {
+		labels[i] = l.Name
+	}
+	return labels
+}
+
+func (r githubIssue) issue() issue {
+	issueType, priority := ticketTypeAndPriority(r.labelNames())
+	t := domain.Ticket{
+		ID:          fmt.Sprintf("gh-%d", r.Number),
+		Title:       r.Title,
+		Description: r.Body,
+		Status:      r.State,
+		Priority:    priority,
+		IssueType:   issueType,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	if r.Assignee != nil {
+		t.Assignee = r.Assignee.Login
+	}
+	return issue{ticket: t, pullRequest: len(r.PullRequest) > 0 && string(r.PullRequest) != "null"}
+}
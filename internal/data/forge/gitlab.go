@@ -0,0 +1,146 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package forge
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// DefaultGitLabURL is the API base URL of gitlab.com.
+const DefaultGitLabURL = "https://gitlab.com/api/v4"
+
+type gitlab struct {
+	baseURL string
+	project string // URL-escaped path or numeric ID
+}
+
+type gitlabIssue struct {
+	IID         int       `json:"iid"`
+	WebURL      string    `json:"web_url"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	State       string    `json:"state"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Labels      []string  `json:"labels"`
+	Assignees   []struct {
+		Username string `json:"username"`
+	} `json:"assignees"`
+}
+
+type gitlabNote struct {
+	Author struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	Body      string    `json:"body"`
+	System    bool      `json:"system"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewGitLabStore returns a store for the issues of project, given as its
+// full path ("group/name") or numeric ID. baseURL defaults to
+// DefaultGitLabURL; self-managed instances use https://HOST/api/v4. An empty
+// token sends unauthenticated requests.
+func NewGitLabStore(project, baseURL, token string) *Store {
+	if baseURL == "" {
+		baseURL = DefaultGitLabURL
+	}
+	c := newClient("GitLab", func(req *http.Request) {
+		if token != "" {
+			req.Header.Set("PRIVATE-TOKEN", token)
+		}
+	})
+	return newStore(gitlab{baseURL: strings.TrimRight(baseURL, "/"), project: url.PathEscape(project)}, c)
+}
+
+func (g gitlab) openIssuesURL() string {
+	return fmt.Sprintf("%s/projects/%s/issues?state=opened&per_page=%d", g.baseURL, g.project, perPage)
+}
+
+func (g gitlab) latestURL() string {
+	return fmt.Sprintf("%s/projects/%s/issues?order_by=updated_at&sort=desc&per_page=1", g.baseURL, g.project)
+}
+
+func (g gitlab) decode(body []byte) ([]issue, error) {
+	var raw []gitlabIssue
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	issues := make([]issue, 0, len(raw))
+	for _, r := range raw {
+		issues = append(issues, issue{ticket: r.ticket()})
+	}
+	return issues, nil
+}
+
+func (g gitlab) idPrefix() string {
+	return "gl-"
+}
+
+func (g gitlab) issueURL(number string) string {
+	return fmt.Sprintf("%s/projects/%s/issues/%s", g.baseURL, g.project, number)
+}
+
+// commentsURL lists the issue's notes oldest first; GitLab defaults to
+// newest first.
+func (g gitlab) commentsURL(number string) string {
+	return fmt.Sprintf("%s/projects/%s/issues/%s/notes?sort=asc&order_by=created_at&per_page=%d", g.baseURL, g.project, number, perPage)
+}
+
+func (g gitlab) decodeDetail(body []byte) (*domain.TicketDetail, error) {
+	var r gitlabIssue
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, err
+	}
+	return &domain.TicketDetail{Ticket: r.ticket(), URL: r.WebURL, Labels: r.Labels}, nil
+}
+
+// decodeComments skips system notes such as "changed the description".
+func (g gitlab) decodeComments(body []byte) ([]domain.TicketComment, error) {
+	var raw []gitlabNote
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+	comments := make([]domain.TicketComment, 0, len(raw))
+	for _, r := range raw {
+		if r.System {
+			continue
+		}
+		comments = append(comments, domain.TicketComment{Author: r.Author.Username, Text: r.Body, CreatedAt: r.CreatedAt})
+	}
+	return comments, nil
+}
+
+func (r gitlabIssue) ticket() domain.Ticket {
+	issueType, priority := ticketTypeAndPriority(r.Labels)
+	status := r.State
+	if status == "opened" {
+		status = "open"
+	}
+	t := domain.Ticket{
+		ID:          fmt.Sprintf("gl-%d", r.IID),
+		Title:       r.Title,
+		Description: r.Description,
+		Status:      status,
+		Priority:    priority,
+		IssueType:   issueType,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	if len(r.Assignees) > 0 {
+		t.Assignee = r.Assignees[0].Username
+	}
+	return t
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package forge
+
+import "strings"
+
+// Defaults for issues without a type or priority label, matching bd create.
+const (
+	defaultIssueType = "task"
+	defaultPriority  = 2
+)
+
+// typeLabels maps unscoped labels to Beads issue types.
+var typeLabels = map[string]string{
+	"bug":         "bug",
+	"feature":     "feature",
+	"enhancement": "feature",
+	"epic":        "epic",
+	"chore":       "chore",
+	"task":        "task",
+}
+
+// priorityWords maps priority label values to Beads priorities.
+var priorityWords = map[string]int{
+	"critical": 0,
+	"urgent":   0,
+	"high":     1,
+	"medium":   2,
+	"normal":   2,
+	"low":      3,
+	"lowest":   4,
+	"backlog":  4,
+}
+
+// ticketTypeAndPriority derives the issue type and priority from labels,
+// case-insensitively. The first matching label of each kind wins.
+//
+// Types come from unscoped labels (bug, feature, enhancement, epic, chore,
+// task) or scoped ones ("type: X", "type/X", "type::X", "kind/X").
+// Priorities come from P0–P4 labels or scoped ones ("priority: 1",
+// "priority::high", "prio/low"), where the value is 0–4, P0–P4 or one of
+// critical, urgent, high, medium, normal, low, lowest, backlog.
+func ticketTypeAndPriority(labels []string) (issueType string, priority int) {
+	issueType, priority = defaultIssueType, defaultPriority
+	typeSet, prioritySet := false, false
+
+	for _, label := range labels {
+		key, value := splitScopedLabel(strings.ToLower(strings.TrimSpace(label)))
+		switch key {
+		case "type", "kind":
+			if !typeSet && value != "" {
+				issueType, typeSet = value, true
+				if t, ok := typeLabels[value]; ok {
+					issueType = t
+				}
+			}
+		case "priority", "prio":
+			if p, ok := parsePriority(value); ok && !prioritySet {
+				priority, prioritySet = p, true
+			}
+		case "":
+			if t, ok := typeLabels[value]; ok && !typeSet {
+				issueType, typeSet = t, true
+			} else if p, ok := parsePriority(value); ok && !prioritySet && strings.HasPrefix(value, "p") {
+				priority, prioritySet = p, true
+			}
+		}
+	}
+	return issueType, priority
+}
+
+// splitScopedLabel splits "key::value", "key: value" and "key/value" labels.
+// Unscoped labels return an empty key.
+func splitScopedLabel(label string) (key, value string) {
+	for _, sep := range []string{"::", ":", "/"} {
+		if k, v, ok := strings.Cut(label, sep); ok {
+			return strings.TrimSpace(k), strings.TrimSpace(v)
+		}
+	}
+	return "", label
+}
+
+func parsePriority(value string) (int, bool) {
+	if p, ok := priorityWords[value]; ok {
+		return p, true
+	}
+	value = strings.TrimPrefix(value, "p")
+	if len(value) == 1 && value[0] >= '0' && value[0] <= '4' {
+		return int(value[0] - '0'), true
+	}
+	return 0, false
+}
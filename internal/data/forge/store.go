@@ -0,0 +1,203 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package forge
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// PollInterval is the minimum time between LatestUpdate requests; the TUI
+// polls every few seconds, which would eat into API rate limits.
+const PollInterval = 30 * time.Second
+
+const (
+	perPage = 100
+	// maxPages caps how many pages of open issues are read.
+	maxPages = 10
+)
+
+// flavor adapts the store to one issue tracker API.
+type flavor interface {
+	// openIssuesURL returns the first page of open issues.
+	openIssuesURL() string
+	// latestURL returns the most recently updated issue, in any state, so
+	// closing an issue is noticed as well.
+	latestURL() string
+	decode(body []byte) ([]issue, error)
+	// idPrefix is prepended to issue numbers to form ticket IDs.
+	idPrefix() string
+	// issueURL and commentsURL return a single issue and its comments.
+	issueURL(number string) string
+	commentsURL(number string) string
+	decodeDetail(body []byte) (*domain.TicketDetail, error)
+	decodeComments(body []byte) ([]domain.TicketComment, error)
+}
+
+// issue is a decoded API issue. Pull requests are kept so LatestUpdate sees
+// their updates too, but are never listed as tickets.
+type issue struct {
+	ticket      domain.Ticket
+	pullRequest bool
+}
+
+// Store implements data.TicketStore for a GitHub or GitLab project.
+type Store struct {
+	flavor flavor
+	client *client
+	now    func() time.Time
+
+	pollInterval time.Duration
+
+	mu       sync.Mutex
+	lastPoll time.Time
+	latest   time.Time
+}
+
+// Verify interface compliance at compile time.
+var (
+	_ data.TicketStore       = (*Store)(nil)
+	_ data.TicketDetailStore = (*Store)(nil)
+)
+
+// NewStore returns the store for a github or gitlab ticket source. The token
+// is read from src.TokenEnv, or GITHUB_TOKEN / GITLAB_TOKEN when unset; without
+// one, only public projects can be read.
+func NewStore(src domain.TicketSource) (*Store, error) {
+	switch src.Type {
+	case domain.TicketSourceGitHub:
+		return NewGitHubStore(src.Repo, src.URL, os.Getenv(tokenEnv(src, "GITHUB_TOKEN"))), nil
+	case domain.TicketSourceGitLab:
+		return NewGitLabStore(src.Repo, src.URL, os.Getenv(tokenEnv(src, "GITLAB_TOKEN"))), nil
+	default:
+		return nil, fmt.Errorf("unsupported ticket source %q", src.Type)
+	}
+}
+
+func tokenEnv(src domain.TicketSource, fallback string) string {
+	if src.TokenEnv != "" {
+		return src.TokenEnv
+	}
+	return fallback
+}
+
+func newStore(f flavor, c *client) *Store {
+	return &Store{flavor: f, client: c, now: time.Now, pollInterval: PollInterval}
+}
+
+// ListTickets returns open issues matching the filter, ordered like the Dolt
+// store: by priority, then most recently updated first. Only the first
+// maxPages pages of open issues are read; when more are left, the tickets
+// read are returned with data.ErrTicketsTruncated.
+func (s *Store) ListTickets(ctx context.Context, filter data.TicketFilter) ([]domain.Ticket, error) {
+	var issues []issue
+	more, err := s.client.getPages(ctx, s.flavor.openIssuesURL(), maxPages, func(body []byte) error {
+		page, err := s.flavor.decode(body)
+		issues = append(issues, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	search := strings.ToLower(filter.Search)
+	var tickets []domain.Ticket
+	for _, iss := range issues {
+		t := iss.ticket
+		if iss.pullRequest || t.Status != "open" {
+			continue
+		}
+		if filter.Status != "" && t.Status != filter.Status {
+			continue
+		}
+		if filter.IssueType != "" && t.IssueType != filter.IssueType {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(t.Title), search) {
+			continue
+		}
+		tickets = append(tickets, t)
+	}
+
+	slices.SortStableFunc(tickets, func(a, b domain.Ticket) int {
+		if a.Priority != b.Priority {
+			return a.Priority - b.Priority
+		}
+		return b.UpdatedAt.Compare(a.UpdatedAt)
+	})
+	if filter.Limit > 0 && len(tickets) > filter.Limit {
+		tickets = tickets[:filter.Limit]
+	}
+	if more {
+		return tickets, fmt.Errorf("%w: only the first %d open %s issues are listed", data.ErrTicketsTruncated, maxPages*perPage, s.client.service)
+	}
+	return tickets, nil
+}
+
+// LatestUpdate returns the update time of the most recently updated issue.
+// Within PollInterval of the previous request the cached value is returned.
+func (s *Store) LatestUpdate(ctx context.Context) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if !s.lastPoll.IsZero() && now.Sub(s.lastPoll) < s.pollInterval {
+		return s.latest, nil
+	}
+
+	body, _, err := s.client.get(ctx, s.flavor.latestURL())
+	if err != nil {
+		return time.Time{}, err
+	}
+	issues, err := s.flavor.decode(body)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to decode %s API response: %w", s.client.service, err)
+	}
+
+	s.lastPoll = now
+	s.latest = time.Time{}
+	if len(issues) > 0 {
+		s.latest = issues[0].ticket.UpdatedAt
+	}
+	return s.latest, nil
+}
+
+// TicketDetail fetches a single issue and its comments. Labels are returned
+// as-is; forge issues have no design, acceptance criteria or dependencies.
+func (s *Store) TicketDetail(ctx context.Context, id string) (*domain.TicketDetail, error) {
+	number, ok := strings.CutPrefix(id, s.flavor.idPrefix())
+	if !ok || number == "" || strings.Trim(number, "0123456789") != "" {
+		return nil, fmt.Errorf("%w: %s", data.ErrTicketNotFound, id)
+	}
+
+	body, _, err := s.client.get(ctx, s.flavor.issueURL(number))
+	if err != nil {
+		return nil, err
+	}
+	d, err := s.flavor.decodeDetail(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s API response: %w", s.client.service, err)
+	}
+
+	_, err = s.client.getPages(ctx, s.flavor.commentsURL(number), maxPages, func(body []byte) error {
+		page, err := s.flavor.decodeComments(body)
+		d.Comments = append(d.Comments, page...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
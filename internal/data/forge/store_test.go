@@ -0,0 +1,376 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package forge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// fakeAPI mimics the paginated, ETag-aware issue listings of GitHub and
+// GitLab. pages maps a request path plus query to a response body; a
+// "next" entry maps it to the path of the following page.
+type fakeAPI struct {
+	t      *testing.T
+	header string // auth header name
+	token  string
+	pages  map[string]string
+	next   map[string]string
+
+	mu       sync.Mutex
+	requests []string
+	notMod   int
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := r.URL.RequestURI()
+	f.requests = append(f.requests, key)
+
+	want := f.token
+	if f.header == "Authorization" {
+		want = "Bearer " + f.token
+	}
+	if got := r.Header.Get(f.header); got != want {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message":"Bad credentials"}`)
+		return
+	}
+
+	body, ok := f.pages[key]
+	if !ok {
+		f.t.Errorf("unexpected request %s", key)
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+		return
+	}
+	etag := fmt.Sprintf(`"%x"`, len(body))
+	if r.Header.Get("If-None-Match") == etag {
+		f.notMod++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	if next, ok := f.next[key]; ok {
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s%s>; rel="next", <http://%s/last>; rel="last"`, r.Host, next, r.Host))
+	}
+	w.Header().Set("ETag", etag)
+	fmt.Fprint(w, body)
+}
+
+const githubPage1 = `[
+  {"number": 1, "title": "Crash on start", "body": "Stack trace", "state": "open",
+   "created_at": "2026-01-01T10:00:00Z", "updated_at": "2026-01-02T10:00:00Z",
+   "labels": [{"name": "bug"}, {"name": "P1"}], "assignee": {"login": "alice"}},
+  {"number": 2, "title": "Bump deps", "body": "", "state": "open",
+   "created_at": "2026-01-01T10:00:00Z", "updated_at": "2026-01-05T10:00:00Z",
+   "labels": [], "pull_request": {"url": "x"}}
+]`
+
+const githubPage2 = `[
+  {"number": 3, "title": "Dark mode", "body": "Please", "state": "open",
+   "created_at": "2026-01-01T10:00:00Z", "updated_at": "2026-01-03T10:00:00Z",
+   "labels": [{"name": "enhancement"}, {"name": "priority: low"}], "assignee": null}
+]`
+
+func newGitHubAPI(t *testing.T) *fakeAPI {
+	return &fakeAPI{
+		t:      t,
+		header: "Authorization",
+		token:  "secret",
+		pages: map[string]string{
+			"/repos/acme/app/issues?state=open&per_page=100":                          githubPage1,
+			"/repos/acme/app/issues?state=open&per_page=100&page=2":                   githubPage2,
+			"/repos/acme/app/issues?state=all&sort=updated&direction=desc&per_page=1": `[{"number": 2, "state": "open", "updated_at": "2026-01-05T10:00:00Z", "pull_request": {}}]`,
+		},
+		next: map[string]string{
+			"/repos/acme/app/issues?state=open&per_page=100": "/repos/acme/app/issues?state=open&per_page=100&page=2",
+		},
+	}
+}
+
+func TestGitHubStore_ListTickets(t *testing.T) {
+	api := newGitHubAPI(t)
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	store := NewGitHubStore("acme/app", server.URL, "secret")
+	tickets, err := store.ListTickets(context.Background(), data.TicketFilter{})
+	if err != nil {
+		t.Fatalf("ListTickets failed: %v", err)
+	}
+
+	want := []domain.Ticket{
+		{ID: "gh-1", Title: "Crash on start", Description: "Stack trace", Status: "open", Priority: 1, IssueType: "bug", Assignee: "alice",
+			CreatedAt: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)},
+		{ID: "gh-3", Title: "Dark mode", Description: "Please", Status: "open", Priority: 3, IssueType: "feature",
+			CreatedAt: time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2026, 1, 3, 10, 0, 0, 0, time.UTC)},
+	}
+	if len(tickets) != len(want) {
+		t.Fatalf("expected %d tickets (pull request skipped), got %+v", len(want), tickets)
+	}
+	for i := range want {
+		if tickets[i] != want[i] {
+			t.Errorf("ticket %d = %+v, want %+v", i, tickets[i], want[i])
+		}
+	}
+
+	filtered, err := store.ListTickets(context.Background(), data.TicketFilter{IssueType: "feature"})
+	if err != nil {
+		t.Fatalf("ListTickets failed: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].ID != "gh-3" {
+		t.Errorf("expected only gh-3 for type feature, got %+v", filtered)
+	}
+	if api.notMod != 2 {
+		t.Errorf("expected both pages to be revalidated with their ETag, got %d 304s", api.notMod)
+	}
+}
+
+func TestGitHubStore_ListTickets_Truncated(t *testing.T) {
+	api := newGitHubAPI(t)
+	first := "/repos/acme/app/issues?state=open&per_page=100"
+	for page := 2; page <= maxPages; page++ {
+		url := fmt.Sprintf("%s&page=%d", first, page)
+		api.pages[url] = "[]"
+		api.next[url] = fmt.Sprintf("%s&page=%d", first, page+1)
+	}
+	api.pages[first+"&page=2"] = githubPage2
+	api.next[first] = first + "&page=2"
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	store := NewGitHubStore("acme/app", server.URL, "secret")
+	tickets, err := store.ListTickets(context.Background(), data.TicketFilter{})
+	if !errors.Is(err, data.ErrTicketsTruncated) {
+		t.Fatalf("expected ErrTicketsTruncated, got %v", err)
+	}
+	if len(tickets) != 2 {
+		t.Errorf("expected the tickets read so far, got %+v", tickets)
+	}
+}
+
+func TestGitHubStore_LatestUpdate(t *testing.T) {
+	api := newGitHubAPI(t)
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	store := NewGitHubStore("acme/app", server.URL, "secret")
+	now := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	want := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		latest, err := store.LatestUpdate(context.Background())
+		if err != nil {
+			t.Fatalf("LatestUpdate failed: %v", err)
+		}
+		if !latest.Equal(want) {
+			t.Errorf("LatestUpdate = %v, want %v", latest, want)
+		}
+	}
+	if len(api.requests) != 1 {
+		t.Fatalf("expected calls within PollInterval to be served from cache, got %d requests", len(api.requests))
+	}
+
+	now = now.Add(PollInterval)
+	if _, err := store.LatestUpdate(context.Background()); err != nil {
+		t.Fatalf("LatestUpdate failed: %v", err)
+	}
+	if len(api.requests) != 2 || api.notMod != 1 {
+		t.Errorf("expected a conditional request after PollInterval, got %d requests and %d 304s", len(api.requests), api.notMod)
+	}
+}
+
+func TestGitHubStore_BadToken(t *testing.T) {
+	server := httptest.NewServer(newGitHubAPI(t))
+	defer server.Close()
+
+	store := NewGitHubStore("acme/app", server.URL, "wrong")
+	_, err := store.ListTickets(context.Background(), data.TicketFilter{})
+	if err == nil {
+		t.Fatal("expected an error for a bad token")
+	}
+	for _, want := range []string{"GitHub API", "401", "Bad credentials", "check the API token"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got: %v", want, err)
+		}
+	}
+}
+
+func TestGitLabStore_ListTickets(t *testing.T) {
+	api := &fakeAPI{
+		t:      t,
+		header: "PRIVATE-TOKEN",
+		token:  "glpat",
+		pages: map[string]string{
+			"/projects/group%2Fapp/issues?state=opened&per_page=100": `[
+  {"iid": 7, "title": "Login fails", "description": "500 error", "state": "opened",
+   "created_at": "2026-01-01T10:00:00Z", "updated_at": "2026-01-02T10:00:00Z",
+   "labels": ["type::bug", "priority::critical"], "assignees": [{"username": "bob"}]},
+  {"iid": 8, "title": "Write docs", "description": "", "state": "opened",
+   "created_at": "2026-01-01T10:00:00Z", "updated_at": "2026-01-03T10:00:00Z",
+   "labels": [], "assignees": []}
+]`,
+			"/projects/group%2Fapp/issues?order_by=updated_at&sort=desc&per_page=1": `[{"iid": 8, "state": "closed", "updated_at": "2026-01-04T10:00:00Z"}]`,
+		},
+	}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	store := NewGitLabStore("group/app", server.URL, "glpat")
+	tickets, err := store.ListTickets(context.Background(), data.TicketFilter{})
+	if err != nil {
+		t.Fatalf("ListTickets failed: %v", err)
+	}
+	if len(tickets) != 2 {
+		t.Fatalf("expected 2 tickets, got %+v", tickets)
+	}
+	first := tickets[0]
+	if first.ID != "gl-7" || first.Status != "open" || first.IssueType != "bug" || first.Priority != 0 || first.Assignee != "bob" {
+		t.Errorf("unexpected first ticket: %+v", first)
+	}
+	if second := tickets[1]; second.ID != "gl-8" || second.IssueType != defaultIssueType || second.Priority != defaultPriority {
+		t.Errorf("expected defaults for an unlabeled issue, got %+v", second)
+	}
+
+	latest, err := store.LatestUpdate(context.Background())
+	if err != nil {
+		t.Fatalf("LatestUpdate failed: %v", err)
+	}
+	if want := time.Date(2026, 1, 4, 10, 0, 0, 0, time.UTC); !latest.Equal(want) {
+		t.Errorf("LatestUpdate = %v, want %v", latest, want)
+	}
+}
+
+func TestGitHubStore_TicketDetail(t *testing.T) {
+	api := newGitHubAPI(t)
+	api.pages["/repos/acme/app/issues/1"] = `{"number": 1, "title": "Crash on start", "body": "Stack trace", "state": "open",
+  "html_url": "https://github.com/acme/app/issues/1", "created_at": "2026-01-01T10:00:00Z", "updated_at": "2026-01-02T10:00:00Z",
+  "labels": [{"name": "bug"}, {"name": "P1"}], "assignee": null}`
+	api.pages["/repos/acme/app/issues/1/comments?per_page=100"] = `[
+  {"user": {"login": "bob"}, "body": "Same here", "created_at": "2026-01-03T10:00:00Z"}
+]`
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	store := NewGitHubStore("acme/app", server.URL, "secret")
+	d, err := store.TicketDetail(context.Background(), "gh-1")
+	if err != nil {
+		t.Fatalf("TicketDetail failed: %v", err)
+	}
+	if d.ID != "gh-1" || d.IssueType != "bug" || d.Priority != 1 || d.URL != "https://github.com/acme/app/issues/1" {
+		t.Errorf("unexpected detail: %+v", d)
+	}
+	if len(d.Labels) != 2 || len(d.Comments) != 1 || d.Comments[0].Author != "bob" {
+		t.Errorf("unexpected labels or comments: %+v", d)
+	}
+
+	for _, id := range []string{"gl-1", "gh-", "gh-1/../2"} {
+		if _, err := store.TicketDetail(context.Background(), id); !errors.Is(err, data.ErrTicketNotFound) {
+			t.Errorf("TicketDetail(%q): expected ErrTicketNotFound, got %v", id, err)
+		}
+	}
+}
+
+func TestGitLabStore_TicketDetail(t *testing.T) {
+	api := &fakeAPI{
+		t:      t,
+		header: "PRIVATE-TOKEN",
+		token:  "glpat",
+		pages: map[string]string{
+			"/projects/group%2Fapp/issues/7": `{"iid": 7, "title": "Login fails", "description": "500 error", "state": "closed",
+  "web_url": "https://gitlab.com/group/app/-/issues/7", "created_at": "2026-01-01T10:00:00Z", "updated_at": "2026-01-02T10:00:00Z",
+  "labels": ["type::bug"], "assignees": []}`,
+			"/projects/group%2Fapp/issues/7/notes?sort=asc&order_by=created_at&per_page=100": `[
+  {"author": {"username": "bob"}, "body": "changed the description", "system": true, "created_at": "2026-01-02T10:00:00Z"},
+  {"author": {"username": "carol"}, "body": "Fixed in !3", "system": false, "created_at": "2026-01-03T10:00:00Z"}
+]`,
+		},
+	}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	store := NewGitLabStore("group/app", server.URL, "glpat")
+	d, err := store.TicketDetail(context.Background(), "gl-7")
+	if err != nil {
+		t.Fatalf("TicketDetail failed: %v", err)
+	}
+	if d.Status != "closed" || d.URL != "https://gitlab.com/group/app/-/issues/7" {
+		t.Errorf("unexpected detail: %+v", d)
+	}
+	if len(d.Comments) != 1 || d.Comments[0].Author != "carol" {
+		t.Errorf("expected system notes to be skipped, got %+v", d.Comments)
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	t.Setenv("ACME_TOKEN", "secret")
+	server := httptest.NewServer(newGitHubAPI(t))
+	defer server.Close()
+
+	store, err := NewStore(domain.TicketSource{Type: domain.TicketSourceGitHub, Repo: "acme/app", URL: server.URL, TokenEnv: "ACME_TOKEN"})
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	if _, err := store.ListTickets(context.Background(), data.TicketFilter{}); err != nil {
+		t.Errorf("expected the token to be read from ACME_TOKEN, got: %v", err)
+	}
+
+	if _, err := NewStore(domain.TicketSource{Type: "jira"}); err == nil {
+		t.Error("expected an error for an unsupported source")
+	}
+}
+
+func TestNextLink(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{`<https://api.example.com/x?page=2>; rel="next", <https://api.example.com/x?page=5>; rel="last"`, "https://api.example.com/x?page=2"},
+		{`<https://api.example.com/x?page=1>; rel="prev"`, ""},
+	}
+	for _, tt := range tests {
+		if got := nextLink(tt.header); got != tt.want {
+			t.Errorf("nextLink(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestTicketTypeAndPriority(t *testing.T) {
+	tests := []struct {
+		labels       []string
+		wantType     string
+		wantPriority int
+	}{
+		{nil, "task", 2},
+		{[]string{"Bug", "P0"}, "bug", 0},
+		{[]string{"enhancement"}, "feature", 2},
+		{[]string{"type: Docs", "priority/high"}, "docs", 1},
+		{[]string{"kind/epic", "prio::4"}, "epic", 4},
+		{[]string{"good first issue", "help wanted"}, "task", 2},
+		{[]string{"bug", "feature", "P3", "P1"}, "bug", 3},
+	}
+	for _, tt := range tests {
+		gotType, gotPriority := ticketTypeAndPriority(tt.labels)
+		if gotType != tt.wantType || gotPriority != tt.wantPriority {
+			t.Errorf("ticketTypeAndPriority(%q) = %q, %d; want %q, %d", tt.labels, gotType, gotPriority, tt.wantType, tt.wantPriority)
+		}
+	}
+}
@@ -12,16 +12,23 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"strconv"
 	"strings"
 )
 
-// GitClient abstracts git operations for worktree discovery.
-// This interface enables testing with fake implementations and supports
-// alternative backends (e.g., worktree manager tools, non-git systems).
+// GitClient abstracts git operations for worktree discovery and prompt
+// context. This interface enables testing with fake implementations and
+// supports alternative backends (e.g., worktree manager tools, non-git systems).
 type GitClient interface {
 	ListWorktrees(ctx context.Context, repoRoot string) ([]WorktreeEntry, error)
 	DetectMainBranch(ctx context.Context, repoRoot string) (string, error)
 	CheckDirty(ctx context.Context, path string) bool
+	// RecentCommits returns up to n commits of the checked-out branch as
+	// "<short hash> <subject>", newest first.
+	RecentCommits(ctx context.Context, path string, n int) ([]string, error)
+	// ChangedFiles returns the files changed on the checked-out branch since
+	// it forked from base, including uncommitted changes.
+	ChangedFiles(ctx context.Context, path, base string) ([]string, error)
 }
 
 // WorktreeEntry represents a single worktree from git worktree list output.
@@ -71,6 +78,38 @@ func (g *gitClient) CheckDirty(ctx context.Context, path string) bool {
 	return len(bytes.TrimSpace(output)) > 0
 }
 
+func (g *gitClient) RecentCommits(ctx context.Context, path string, n int) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", path, "log", "--no-color", "--format=%h %s", "-n", strconv.Itoa(n))
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read git log: %w", err)
+	}
+	return splitLines(output), nil
+}
+
+func (g *gitClient) ChangedFiles(ctx context.Context, path, base string) ([]string, error) {
+	mergeBase, err := exec.CommandContext(ctx, "git", "-C", path, "merge-base", base, "HEAD").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base with %s: %w", base, err)
+	}
+	cmd := exec.CommandContext(ctx, "git", "-C", path, "diff", "--name-only", strings.TrimSpace(string(mergeBase)))
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files: %w", err)
+	}
+	return splitLines(output), nil
+}
+
+func splitLines(output []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
 // parseWorktreePorcelain parses the output of `git worktree list --porcelain`.
 // Each worktree is separated by an empty line, with fields in key-value format.
 func parseWorktreePorcelain(output []byte) []WorktreeEntry {
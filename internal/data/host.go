@@ -0,0 +1,51 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package data
+
+import (
+	"os"
+	"os/user"
+	"strings"
+	"sync"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// machineIDFiles are checked in order for a stable machine identifier.
+var machineIDFiles = []string{"/etc/machine-id", "/var/lib/dbus/machine-id"}
+
+var localHost = sync.OnceValue(detectLocalHost)
+
+// LocalHost returns the identity of the machine blunderbust runs on.
+func LocalHost() domain.HostIdentity {
+	return localHost()
+}
+
+func detectLocalHost() domain.HostIdentity {
+	var h domain.HostIdentity
+	if name, err := os.Hostname(); err == nil {
+		h.Hostname = name
+	}
+	for _, path := range machineIDFiles {
+		if data, err := os.ReadFile(path); err == nil {
+			if id := strings.TrimSpace(string(data)); id != "" {
+				h.MachineID = id
+				break
+			}
+		}
+	}
+	// Without a machine ID (e.g. macOS), the hostname is the best we have.
+	if h.MachineID == "" {
+		h.MachineID = h.Hostname
+	}
+	if u, err := user.Current(); err == nil {
+		h.User = u.Username
+	} else {
+		h.User = os.Getenv("USER")
+	}
+	return h
+}
@@ -0,0 +1,28 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Package jsonl implements the TicketStore interface on top of the
+// .beads/issues.jsonl file that Beads keeps as its git-synced export.
+//
+// It is used for Beads projects that have no Dolt database (no
+// .beads/metadata.json). Each line of the file is one issue. Readiness is
+// computed the way the ready_issues view does it: an issue is ready when it
+// is open, not ephemeral, not deferred into the future, and not blocked.
+// An issue is blocked by a "blocks" dependency on an issue that is not
+// closed, and children of a blocked issue ("parent-child" dependencies) are
+// blocked too.
+//
+// The file is not watched. Each call stats it and re-reads it when its
+// modification time or size changed, so LatestUpdate, polled by the TUI's
+// auto-refresh, reflects edits made by bd, git pulls or merges.
+//
+// Usage
+//
+//	if jsonl.Exists(".beads") {
+//		store, err := jsonl.NewStore(".beads")
+//		...
+//	}
+package jsonl
@@ -0,0 +1,342 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package jsonl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// FileName is the name of the Beads issue export inside the beads directory.
+const FileName = "issues.jsonl"
+
+// maxLineSize bounds a single issue line; descriptions can be long.
+const maxLineSize = 16 * 1024 * 1024
+
+// issue is one line of issues.jsonl. Fields Blunderbust does not use are
+// ignored.
+type issue struct {
+	ID                 string       `json:"id"`
+	Title              string       `json:"title"`
+	Description        string       `json:"description"`
+	Design             string       `json:"design"`
+	AcceptanceCriteria string       `json:"acceptance_criteria"`
+	Notes              string       `json:"notes"`
+	Status             string       `json:"status"`
+	Priority           int          `json:"priority"`
+	IssueType          string       `json:"issue_type"`
+	Assignee           string       `json:"assignee"`
+	CreatedAt          time.Time    `json:"created_at"`
+	UpdatedAt          time.Time    `json:"updated_at"`
+	DeferUntil         *time.Time   `json:"defer_until"`
+	Ephemeral          bool         `json:"ephemeral"`
+	Labels             []string     `json:"labels"`
+	Dependencies       []dependency `json:"dependencies"`
+	Comments           []comment    `json:"comments"`
+}
+
+// dependency is an edge from IssueID to DependsOnID.
+type dependency struct {
+	IssueID     string `json:"issue_id"`
+	DependsOnID string `json:"depends_on_id"`
+	Type        string `json:"type"`
+}
+
+// comment is a comment embedded in an issue line.
+type comment struct {
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Store implements data.TicketStore by reading issues.jsonl.
+type Store struct {
+	path string
+	now  func() time.Time
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	issues  []issue
+}
+
+// Verify interface compliance at compile time.
+var (
+	_ data.TicketStore       = (*Store)(nil)
+	_ data.TicketDetailStore = (*Store)(nil)
+)
+
+// Exists reports whether beadsDir contains an issues.jsonl file.
+func Exists(beadsDir string) bool {
+	info, err := os.Stat(filepath.Join(beadsDir, FileName))
+	return err == nil && !info.IsDir()
+}
+
+// NewStore returns a store reading beadsDir/issues.jsonl. The file is parsed
+// once up front so a malformed export is reported immediately.
+func NewStore(beadsDir string) (*Store, error) {
+	s := &Store{path: filepath.Join(beadsDir, FileName), now: time.Now}
+	if _, err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Path returns the location of the issues file.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// ListTickets returns ready issues matching the filter, ordered like the Dolt
+// store: by priority, then most recently updated first.
+func (s *Store) ListTickets(_ context.Context, filter data.TicketFilter) ([]domain.Ticket, error) {
+	issues, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	search := strings.ToLower(filter.Search)
+	var tickets []domain.Ticket
+	for _, iss := range readyIssues(issues, s.now()) {
+		if filter.Status != "" && iss.Status != filter.Status {
+			continue
+		}
+		if filter.IssueType != "" && iss.IssueType != filter.IssueType {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(iss.Title), search) {
+			continue
+		}
+		tickets = append(tickets, iss.ticket())
+	}
+
+	slices.SortStableFunc(tickets, func(a, b domain.Ticket) int {
+		if a.Priority != b.Priority {
+			return a.Priority - b.Priority
+		}
+		return b.UpdatedAt.Compare(a.UpdatedAt)
+	})
+	if filter.Limit > 0 && len(tickets) > filter.Limit {
+		tickets = tickets[:filter.Limit]
+	}
+	return tickets, nil
+}
+
+// TicketDetail returns the issue with the given ID, whatever its status,
+// along with the issues it depends on and the issues depending on it.
+func (s *Store) TicketDetail(_ context.Context, id string) (*domain.TicketDetail, error) {
+	issues, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]issue, len(issues))
+	for _, iss := range issues {
+		byID[iss.ID] = iss
+	}
+	iss, ok := byID[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", data.ErrTicketNotFound, id)
+	}
+
+	d := &domain.TicketDetail{
+		Ticket:             iss.ticket(),
+		Design:             iss.Design,
+		AcceptanceCriteria: iss.AcceptanceCriteria,
+		Notes:              iss.Notes,
+		Labels:             iss.Labels,
+	}
+	edge := func(otherID, depType string) domain.TicketDependency {
+		other := byID[otherID]
+		return domain.TicketDependency{ID: otherID, Title: other.Title, Status: other.Status, Type: depType}
+	}
+	for _, other := range issues {
+		for _, dep := range other.Dependencies {
+			issueID := dep.IssueID
+			if issueID == "" {
+				issueID = other.ID
+			}
+			switch {
+			case issueID == id:
+				d.Dependencies = append(d.Dependencies, edge(dep.DependsOnID, dep.Type))
+			case dep.DependsOnID == id:
+				d.Dependents = append(d.Dependents, edge(issueID, dep.Type))
+			}
+		}
+	}
+	for _, c := range iss.Comments {
+		d.Comments = append(d.Comments, domain.TicketComment{Author: c.Author, Text: c.Text, CreatedAt: c.CreatedAt})
+	}
+	return d, nil
+}
+
+// LatestUpdate returns the later of the newest updated_at among ready issues
+// and the file's modification time, so closing, deleting or unblocking
+// issues is noticed as well, including when no issue is ready any more.
+func (s *Store) LatestUpdate(_ context.Context) (time.Time, error) {
+	issues, err := s.load()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	s.mu.Lock()
+	latest := s.modTime
+	s.mu.Unlock()
+	for _, iss := range readyIssues(issues, s.now()) {
+		if iss.UpdatedAt.After(latest) {
+			latest = iss.UpdatedAt
+		}
+	}
+	return latest, nil
+}
+
+// load returns the parsed issues, re-reading the file when its modification
+// time or size changed since the last read.
+func (s *Store) load() ([]issue, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.path, err)
+	}
+	if s.issues != nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return s.issues, nil
+	}
+
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.path, err)
+	}
+	issues, err := parseIssues(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", s.path, err)
+	}
+
+	s.issues, s.modTime, s.size = issues, info.ModTime(), info.Size()
+	return issues, nil
+}
+
+// parseIssues decodes one issue per non-empty line. Later lines win when an
+// ID repeats, as they do after an unresolved merge appends both sides.
+func parseIssues(content []byte) ([]issue, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	issues := []issue{}
+	index := make(map[string]int)
+	for line := 1; scanner.Scan(); line++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		var iss issue
+		if err := json.Unmarshal(raw, &iss); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if iss.ID == "" {
+			return nil, fmt.Errorf("line %d: issue has no id", line)
+		}
+		if i, ok := index[iss.ID]; ok {
+			issues[i] = iss
+			continue
+		}
+		index[iss.ID] = len(issues)
+		issues = append(issues, iss)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// readyIssues returns the issues the ready_issues view would contain at now.
+func readyIssues(issues []issue, now time.Time) []issue {
+	blocked := blockedIssues(issues)
+	var ready []issue
+	for _, iss := range issues {
+		if iss.Status != "open" || iss.Ephemeral || blocked[iss.ID] {
+			continue
+		}
+		if iss.DeferUntil != nil && iss.DeferUntil.After(now) {
+			continue
+		}
+		ready = append(ready, iss)
+	}
+	return ready
+}
+
+// blockedIssues returns the IDs of issues with a "blocks" dependency on an
+// issue that is not closed, plus the descendants of those issues through
+// "parent-child" dependencies. Dependencies on unknown issues are ignored.
+func blockedIssues(issues []issue) map[string]bool {
+	status := make(map[string]string, len(issues))
+	for _, iss := range issues {
+		status[iss.ID] = iss.Status
+	}
+
+	blocked := make(map[string]bool)
+	children := make(map[string][]string)
+	for _, iss := range issues {
+		for _, d := range iss.Dependencies {
+			issueID := d.IssueID
+			if issueID == "" {
+				issueID = iss.ID
+			}
+			switch d.Type {
+			case "blocks":
+				if s, ok := status[d.DependsOnID]; ok && s != "closed" && s != "tombstone" {
+					blocked[issueID] = true
+				}
+			case "parent-child":
+				children[d.DependsOnID] = append(children[d.DependsOnID], issueID)
+			}
+		}
+	}
+
+	queue := make([]string, 0, len(blocked))
+	for id := range blocked {
+		queue = append(queue, id)
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, child := range children[id] {
+			if !blocked[child] {
+				blocked[child] = true
+				queue = append(queue, child)
+			}
+		}
+	}
+	return blocked
+}
+
+func (iss issue) ticket() domain.Ticket {
+	return domain.Ticket{
+		ID:          iss.ID,
+		Title:       iss.Title,
+		Description: iss.Description,
+		Status:      iss.Status,
+		Priority:    iss.Priority,
+		IssueType:   iss.IssueType,
+		Assignee:    iss.Assignee,
+		CreatedAt:   iss.CreatedAt,
+		UpdatedAt:   iss.UpdatedAt,
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package jsonl
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/data"
+)
+
+const sampleIssues = `{"id":"bd-1","title":"Ready task","status":"open","priority":2,"issue_type":"task","created_at":"2026-01-01T10:00:00Z","updated_at":"2026-01-02T10:00:00Z"}
+{"id":"bd-2","title":"Closed blocker","status":"closed","priority":1,"issue_type":"task","created_at":"2026-01-01T10:00:00Z","updated_at":"2026-01-01T10:00:00Z"}
+{"id":"bd-3","title":"Unblocked bug","status":"open","priority":1,"issue_type":"bug","created_at":"2026-01-01T10:00:00Z","updated_at":"2026-01-03T10:00:00Z","dependencies":[{"issue_id":"bd-3","depends_on_id":"bd-2","type":"blocks"}]}
+{"id":"bd-4","title":"Blocked epic","status":"open","priority":0,"issue_type":"epic","created_at":"2026-01-01T10:00:00Z","updated_at":"2026-01-01T10:00:00Z","dependencies":[{"issue_id":"bd-4","depends_on_id":"bd-1","type":"blocks"}]}
+{"id":"bd-5","title":"Child of blocked epic","status":"open","priority":0,"issue_type":"task","created_at":"2026-01-01T10:00:00Z","updated_at":"2026-01-01T10:00:00Z","dependencies":[{"issue_id":"bd-5","depends_on_id":"bd-4","type":"parent-child"}]}
+{"id":"bd-6","title":"Deferred","status":"open","priority":0,"issue_type":"task","created_at":"2026-01-01T10:00:00Z","updated_at":"2026-01-01T10:00:00Z","defer_until":"2099-01-01T00:00:00Z"}
+{"id":"bd-7","title":"Ephemeral","status":"open","priority":0,"issue_type":"task","created_at":"2026-01-01T10:00:00Z","updated_at":"2026-01-01T10:00:00Z","ephemeral":true}
+{"id":"bd-8","title":"In progress","status":"in_progress","priority":0,"issue_type":"task","created_at":"2026-01-01T10:00:00Z","updated_at":"2026-01-01T10:00:00Z"}
+{"id":"bd-9","title":"Related only","status":"open","priority":2,"issue_type":"task","created_at":"2026-01-01T10:00:00Z","updated_at":"2026-01-01T10:00:00Z","dependencies":[{"issue_id":"bd-9","depends_on_id":"bd-8","type":"related"}]}
+`
+
+func writeIssues(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write issues: %v", err)
+	}
+}
+
+func ticketIDs(t *testing.T, store *Store, filter data.TicketFilter) []string {
+	t.Helper()
+	tickets, err := store.ListTickets(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("ListTickets failed: %v", err)
+	}
+	ids := make([]string, len(tickets))
+	for i, tk := range tickets {
+		ids[i] = tk.ID
+	}
+	return ids
+}
+
+func TestStore_ListTickets_Readiness(t *testing.T) {
+	dir := t.TempDir()
+	writeIssues(t, dir, sampleIssues)
+
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	got := strings.Join(ticketIDs(t, store, data.TicketFilter{}), ",")
+	if got != "bd-3,bd-1,bd-9" {
+		t.Errorf("expected ready tickets bd-3,bd-1,bd-9 in priority order, got %s", got)
+	}
+}
+
+func TestStore_ListTickets_Filters(t *testing.T) {
+	dir := t.TempDir()
+	writeIssues(t, dir, sampleIssues)
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		filter data.TicketFilter
+		want   string
+	}{
+		{"issue type", data.TicketFilter{IssueType: "bug"}, "bd-3"},
+		{"search is case-insensitive", data.TicketFilter{Search: "READY"}, "bd-1"},
+		{"limit", data.TicketFilter{Limit: 2}, "bd-3,bd-1"},
+		{"status", data.TicketFilter{Status: "in_progress"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.Join(ticketIDs(t, store, tt.filter), ","); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStore_ReloadsOnChange(t *testing.T) {
+	dir := t.TempDir()
+	writeIssues(t, dir, sampleIssues)
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	before, err := store.LatestUpdate(context.Background())
+	if err != nil {
+		t.Fatalf("LatestUpdate failed: %v", err)
+	}
+
+	// Closing bd-1 unblocks bd-4 and, through it, bd-5.
+	updated := strings.Replace(sampleIssues,
+		`{"id":"bd-1","title":"Ready task","status":"open"`,
+		`{"id":"bd-1","title":"Ready task","status":"closed"`, 1)
+	writeIssues(t, dir, updated)
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(filepath.Join(dir, FileName), future, future); err != nil {
+		t.Fatalf("failed to touch issues: %v", err)
+	}
+
+	got := strings.Join(ticketIDs(t, store, data.TicketFilter{}), ",")
+	if got != "bd-4,bd-5,bd-3,bd-9" {
+		t.Errorf("expected unblocked tickets after reload, got %s", got)
+	}
+	after, err := store.LatestUpdate(context.Background())
+	if err != nil {
+		t.Fatalf("LatestUpdate failed: %v", err)
+	}
+	if !after.After(before) {
+		t.Errorf("expected LatestUpdate to advance, before=%v after=%v", before, after)
+	}
+}
+
+func TestStore_TicketDetail(t *testing.T) {
+	dir := t.TempDir()
+	writeIssues(t, dir, sampleIssues+`{"id":"bd-10","title":"Detailed","design":"Use a pane","acceptance_criteria":"- renders","status":"closed","priority":1,"issue_type":"feature","labels":["tui"],"comments":[{"author":"bob","text":"Done","created_at":"2026-01-04T10:00:00Z"}],"created_at":"2026-01-01T10:00:00Z","updated_at":"2026-01-01T10:00:00Z","dependencies":[{"issue_id":"bd-10","depends_on_id":"bd-4","type":"blocks"}]}
+`)
+
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	d, err := store.TicketDetail(context.Background(), "bd-10")
+	if err != nil {
+		t.Fatalf("TicketDetail failed: %v", err)
+	}
+	if d.Design != "Use a pane" || d.AcceptanceCriteria != "- renders" || d.Status != "closed" {
+		t.Errorf("unexpected detail fields: %+v", d)
+	}
+	if len(d.Labels) != 1 || len(d.Comments) != 1 || d.Comments[0].Author != "bob" {
+		t.Errorf("unexpected labels or comments: %+v", d)
+	}
+	if len(d.Dependencies) != 1 || d.Dependencies[0].ID != "bd-4" || d.Dependencies[0].Title != "Blocked epic" {
+		t.Errorf("unexpected dependencies: %+v", d.Dependencies)
+	}
+
+	epic, err := store.TicketDetail(context.Background(), "bd-4")
+	if err != nil {
+		t.Fatalf("TicketDetail failed: %v", err)
+	}
+	var dependents []string
+	for _, dep := range epic.Dependents {
+		dependents = append(dependents, dep.ID+":"+dep.Type)
+	}
+	if got := strings.Join(dependents, ","); got != "bd-5:parent-child,bd-10:blocks" {
+		t.Errorf("unexpected dependents: %s", got)
+	}
+
+	if _, err := store.TicketDetail(context.Background(), "bd-99"); !errors.Is(err, data.ErrTicketNotFound) {
+		t.Errorf("expected ErrTicketNotFound, got %v", err)
+	}
+}
+
+func TestStore_LatestUpdate_NoReadyIssues(t *testing.T) {
+	dir := t.TempDir()
+	writeIssues(t, dir, `{"id":"bd-1","title":"Last","status":"open","updated_at":"2026-01-01T10:00:00Z"}`+"\n")
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+
+	before, err := store.LatestUpdate(context.Background())
+	if err != nil {
+		t.Fatalf("LatestUpdate failed: %v", err)
+	}
+
+	// Closing the last ready issue must still be noticed.
+	writeIssues(t, dir, `{"id":"bd-1","title":"Last","status":"closed","updated_at":"2026-01-01T10:00:00Z"}`+"\n")
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(filepath.Join(dir, FileName), future, future); err != nil {
+		t.Fatalf("failed to touch issues: %v", err)
+	}
+
+	after, err := store.LatestUpdate(context.Background())
+	if err != nil {
+		t.Fatalf("LatestUpdate failed: %v", err)
+	}
+	if !after.After(before) {
+		t.Errorf("expected LatestUpdate to advance, before=%v after=%v", before, after)
+	}
+}
+
+func TestParseIssues(t *testing.T) {
+	issues, err := parseIssues([]byte(`{"id":"bd-1","title":"Old"}` + "\n\n" + `{"id":"bd-1","title":"New"}` + "\n"))
+	if err != nil {
+		t.Fatalf("parseIssues failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Title != "New" {
+		t.Errorf("expected the later duplicate to win, got %+v", issues)
+	}
+
+	if _, err := parseIssues([]byte(`{"id":"bd-1"}` + "\n{not json\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected an error naming line 2, got %v", err)
+	}
+	if _, err := parseIssues([]byte(`{"title":"No id"}`)); err == nil {
+		t.Error("expected an error for an issue without id")
+	}
+}
+
+func TestExists(t *testing.T) {
+	dir := t.TempDir()
+	if Exists(dir) {
+		t.Error("expected Exists to be false without issues.jsonl")
+	}
+	writeIssues(t, dir, "")
+	if !Exists(dir) {
+		t.Error("expected Exists to be true with issues.jsonl")
+	}
+	if _, err := NewStore(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected NewStore to fail for a missing file")
+	}
+}
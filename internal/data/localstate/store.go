@@ -0,0 +1,502 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+// Package localstate stores running agents and agent session history in a
+// local bbolt file, keeping Blunderbust's bookkeeping out of the Beads
+// database so it never leaves uncommitted changes there.
+//
+// The file is opened for each operation rather than held open, because bbolt
+// locks it exclusively and several bdb instances may run at once.
+package localstate
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+var (
+	bucketRunningAgents = []byte("running_agents")
+	bucketAgentSessions = []byte("agent_sessions")
+	bucketImports       = []byte("imports")
+)
+
+// lockTimeout bounds how long an operation waits for another bdb instance
+// that has the file open.
+const lockTimeout = 5 * time.Second
+
+// FileName is the name of the state file inside the state directory.
+const FileName = "agent_state.db"
+
+// Store implements data.AgentStateStore on a local bbolt file.
+type Store struct {
+	path string
+
+	// host overrides the local host identity (used by tests).
+	host *domain.HostIdentity
+}
+
+// Verify interface compliance at compile time.
+var _ data.AgentStateStore = (*Store)(nil)
+
+// New returns a Store backed by the file at path. The file and its directory
+// are created on first write.
+func New(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the location of the state file.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Host returns the identity that running agents are recorded and validated
+// against.
+func (s *Store) Host() domain.HostIdentity {
+	if s.host != nil {
+		return *s.host
+	}
+	return data.LocalHost()
+}
+
+// UpsertRunningAgent inserts or updates one running agent, identified by
+// project, worktree, PID and machine like the Dolt table's unique key.
+func (s *Store) UpsertRunningAgent(_ context.Context, a domain.PersistedRunningAgent) error {
+	if a.ProjectDir == "" || a.WorktreePath == "" || a.PID <= 0 || a.HarnessName == "" {
+		return fmt.Errorf("invalid running agent data")
+	}
+	if a.LauncherID == "" {
+		a.LauncherID = "unknown"
+	}
+	if a.MachineID == "" {
+		host := s.Host()
+		a.Hostname, a.MachineID, a.Owner = host.Hostname, host.MachineID, host.User
+	}
+
+	now := time.Now().UTC()
+	err := s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketRunningAgents)
+		a.ID = 0
+		a.StartedAt = now
+		err := forEach(b, func(existing *domain.PersistedRunningAgent) (bool, error) {
+			if existing.ProjectDir == a.ProjectDir && existing.WorktreePath == a.WorktreePath &&
+				existing.PID == a.PID && existing.MachineID == a.MachineID {
+				a.ID, a.StartedAt = existing.ID, existing.StartedAt
+				return true, nil
+			}
+			return false, nil
+		})
+		if err != nil {
+			return err
+		}
+		if a.ID == 0 {
+			id, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			a.ID = int(id)
+		}
+		a.Status = domain.AgentRunning
+		a.ExitCode = nil
+		a.EndedAt = nil
+		a.LastSeen = now
+		return put(b, a.ID, a)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert running agent: %w", err)
+	}
+	return nil
+}
+
+// ListRunningAgentsByProjects returns agents for the given project
+// directories, most recently started first.
+func (s *Store) ListRunningAgentsByProjects(_ context.Context, projectDirs []string) ([]domain.PersistedRunningAgent, error) {
+	if len(projectDirs) == 0 {
+		return nil, nil
+	}
+
+	var agents []domain.PersistedRunningAgent
+	err := s.view(func(tx *bolt.Tx) error {
+		return forEach(tx.Bucket(bucketRunningAgents), func(a *domain.PersistedRunningAgent) (bool, error) {
+			if slices.Contains(projectDirs, a.ProjectDir) {
+				agents = append(agents, *a)
+			}
+			return false, nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list running agents: %w", err)
+	}
+	slices.SortStableFunc(agents, func(a, b domain.PersistedRunningAgent) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+	return agents, nil
+}
+
+// FinishRunningAgent records the final status of an agent identified by its
+// launcher ID and PID on this host. The record is kept as history.
+func (s *Store) FinishRunningAgent(_ context.Context, launcherID string, pid int, status domain.AgentStatus, exitCode *int) error {
+	machineID := s.Host().MachineID
+	now := time.Now().UTC()
+	err := s.updateRunning(func(a *domain.PersistedRunningAgent) bool {
+		if a.LauncherID != launcherID || a.PID != pid || a.Status != domain.AgentRunning ||
+			(a.MachineID != machineID && a.MachineID != "") {
+			return false
+		}
+		a.Status = status
+		a.ExitCode = exitCode
+		a.EndedAt = &now
+		a.LastSeen = now
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to finish running agent %s: %w", launcherID, err)
+	}
+	return nil
+}
+
+// ValidateAndPruneRunningAgents validates running agents and returns the live
+// ones, with the same semantics as the Dolt implementation: finished records
+// are left as history, records whose process is gone are marked completed,
+// and agents of other hosts are returned unvalidated.
+func (s *Store) ValidateAndPruneRunningAgents(ctx context.Context, projectDirs []string, inspector data.ProcessInspector) ([]domain.PersistedRunningAgent, error) {
+	if inspector == nil {
+		inspector = data.HostProcessInspector{}
+	}
+
+	agents, err := s.ListRunningAgentsByProjects(ctx, projectDirs)
+	if err != nil {
+		return nil, err
+	}
+
+	host := s.Host()
+	valid := make([]domain.PersistedRunningAgent, 0, len(agents))
+	for _, a := range agents {
+		if a.Status != domain.AgentRunning {
+			continue
+		}
+		if !a.RunsOn(host) {
+			valid = append(valid, a)
+			continue
+		}
+		if !data.AgentProcessAlive(ctx, a, inspector) {
+			if err := s.markRunningAgentEnded(ctx, a); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := s.touchRunningAgent(a.ID); err != nil {
+			return nil, err
+		}
+		valid = append(valid, a)
+	}
+	return valid, nil
+}
+
+// DeleteStaleRunningAgents deletes this host's still-running records not
+// seen for maxAge. Finished records are history and are not affected.
+func (s *Store) DeleteStaleRunningAgents(_ context.Context, maxAge time.Duration) error {
+	if maxAge <= 0 {
+		maxAge = data.DefaultRunningAgentMaxAge
+	}
+	cutoff := time.Now().UTC().Add(-maxAge)
+	machineID := s.Host().MachineID
+
+	err := s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketRunningAgents)
+		var stale []int
+		err := forEach(b, func(a *domain.PersistedRunningAgent) (bool, error) {
+			if a.Status == domain.AgentRunning && a.LastSeen.Before(cutoff) &&
+				(a.MachineID == machineID || a.MachineID == "") {
+				stale = append(stale, a.ID)
+			}
+			return false, nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, id := range stale {
+			if err := b.Delete(itob(id)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed deleting stale running agents: %w", err)
+	}
+	return nil
+}
+
+// RecordAgentSession appends one finished agent run to the history.
+func (s *Store) RecordAgentSession(_ context.Context, a domain.AgentSession) error {
+	if a.ProjectDir == "" || a.HarnessName == "" || a.StartedAt.IsZero() || a.EndedAt.IsZero() {
+		return fmt.Errorf("invalid agent session data")
+	}
+	if a.WorktreePath == "" {
+		a.WorktreePath = a.ProjectDir
+	}
+	a.StartedAt = a.StartedAt.UTC()
+	a.EndedAt = a.EndedAt.UTC()
+
+	err := s.update(func(tx *bolt.Tx) error {
+		return appendSession(tx.Bucket(bucketAgentSessions), a)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record agent session: %w", err)
+	}
+	return nil
+}
+
+// ListAgentSessions returns finished sessions, most recent first.
+// An empty projectDirs lists sessions for all projects; limit <= 0 means no limit.
+func (s *Store) ListAgentSessions(_ context.Context, projectDirs []string, limit int) ([]domain.AgentSession, error) {
+	var sessions []domain.AgentSession
+	err := s.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketAgentSessions)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(_, v []byte) error {
+			var a domain.AgentSession
+			if err := json.Unmarshal(v, &a); err != nil {
+				return err
+			}
+			if len(projectDirs) == 0 || slices.Contains(projectDirs, a.ProjectDir) {
+				sessions = append(sessions, a)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agent sessions: %w", err)
+	}
+	slices.SortStableFunc(sessions, func(a, b domain.AgentSession) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+	if limit > 0 && len(sessions) > limit {
+		sessions = sessions[:limit]
+	}
+	return sessions, nil
+}
+
+// ImportFrom copies this host's running agents and all finished sessions for
+// projectDirs from src, typically the Dolt running_agents and agent_sessions
+// tables. The import runs once per key; later calls return 0 without reading
+// src. It returns the number of records imported.
+func (s *Store) ImportFrom(ctx context.Context, key string, src data.AgentStateStore, projectDirs []string) (int, error) {
+	done, err := s.Imported(key)
+	if err != nil || done {
+		return 0, err
+	}
+
+	agents, err := src.ListRunningAgentsByProjects(ctx, projectDirs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read running agents to import: %w", err)
+	}
+	sessions, err := src.ListAgentSessions(ctx, projectDirs, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read agent sessions to import: %w", err)
+	}
+
+	host := s.Host()
+	imported := 0
+	err = s.update(func(tx *bolt.Tx) error {
+		running := tx.Bucket(bucketRunningAgents)
+		for _, a := range agents {
+			if a.Status != domain.AgentRunning || !a.RunsOn(host) {
+				continue
+			}
+			id, err := running.NextSequence()
+			if err != nil {
+				return err
+			}
+			a.ID = int(id)
+			if err := put(running, a.ID, a); err != nil {
+				return err
+			}
+			imported++
+		}
+		history := tx.Bucket(bucketAgentSessions)
+		for _, a := range sessions {
+			if err := appendSession(history, a); err != nil {
+				return err
+			}
+			imported++
+		}
+		return tx.Bucket(bucketImports).Put([]byte(key), []byte(time.Now().UTC().Format(time.RFC3339)))
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to import agent state: %w", err)
+	}
+	return imported, nil
+}
+
+// Imported reports whether ImportFrom already ran for key.
+func (s *Store) Imported(key string) (bool, error) {
+	var done bool
+	err := s.view(func(tx *bolt.Tx) error {
+		if b := tx.Bucket(bucketImports); b != nil {
+			done = b.Get([]byte(key)) != nil
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to read import state: %w", err)
+	}
+	return done, nil
+}
+
+// markRunningAgentEnded marks an agent whose process disappeared while
+// nobody was watching as completed and records it in the session history.
+func (s *Store) markRunningAgentEnded(ctx context.Context, a domain.PersistedRunningAgent) error {
+	err := s.updateRunning(func(r *domain.PersistedRunningAgent) bool {
+		if r.ID != a.ID {
+			return false
+		}
+		r.Status = domain.AgentCompleted
+		endedAt := r.LastSeen
+		r.EndedAt = &endedAt
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed marking running agent id=%d ended: %w", a.ID, err)
+	}
+	return s.RecordAgentSession(ctx, a.EndedSession(domain.AgentCompleted))
+}
+
+func (s *Store) touchRunningAgent(id int) error {
+	now := time.Now().UTC()
+	err := s.updateRunning(func(a *domain.PersistedRunningAgent) bool {
+		if a.ID != id {
+			return false
+		}
+		a.LastSeen = now
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed touching running agent id=%d: %w", id, err)
+	}
+	return nil
+}
+
+// updateRunning applies fn to every running agent record and saves the
+// records for which it returns true.
+func (s *Store) updateRunning(fn func(a *domain.PersistedRunningAgent) bool) error {
+	return s.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketRunningAgents)
+		var changed []domain.PersistedRunningAgent
+		err := forEach(b, func(a *domain.PersistedRunningAgent) (bool, error) {
+			if fn(a) {
+				changed = append(changed, *a)
+			}
+			return false, nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, a := range changed {
+			if err := put(b, a.ID, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// update runs fn in a write transaction, creating the file and buckets as needed.
+func (s *Store) update(fn func(tx *bolt.Tx) error) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	db, err := bolt.Open(s.path, 0o600, &bolt.Options{Timeout: lockTimeout})
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", s.path, err)
+	}
+	defer db.Close()
+
+	return db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{bucketRunningAgents, bucketAgentSessions, bucketImports} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return fn(tx)
+	})
+}
+
+// view runs fn in a read-only transaction. A missing file is treated as an
+// empty store; buckets may be nil.
+func (s *Store) view(fn func(tx *bolt.Tx) error) error {
+	if _, err := os.Stat(s.path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	db, err := bolt.Open(s.path, 0o600, &bolt.Options{Timeout: lockTimeout, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", s.path, err)
+	}
+	defer db.Close()
+
+	return db.View(fn)
+}
+
+// forEach decodes each running agent in b until fn returns true.
+func forEach(b *bolt.Bucket, fn func(a *domain.PersistedRunningAgent) (bool, error)) error {
+	if b == nil {
+		return nil
+	}
+	c := b.Cursor()
+	for k, v := c.First(); k != nil; k, v = c.Next() {
+		var a domain.PersistedRunningAgent
+		if err := json.Unmarshal(v, &a); err != nil {
+			return fmt.Errorf("corrupt running agent record %d: %w", btoi(k), err)
+		}
+		stop, err := fn(&a)
+		if err != nil || stop {
+			return err
+		}
+	}
+	return nil
+}
+
+func appendSession(b *bolt.Bucket, a domain.AgentSession) error {
+	id, err := b.NextSequence()
+	if err != nil {
+		return err
+	}
+	a.ID = int(id)
+	return put(b, a.ID, a)
+}
+
+func put(b *bolt.Bucket, id int, v any) error {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return b.Put(itob(id), buf)
+}
+
+// itob encodes an ID as a big-endian key so records iterate in insertion order.
+func itob(id int) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(id))
+	return key
+}
+
+func btoi(key []byte) int {
+	return int(binary.BigEndian.Uint64(key))
+}
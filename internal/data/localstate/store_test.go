@@ -0,0 +1,309 @@
+package localstate
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+var testHost = domain.HostIdentity{Hostname: "laptop", MachineID: "m-1", User: "alice"}
+
+type fakeInspector struct {
+	exists   map[int]bool
+	commands map[int]string
+}
+
+func (f fakeInspector) PIDExists(pid int) bool {
+	return f.exists[pid]
+}
+
+func (f fakeInspector) CommandForPID(_ context.Context, pid int) (string, error) {
+	return f.commands[pid], nil
+}
+
+// fakeSource serves the listing methods ImportFrom reads; the embedded nil
+// interface panics if anything else is called.
+type fakeSource struct {
+	data.AgentStateStore
+	agents   []domain.PersistedRunningAgent
+	sessions []domain.AgentSession
+	reads    int
+}
+
+func (f *fakeSource) ListRunningAgentsByProjects(context.Context, []string) ([]domain.PersistedRunningAgent, error) {
+	f.reads++
+	return f.agents, nil
+}
+
+func (f *fakeSource) ListAgentSessions(context.Context, []string, int) ([]domain.AgentSession, error) {
+	return f.sessions, nil
+}
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	s := New(filepath.Join(t.TempDir(), "state", FileName))
+	s.host = &testHost
+	return s
+}
+
+func testAgent(pid int) domain.PersistedRunningAgent {
+	return domain.PersistedRunningAgent{
+		ProjectDir:    "/repo",
+		WorktreePath:  "/repo",
+		PID:           pid,
+		LauncherType:  domain.LauncherTypeTmux,
+		LauncherID:    "bb-1",
+		Ticket:        "bb-1",
+		HarnessName:   "kilocode",
+		HarnessBinary: "kilo",
+		Model:         "m",
+	}
+}
+
+func TestStore_EmptyFile(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	agents, err := s.ListRunningAgentsByProjects(ctx, []string{"/repo"})
+	if err != nil || len(agents) != 0 {
+		t.Fatalf("expected no agents, got %v, %v", agents, err)
+	}
+	sessions, err := s.ListAgentSessions(ctx, nil, 0)
+	if err != nil || len(sessions) != 0 {
+		t.Fatalf("expected no sessions, got %v, %v", sessions, err)
+	}
+}
+
+func TestStore_UpsertRunningAgent(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	if err := s.UpsertRunningAgent(ctx, testAgent(1234)); err != nil {
+		t.Fatalf("UpsertRunningAgent failed: %v", err)
+	}
+	agents, err := s.ListRunningAgentsByProjects(ctx, []string{"/repo"})
+	if err != nil {
+		t.Fatalf("ListRunningAgentsByProjects failed: %v", err)
+	}
+	if len(agents) != 1 {
+		t.Fatalf("expected 1 agent, got %d", len(agents))
+	}
+	first := agents[0]
+	if first.Status != domain.AgentRunning || first.MachineID != "m-1" || first.Owner != "alice" {
+		t.Errorf("unexpected agent: %+v", first)
+	}
+
+	// Upserting the same agent again updates the record in place.
+	updated := testAgent(1234)
+	updated.Model = "other"
+	if err := s.UpsertRunningAgent(ctx, updated); err != nil {
+		t.Fatalf("UpsertRunningAgent failed: %v", err)
+	}
+	agents, _ = s.ListRunningAgentsByProjects(ctx, []string{"/repo"})
+	if len(agents) != 1 || agents[0].ID != first.ID || agents[0].Model != "other" {
+		t.Fatalf("expected the record to be updated in place, got %+v", agents)
+	}
+	if !agents[0].StartedAt.Equal(first.StartedAt) {
+		t.Errorf("expected started_at to be kept, got %v want %v", agents[0].StartedAt, first.StartedAt)
+	}
+
+	if err := s.UpsertRunningAgent(ctx, domain.PersistedRunningAgent{ProjectDir: "/repo"}); err == nil {
+		t.Error("expected an error for invalid agent data")
+	}
+}
+
+func TestStore_ListRunningAgentsByProjects_FiltersAndSorts(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	other := testAgent(3)
+	other.ProjectDir, other.WorktreePath = "/other", "/other"
+	for _, a := range []domain.PersistedRunningAgent{testAgent(1), other, testAgent(2)} {
+		if err := s.UpsertRunningAgent(ctx, a); err != nil {
+			t.Fatalf("UpsertRunningAgent failed: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	agents, err := s.ListRunningAgentsByProjects(ctx, []string{"/repo"})
+	if err != nil {
+		t.Fatalf("ListRunningAgentsByProjects failed: %v", err)
+	}
+	if len(agents) != 2 || agents[0].PID != 2 || agents[1].PID != 1 {
+		t.Fatalf("expected agents 2 and 1 newest first, got %+v", agents)
+	}
+}
+
+func TestStore_FinishRunningAgent(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	if err := s.UpsertRunningAgent(ctx, testAgent(1234)); err != nil {
+		t.Fatalf("UpsertRunningAgent failed: %v", err)
+	}
+
+	code := 1
+	if err := s.FinishRunningAgent(ctx, "bb-1", 1234, domain.AgentFailed, &code); err != nil {
+		t.Fatalf("FinishRunningAgent failed: %v", err)
+	}
+	agents, _ := s.ListRunningAgentsByProjects(ctx, []string{"/repo"})
+	if len(agents) != 1 {
+		t.Fatalf("expected the finished record to be kept, got %d", len(agents))
+	}
+	a := agents[0]
+	if a.Status != domain.AgentFailed || a.ExitCode == nil || *a.ExitCode != 1 || a.EndedAt == nil {
+		t.Errorf("unexpected finished agent: %+v", a)
+	}
+}
+
+func TestStore_ValidateAndPruneRunningAgents(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	remote := testAgent(3)
+	remote.Hostname, remote.MachineID = "desktop", "m-2"
+	for _, a := range []domain.PersistedRunningAgent{testAgent(1), testAgent(2), remote} {
+		if err := s.UpsertRunningAgent(ctx, a); err != nil {
+			t.Fatalf("UpsertRunningAgent failed: %v", err)
+		}
+	}
+
+	inspector := fakeInspector{
+		exists:   map[int]bool{1: true},
+		commands: map[int]string{1: "kilo run"},
+	}
+	valid, err := s.ValidateAndPruneRunningAgents(ctx, []string{"/repo"}, inspector)
+	if err != nil {
+		t.Fatalf("ValidateAndPruneRunningAgents failed: %v", err)
+	}
+	pids := map[int]bool{}
+	for _, a := range valid {
+		pids[a.PID] = true
+	}
+	if len(valid) != 2 || !pids[1] || !pids[3] {
+		t.Fatalf("expected the live local agent and the remote agent, got %+v", valid)
+	}
+
+	sessions, err := s.ListAgentSessions(ctx, []string{"/repo"}, 0)
+	if err != nil {
+		t.Fatalf("ListAgentSessions failed: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].Status != domain.AgentCompleted {
+		t.Fatalf("expected the dead agent to be recorded as completed, got %+v", sessions)
+	}
+}
+
+func TestStore_DeleteStaleRunningAgents(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	if err := s.UpsertRunningAgent(ctx, testAgent(1234)); err != nil {
+		t.Fatalf("UpsertRunningAgent failed: %v", err)
+	}
+
+	if err := s.DeleteStaleRunningAgents(ctx, time.Hour); err != nil {
+		t.Fatalf("DeleteStaleRunningAgents failed: %v", err)
+	}
+	agents, _ := s.ListRunningAgentsByProjects(ctx, []string{"/repo"})
+	if len(agents) != 1 {
+		t.Fatalf("expected a recently seen agent to be kept, got %d", len(agents))
+	}
+
+	if err := s.DeleteStaleRunningAgents(ctx, time.Nanosecond); err != nil {
+		t.Fatalf("DeleteStaleRunningAgents failed: %v", err)
+	}
+	agents, _ = s.ListRunningAgentsByProjects(ctx, []string{"/repo"})
+	if len(agents) != 0 {
+		t.Fatalf("expected the stale agent to be deleted, got %d", len(agents))
+	}
+}
+
+func TestStore_AgentSessions(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	for i, dir := range []string{"/repo", "/other", "/repo"} {
+		err := s.RecordAgentSession(ctx, domain.AgentSession{
+			ProjectDir:  dir,
+			HarnessName: "kilocode",
+			Status:      domain.AgentCompleted,
+			StartedAt:   start.Add(time.Duration(i) * time.Hour),
+			EndedAt:     start.Add(time.Duration(i)*time.Hour + time.Minute),
+		})
+		if err != nil {
+			t.Fatalf("RecordAgentSession failed: %v", err)
+		}
+	}
+	if err := s.RecordAgentSession(ctx, domain.AgentSession{ProjectDir: "/repo"}); err == nil {
+		t.Error("expected an error for invalid session data")
+	}
+
+	all, err := s.ListAgentSessions(ctx, nil, 0)
+	if err != nil || len(all) != 3 {
+		t.Fatalf("expected 3 sessions, got %d, %v", len(all), err)
+	}
+	if all[0].ProjectDir != "/repo" || !all[0].StartedAt.Equal(start.Add(2*time.Hour)) {
+		t.Errorf("expected newest session first, got %+v", all[0])
+	}
+	if all[0].WorktreePath != "/repo" {
+		t.Errorf("expected worktree to default to the project dir, got %q", all[0].WorktreePath)
+	}
+
+	limited, _ := s.ListAgentSessions(ctx, []string{"/repo"}, 1)
+	if len(limited) != 1 || limited[0].ProjectDir != "/repo" {
+		t.Fatalf("expected 1 /repo session, got %+v", limited)
+	}
+}
+
+func TestStore_ImportFrom(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	local := testAgent(1)
+	local.Hostname, local.MachineID = "laptop", "m-1"
+	local.Status = domain.AgentRunning
+	remote := testAgent(2)
+	remote.Hostname, remote.MachineID = "desktop", "m-2"
+	remote.Status = domain.AgentRunning
+	finished := testAgent(3)
+	finished.Status = domain.AgentCompleted
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	src := &fakeSource{
+		agents: []domain.PersistedRunningAgent{local, remote, finished},
+		sessions: []domain.AgentSession{{
+			ProjectDir:  "/repo",
+			HarnessName: "kilocode",
+			Status:      domain.AgentCompleted,
+			StartedAt:   start,
+			EndedAt:     start.Add(time.Minute),
+		}},
+	}
+
+	n, err := s.ImportFrom(ctx, "dolt:/repo", src, []string{"/repo"})
+	if err != nil {
+		t.Fatalf("ImportFrom failed: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 imported records, got %d", n)
+	}
+	agents, _ := s.ListRunningAgentsByProjects(ctx, []string{"/repo"})
+	if len(agents) != 1 || agents[0].PID != 1 {
+		t.Fatalf("expected only the local running agent, got %+v", agents)
+	}
+	sessions, _ := s.ListAgentSessions(ctx, nil, 0)
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 imported session, got %d", len(sessions))
+	}
+
+	n, err = s.ImportFrom(ctx, "dolt:/repo", src, []string{"/repo"})
+	if err != nil || n != 0 || src.reads != 1 {
+		t.Fatalf("expected the second import to be skipped, got n=%d reads=%d err=%v", n, src.reads, err)
+	}
+	done, err := s.Imported("dolt:/repo")
+	if err != nil || !done {
+		t.Fatalf("expected the import to be recorded, got %v, %v", done, err)
+	}
+}
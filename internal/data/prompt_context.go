@@ -0,0 +1,225 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// repoDocFiles are the agent instruction files read by the docs provider,
+// in the order they are concatenated.
+var repoDocFiles = []string{"AGENTS.md", "CLAUDE.md"}
+
+// ContextGatherer collects the prompt context of a harness's enabled
+// providers from the ticket store and the work directory.
+type ContextGatherer struct {
+	gitClient GitClient
+}
+
+// NewContextGatherer creates a new ContextGatherer with the given GitClient.
+// If gitClient is nil, a default gitClient is used.
+func NewContextGatherer(gitClient GitClient) *ContextGatherer {
+	if gitClient == nil {
+		gitClient = NewGitClient()
+	}
+	return &ContextGatherer{gitClient: gitClient}
+}
+
+// Gather runs each provider in order for ticketID, launched in workDir.
+// A failing provider records its error in its section and leaves its fields
+// empty; the other providers still run. store may be nil when no project is
+// loaded.
+func (g *ContextGatherer) Gather(ctx context.Context, store TicketStore, ticketID, workDir string, providers []domain.ContextProvider) domain.PromptContext {
+	var pc domain.PromptContext
+	var detail *domain.TicketDetail
+	var detailErr error
+	loadDetail := func() (*domain.TicketDetail, error) {
+		if detail == nil && detailErr == nil {
+			detail, detailErr = ticketDetail(ctx, store, ticketID)
+		}
+		return detail, detailErr
+	}
+
+	for _, p := range providers {
+		section := domain.ContextSection{Provider: p.Name}
+		limit := p.EffectiveLimit()
+		switch p.Name {
+		case domain.ContextRepoDocs:
+			pc.RepoDocs, section = readRepoDocs(workDir, limit)
+		case domain.ContextRelated:
+			section = g.related(ctx, store, loadDetail, limit, &pc)
+		case domain.ContextDependencies:
+			section = dependencies(loadDetail, limit, &pc)
+		case domain.ContextGitLog:
+			commits, err := g.gitClient.RecentCommits(ctx, workDir, limit+1)
+			section.Err = err
+			pc.GitLog, section.Truncated = truncateList(commits, limit)
+			section.Summary = countNoun(len(pc.GitLog), "commit")
+		case domain.ContextChangedFiles:
+			section = g.changedFiles(ctx, workDir, limit, &pc)
+		default:
+			section.Err = fmt.Errorf("unknown context provider %q", p.Name)
+		}
+		section.Provider = p.Name
+		pc.Sections = append(pc.Sections, section)
+	}
+	return pc
+}
+
+func ticketDetail(ctx context.Context, store TicketStore, id string) (*domain.TicketDetail, error) {
+	ds, ok := store.(TicketDetailStore)
+	if !ok {
+		return nil, errors.New("the ticket store does not provide ticket dependencies")
+	}
+	return ds.TicketDetail(ctx, id)
+}
+
+// readRepoDocs concatenates the repo doc files found in workDir, cut to
+// limit bytes. A CLAUDE.md that only repeats AGENTS.md, as when it is a
+// symlink to it, is read once.
+func readRepoDocs(workDir string, limit int) (string, domain.ContextSection) {
+	section := domain.ContextSection{}
+	var parts, found []string
+	for _, name := range repoDocFiles {
+		content, err := os.ReadFile(filepath.Join(workDir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			section.Err = fmt.Errorf("failed to read %s: %w", name, err)
+			continue
+		}
+		text := strings.TrimSpace(string(content))
+		if text == "" || (len(parts) > 0 && text == parts[len(parts)-1]) {
+			continue
+		}
+		parts = append(parts, text)
+		found = append(found, name)
+	}
+
+	docs := strings.Join(parts, "\n\n")
+	if len(docs) > limit {
+		docs = strings.ToValidUTF8(docs[:limit], "")
+		section.Truncated = true
+	}
+	if len(found) == 0 {
+		section.Summary = "no AGENTS.md or CLAUDE.md"
+	} else {
+		section.Summary = fmt.Sprintf("%s (%s)", strings.Join(found, ", "), formatBytes(len(docs)))
+	}
+	return docs, section
+}
+
+// related fills the parent epic and its other children.
+func (g *ContextGatherer) related(ctx context.Context, store TicketStore, loadDetail func() (*domain.TicketDetail, error), limit int, pc *domain.PromptContext) domain.ContextSection {
+	section := domain.ContextSection{}
+	detail, err := loadDetail()
+	if err != nil {
+		section.Err = err
+		return section
+	}
+	for _, dep := range detail.Dependencies {
+		if dep.Type == "parent-child" {
+			parent := dep
+			pc.ParentTicket = &parent
+			break
+		}
+	}
+	if pc.ParentTicket == nil {
+		section.Summary = "no parent"
+		return section
+	}
+
+	parent, err := ticketDetail(ctx, store, pc.ParentTicket.ID)
+	if err != nil {
+		section.Err = fmt.Errorf("failed to load parent %s: %w", pc.ParentTicket.ID, err)
+		return section
+	}
+	var siblings []domain.TicketDependency
+	for _, child := range parent.Dependents {
+		if child.Type == "parent-child" && child.ID != detail.ID {
+			siblings = append(siblings, child)
+		}
+	}
+	pc.SiblingTickets, section.Truncated = truncateList(siblings, limit)
+	section.Summary = fmt.Sprintf("parent %s, %s", pc.ParentTicket.ID, countNoun(len(pc.SiblingTickets), "sibling"))
+	return section
+}
+
+// dependencies fills the blocking edges in both directions.
+func dependencies(loadDetail func() (*domain.TicketDetail, error), limit int, pc *domain.PromptContext) domain.ContextSection {
+	section := domain.ContextSection{}
+	detail, err := loadDetail()
+	if err != nil {
+		section.Err = err
+		return section
+	}
+	var blockedBy, blocks []domain.TicketDependency
+	for _, dep := range detail.Dependencies {
+		if dep.Type == "blocks" {
+			blockedBy = append(blockedBy, dep)
+		}
+	}
+	for _, dep := range detail.Dependents {
+		if dep.Type == "blocks" {
+			blocks = append(blocks, dep)
+		}
+	}
+	var cutBy, cut bool
+	pc.BlockedBy, cutBy = truncateList(blockedBy, limit)
+	pc.Blocks, cut = truncateList(blocks, limit)
+	section.Truncated = cutBy || cut
+	section.Summary = fmt.Sprintf("blocked by %d, blocks %d", len(pc.BlockedBy), len(pc.Blocks))
+	return section
+}
+
+// changedFiles fills the files changed since workDir's branch forked from
+// the main branch.
+func (g *ContextGatherer) changedFiles(ctx context.Context, workDir string, limit int, pc *domain.PromptContext) domain.ContextSection {
+	section := domain.ContextSection{}
+	base, err := g.gitClient.DetectMainBranch(ctx, workDir)
+	if err != nil {
+		section.Err = err
+		return section
+	}
+	files, err := g.gitClient.ChangedFiles(ctx, workDir, base)
+	if err != nil {
+		section.Err = err
+		return section
+	}
+	pc.ChangedFiles, section.Truncated = truncateList(files, limit)
+	section.Summary = fmt.Sprintf("%s vs %s", countNoun(len(pc.ChangedFiles), "file"), base)
+	return section
+}
+
+func truncateList[T any](items []T, limit int) ([]T, bool) {
+	if len(items) > limit {
+		return items[:limit], true
+	}
+	return items, false
+}
+
+func countNoun(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+func formatBytes(n int) string {
+	if n < 1024 {
+		return fmt.Sprintf("%d B", n)
+	}
+	return fmt.Sprintf("%.1f KB", float64(n)/1024)
+}
@@ -0,0 +1,137 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package data_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/fake"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func contextTestStore() *fake.TicketStore {
+	store := fake.NewWithSampleData()
+	store.Details["bb-001"] = domain.TicketDetail{
+		Dependents: []domain.TicketDependency{
+			{ID: "bb-003", Type: "parent-child"},
+			{ID: "bb-004", Type: "parent-child"},
+			{ID: "bb-005", Type: "parent-child"},
+		},
+	}
+	store.Details["bb-004"] = domain.TicketDetail{
+		Dependencies: []domain.TicketDependency{
+			{ID: "bb-001", Title: "Bootstrap Go module", Type: "parent-child"},
+			{ID: "bb-002", Type: "blocks"},
+		},
+		Dependents: []domain.TicketDependency{{ID: "bb-005", Type: "blocks"}},
+	}
+	return store
+}
+
+func TestContextGatherer_Tickets(t *testing.T) {
+	gatherer := data.NewContextGatherer(fake.NewFakeGitClient())
+	pc := gatherer.Gather(context.Background(), contextTestStore(), "bb-004", t.TempDir(), []domain.ContextProvider{
+		{Name: domain.ContextRelated, Limit: 1},
+		{Name: domain.ContextDependencies},
+	})
+
+	if pc.ParentTicket == nil || pc.ParentTicket.ID != "bb-001" {
+		t.Fatalf("expected parent bb-001, got %+v", pc.ParentTicket)
+	}
+	if len(pc.SiblingTickets) != 1 || pc.SiblingTickets[0].ID != "bb-003" {
+		t.Errorf("expected siblings cut to [bb-003], got %+v", pc.SiblingTickets)
+	}
+	if len(pc.BlockedBy) != 1 || pc.BlockedBy[0].ID != "bb-002" {
+		t.Errorf("expected blocked by bb-002, got %+v", pc.BlockedBy)
+	}
+	if len(pc.Blocks) != 1 || pc.Blocks[0].ID != "bb-005" {
+		t.Errorf("expected blocks bb-005, got %+v", pc.Blocks)
+	}
+
+	if len(pc.Sections) != 2 {
+		t.Fatalf("expected 2 sections, got %d", len(pc.Sections))
+	}
+	if s := pc.Sections[0]; !s.Truncated || s.Summary != "parent bb-001, 1 sibling" {
+		t.Errorf("unexpected related section: %+v", s)
+	}
+	if s := pc.Sections[1]; s.Truncated || s.Summary != "blocked by 1, blocks 1" {
+		t.Errorf("unexpected dependencies section: %+v", s)
+	}
+}
+
+func TestContextGatherer_StoreWithoutDetails(t *testing.T) {
+	gatherer := data.NewContextGatherer(fake.NewFakeGitClient())
+	pc := gatherer.Gather(context.Background(), nil, "bb-004", t.TempDir(), []domain.ContextProvider{
+		{Name: domain.ContextDependencies},
+	})
+
+	if pc.Sections[0].Err == nil {
+		t.Error("expected an error for a store without ticket details")
+	}
+}
+
+func TestContextGatherer_RepoDocs(t *testing.T) {
+	dir := t.TempDir()
+	agents := "# Agents\n\nRun make test before committing."
+	if err := os.WriteFile(filepath.Join(dir, "AGENTS.md"), []byte(agents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "CLAUDE.md"), []byte(agents+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	gatherer := data.NewContextGatherer(fake.NewFakeGitClient())
+	pc := gatherer.Gather(context.Background(), nil, "bb-004", dir, []domain.ContextProvider{{Name: domain.ContextRepoDocs}})
+	if pc.RepoDocs != agents {
+		t.Errorf("expected a CLAUDE.md repeating AGENTS.md to be read once, got %q", pc.RepoDocs)
+	}
+	if s := pc.Sections[0]; s.Summary != "AGENTS.md (42 B)" || s.Truncated {
+		t.Errorf("unexpected docs section: %+v", s)
+	}
+
+	pc = gatherer.Gather(context.Background(), nil, "bb-004", dir, []domain.ContextProvider{{Name: domain.ContextRepoDocs, Limit: 8}})
+	if pc.RepoDocs != "# Agents" || !pc.Sections[0].Truncated {
+		t.Errorf("expected docs cut to 8 bytes, got %q", pc.RepoDocs)
+	}
+}
+
+func TestContextGatherer_Git(t *testing.T) {
+	client := fake.NewFakeGitClient()
+	client.SetCommits("/work", []string{"c3 third", "c2 second", "c1 first"})
+	client.SetMainBranch("/work", "main")
+	client.SetChangedFiles("/work", []string{"a.go", "b.go"})
+
+	gatherer := data.NewContextGatherer(client)
+	pc := gatherer.Gather(context.Background(), nil, "bb-004", "/work", []domain.ContextProvider{
+		{Name: domain.ContextGitLog, Limit: 2},
+		{Name: domain.ContextChangedFiles},
+	})
+
+	if strings.Join(pc.GitLog, ",") != "c3 third,c2 second" {
+		t.Errorf("expected the 2 newest commits, got %v", pc.GitLog)
+	}
+	if !pc.Sections[0].Truncated {
+		t.Error("expected git_log to be marked truncated")
+	}
+	if len(pc.ChangedFiles) != 2 {
+		t.Errorf("expected 2 changed files, got %v", pc.ChangedFiles)
+	}
+	if s := pc.Sections[1]; s.Summary != "2 files vs main" || s.Err != nil {
+		t.Errorf("unexpected changed_files section: %+v", s)
+	}
+
+	client.SetError("changedfiles", "/work", errFakeGit)
+	pc = gatherer.Gather(context.Background(), nil, "bb-004", "/work", []domain.ContextProvider{{Name: domain.ContextChangedFiles}})
+	if pc.Sections[0].Err == nil || pc.ChangedFiles != nil {
+		t.Errorf("expected the git error to be recorded, got %+v", pc.Sections[0])
+	}
+}
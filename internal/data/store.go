@@ -8,6 +8,7 @@ package data
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/megatherium/blunderbust/internal/domain"
@@ -19,6 +20,56 @@ type TicketStore interface {
 	LatestUpdate(ctx context.Context) (time.Time, error)
 }
 
+// TicketDetailStore is implemented by ticket stores that can load the full
+// record of a single ticket, including comments and dependencies.
+type TicketDetailStore interface {
+	TicketDetail(ctx context.Context, id string) (*domain.TicketDetail, error)
+}
+
+// ErrTicketNotFound is returned by TicketDetail for an unknown ticket ID.
+var ErrTicketNotFound = errors.New("ticket not found")
+
+// ErrTicketsTruncated is returned, wrapped, by ListTickets together with
+// the tickets read so far when a store stops reading before the end of
+// the list.
+var ErrTicketsTruncated = errors.New("ticket list truncated")
+
+// TicketWriter is implemented by ticket stores that can create and update
+// tickets.
+type TicketWriter interface {
+	// CreateTicket creates an open ticket and returns its ID.
+	CreateTicket(ctx context.Context, t NewTicket) (string, error)
+	UpdateTicket(ctx context.Context, id string, u TicketUpdate) error
+}
+
+// TicketCommenter is implemented by ticket stores that can add comments to
+// tickets.
+type TicketCommenter interface {
+	AddComment(ctx context.Context, id, text string) error
+}
+
+// NewTicket describes a ticket to create.
+type NewTicket struct {
+	Title       string
+	IssueType   string
+	Priority    int
+	Description string
+	ParentID    string   // parent-child dependency, e.g. on an epic
+	DependsOn   []string // tickets that block the new one
+}
+
+// TicketUpdate lists the fields to change; nil fields are left alone.
+type TicketUpdate struct {
+	Priority *int
+	Status   *string
+	Assignee *string
+}
+
+// IsEmpty reports whether the update changes nothing.
+func (u TicketUpdate) IsEmpty() bool {
+	return u.Priority == nil && u.Status == nil && u.Assignee == nil
+}
+
 // TicketFilter controls which tickets are returned by ListTickets.
 type TicketFilter struct {
 	Status    string
@@ -0,0 +1,128 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// AgentSession is one finished agent run, as recorded in the agent_sessions
+// history table. ExitCode is nil when the exit status was not observed.
+type AgentSession struct {
+	ID            int
+	ProjectDir    string
+	WorktreePath  string
+	LauncherID    string
+	Ticket        string
+	TicketTitle   string
+	HarnessName   string
+	Model         string
+	Agent         string
+	Status        AgentStatus
+	ExitCode      *int
+	StartedAt     time.Time
+	EndedAt       time.Time
+	OutputLogPath string
+}
+
+// Duration returns how long the session ran, or zero if the times are unknown.
+func (s AgentSession) Duration() time.Duration {
+	if s.StartedAt.IsZero() || s.EndedAt.Before(s.StartedAt) {
+		return 0
+	}
+	return s.EndedAt.Sub(s.StartedAt)
+}
+
+// SessionGroupStats aggregates sessions sharing one key (a model or harness name).
+type SessionGroupStats struct {
+	Key         string
+	Runs        int
+	Succeeded   int
+	Failed      int
+	AvgDuration time.Duration
+}
+
+// SuccessRate returns the fraction of runs that completed successfully.
+func (g SessionGroupStats) SuccessRate() float64 {
+	if g.Runs == 0 {
+		return 0
+	}
+	return float64(g.Succeeded) / float64(g.Runs)
+}
+
+// SessionSummary holds aggregate statistics over a set of agent sessions.
+type SessionSummary struct {
+	Total     SessionGroupStats
+	ByModel   []SessionGroupStats
+	ByHarness []SessionGroupStats
+}
+
+// SummarizeSessions computes per-model and per-harness statistics.
+// Groups are ordered by run count, then key. Sessions without a model are
+// grouped under "(none)".
+func SummarizeSessions(sessions []AgentSession) SessionSummary {
+	type acc struct {
+		stats SessionGroupStats
+		total time.Duration
+		timed int
+	}
+	add := func(a *acc, s AgentSession) {
+		a.stats.Runs++
+		switch s.Status {
+		case AgentCompleted:
+			a.stats.Succeeded++
+		case AgentFailed:
+			a.stats.Failed++
+		}
+		if d := s.Duration(); d > 0 {
+			a.total += d
+			a.timed++
+		}
+	}
+	finish := func(a *acc) SessionGroupStats {
+		if a.timed > 0 {
+			a.stats.AvgDuration = a.total / time.Duration(a.timed)
+		}
+		return a.stats
+	}
+	group := func(keyOf func(AgentSession) string) []SessionGroupStats {
+		groups := make(map[string]*acc)
+		for _, s := range sessions {
+			k := keyOf(s)
+			if k == "" {
+				k = "(none)"
+			}
+			if groups[k] == nil {
+				groups[k] = &acc{stats: SessionGroupStats{Key: k}}
+			}
+			add(groups[k], s)
+		}
+		out := make([]SessionGroupStats, 0, len(groups))
+		for _, a := range groups {
+			out = append(out, finish(a))
+		}
+		sort.Slice(out, func(i, j int) bool {
+			if out[i].Runs != out[j].Runs {
+				return out[i].Runs > out[j].Runs
+			}
+			return out[i].Key < out[j].Key
+		})
+		return out
+	}
+
+	total := &acc{stats: SessionGroupStats{Key: "all"}}
+	for _, s := range sessions {
+		add(total, s)
+	}
+
+	return SessionSummary{
+		Total:     finish(total),
+		ByModel:   group(func(s AgentSession) string { return s.Model }),
+		ByHarness: group(func(s AgentSession) string { return s.HarnessName }),
+	}
+}
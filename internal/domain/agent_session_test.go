@@ -0,0 +1,88 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAgentSession_Duration(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	s := AgentSession{StartedAt: start, EndedAt: start.Add(5 * time.Minute)}
+	if got := s.Duration(); got != 5*time.Minute {
+		t.Errorf("Duration() = %v, want 5m", got)
+	}
+
+	if got := (AgentSession{EndedAt: start}).Duration(); got != 0 {
+		t.Errorf("Duration() without start = %v, want 0", got)
+	}
+	if got := (AgentSession{StartedAt: start, EndedAt: start.Add(-time.Minute)}).Duration(); got != 0 {
+		t.Errorf("Duration() with end before start = %v, want 0", got)
+	}
+}
+
+func TestSummarizeSessions(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	session := func(harness, model string, status AgentStatus, minutes int) AgentSession {
+		return AgentSession{
+			HarnessName: harness,
+			Model:       model,
+			Status:      status,
+			StartedAt:   start,
+			EndedAt:     start.Add(time.Duration(minutes) * time.Minute),
+		}
+	}
+
+	summary := SummarizeSessions([]AgentSession{
+		session("codex", "gpt", AgentCompleted, 10),
+		session("codex", "gpt", AgentFailed, 20),
+		session("claude", "sonnet", AgentCompleted, 30),
+		session("claude", "", AgentCompleted, 0),
+	})
+
+	if summary.Total.Runs != 4 || summary.Total.Succeeded != 3 || summary.Total.Failed != 1 {
+		t.Errorf("unexpected total: %+v", summary.Total)
+	}
+	if rate := summary.Total.SuccessRate(); rate != 0.75 {
+		t.Errorf("SuccessRate() = %v, want 0.75", rate)
+	}
+
+	if len(summary.ByModel) != 3 {
+		t.Fatalf("expected 3 model groups, got %d", len(summary.ByModel))
+	}
+	if summary.ByModel[0].Key != "gpt" || summary.ByModel[0].Runs != 2 {
+		t.Errorf("expected gpt first with 2 runs, got %+v", summary.ByModel[0])
+	}
+	if summary.ByModel[1].Key != "(none)" {
+		t.Errorf("expected sessions without model grouped as (none), got %q", summary.ByModel[1].Key)
+	}
+
+	if len(summary.ByHarness) != 2 {
+		t.Fatalf("expected 2 harness groups, got %d", len(summary.ByHarness))
+	}
+	for _, g := range summary.ByHarness {
+		switch g.Key {
+		case "codex":
+			if g.AvgDuration != 15*time.Minute {
+				t.Errorf("codex AvgDuration = %v, want 15m", g.AvgDuration)
+			}
+		case "claude":
+			// The zero-length session carries no timing information.
+			if g.AvgDuration != 30*time.Minute {
+				t.Errorf("claude AvgDuration = %v, want 30m", g.AvgDuration)
+			}
+		default:
+			t.Errorf("unexpected harness group %q", g.Key)
+		}
+	}
+
+	if empty := SummarizeSessions(nil); empty.Total.Runs != 0 || empty.Total.SuccessRate() != 0 {
+		t.Errorf("unexpected summary for no sessions: %+v", empty)
+	}
+}
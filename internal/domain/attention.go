@@ -0,0 +1,112 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// AttentionState classifies whether a running agent needs the user, based
+// on its pane output.
+type AttentionState int
+
+const (
+	AttentionNone AttentionState = iota
+	AttentionApproval
+	AttentionIdle
+	AttentionError
+)
+
+// String returns the config name of the state.
+func (s AttentionState) String() string {
+	switch s {
+	case AttentionApproval:
+		return "approval"
+	case AttentionIdle:
+		return "idle"
+	case AttentionError:
+		return "error"
+	default:
+		return "none"
+	}
+}
+
+// Description returns a short phrase describing the state, e.g. for
+// notifications ("bb-1 is waiting for approval").
+func (s AttentionState) Description() string {
+	switch s {
+	case AttentionApproval:
+		return "is waiting for approval"
+	case AttentionIdle:
+		return "is idle"
+	case AttentionError:
+		return "reported an error"
+	default:
+		return "is working"
+	}
+}
+
+// ParseAttentionState parses a config state name.
+func ParseAttentionState(name string) (AttentionState, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "approval":
+		return AttentionApproval, nil
+	case "idle":
+		return AttentionIdle, nil
+	case "error":
+		return AttentionError, nil
+	default:
+		return AttentionNone, fmt.Errorf("unknown attention state %q (must be 'approval', 'idle' or 'error')", name)
+	}
+}
+
+// AttentionRule flags an agent as needing attention when Pattern matches
+// the tail of its pane output.
+type AttentionRule struct {
+	State   AttentionState
+	Pattern *regexp.Regexp
+}
+
+// Attention notification modes for GeneralConfig.AttentionNotify.
+const (
+	AttentionNotifyNone           = "none"
+	AttentionNotifyBell           = "bell"
+	AttentionNotifyDisplayMessage = "display-message"
+)
+
+// attentionTailLines is how many trailing non-empty lines of output are
+// matched, so prompts that scrolled away do not keep an agent flagged.
+const attentionTailLines = 15
+
+// DetectAttention returns the state of the first rule matching the last
+// lines of output, or AttentionNone.
+func DetectAttention(rules []AttentionRule, output string) AttentionState {
+	if len(rules) == 0 {
+		return AttentionNone
+	}
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	tail := make([]string, 0, attentionTailLines)
+	for i := len(lines) - 1; i >= 0 && len(tail) < attentionTailLines; i-- {
+		if strings.TrimSpace(lines[i]) != "" {
+			tail = append(tail, lines[i])
+		}
+	}
+	for i, j := 0, len(tail)-1; i < j; i, j = i+1, j-1 {
+		tail[i], tail[j] = tail[j], tail[i]
+	}
+	text := strings.Join(tail, "\n")
+
+	for _, rule := range rules {
+		if rule.Pattern != nil && rule.Pattern.MatchString(text) {
+			return rule.State
+		}
+	}
+	return AttentionNone
+}
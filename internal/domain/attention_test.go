@@ -0,0 +1,61 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestDetectAttention(t *testing.T) {
+	rules := []AttentionRule{
+		{State: AttentionApproval, Pattern: regexp.MustCompile(`Do you want to proceed\?`)},
+		{State: AttentionError, Pattern: regexp.MustCompile(`(?m)^Error:`)},
+		{State: AttentionIdle, Pattern: regexp.MustCompile(`(?m)^> $`)},
+	}
+
+	tests := []struct {
+		name   string
+		output string
+		want   AttentionState
+	}{
+		{"no match", "Reading files...\nEditing main.go\n", AttentionNone},
+		{"approval", "Run `go test`?\nDo you want to proceed?\n  1. Yes\n  2. No\n\n\n", AttentionApproval},
+		{"idle prompt", "Done.\n> \n", AttentionIdle},
+		{"first rule wins", "Error: build failed\nDo you want to proceed?\n", AttentionApproval},
+		{"error", "Error: rate limited\n", AttentionError},
+		{"scrolled away", "Do you want to proceed?\n" + strings.Repeat("working\n", 20), AttentionNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectAttention(rules, tt.output); got != tt.want {
+				t.Errorf("DetectAttention() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := DetectAttention(nil, "Do you want to proceed?"); got != AttentionNone {
+		t.Errorf("DetectAttention() without rules = %v, want none", got)
+	}
+}
+
+func TestParseAttentionState(t *testing.T) {
+	for _, name := range []string{"approval", "idle", "error"} {
+		state, err := ParseAttentionState(name)
+		if err != nil {
+			t.Fatalf("ParseAttentionState(%q) error = %v", name, err)
+		}
+		if state.String() != name {
+			t.Errorf("round trip %q -> %q", name, state.String())
+		}
+	}
+	if _, err := ParseAttentionState("sleepy"); err == nil {
+		t.Error("expected error for unknown state")
+	}
+}
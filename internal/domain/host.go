@@ -0,0 +1,37 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package domain
+
+// HostIdentity identifies the machine (and user) that launched an agent.
+// running_agents lives in the Beads database, which teams may share through
+// a Dolt sql-server, so rows must record where their process lives.
+type HostIdentity struct {
+	Hostname  string
+	MachineID string
+	User      string
+}
+
+// Label returns "user@hostname", or just the hostname when the user is unknown.
+func (h HostIdentity) Label() string {
+	if h.User == "" {
+		return h.Hostname
+	}
+	return h.User + "@" + h.Hostname
+}
+
+// RunsOn reports whether the agent's process lives on the given host.
+// Machine IDs are compared when both sides have one; otherwise hostnames
+// are. Rows written before hosts were recorded are treated as local.
+func (a PersistedRunningAgent) RunsOn(h HostIdentity) bool {
+	if a.MachineID == "" && a.Hostname == "" {
+		return true
+	}
+	if a.MachineID != "" && h.MachineID != "" {
+		return a.MachineID == h.MachineID
+	}
+	return a.Hostname == h.Hostname
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package domain
+
+import "testing"
+
+func TestPersistedRunningAgent_RunsOn(t *testing.T) {
+	local := HostIdentity{Hostname: "laptop", MachineID: "m-1", User: "alice"}
+
+	tests := []struct {
+		name  string
+		agent PersistedRunningAgent
+		want  bool
+	}{
+		{"legacy row", PersistedRunningAgent{}, true},
+		{"same machine", PersistedRunningAgent{Hostname: "laptop", MachineID: "m-1"}, true},
+		{"same machine renamed", PersistedRunningAgent{Hostname: "old-name", MachineID: "m-1"}, true},
+		{"other machine same hostname", PersistedRunningAgent{Hostname: "laptop", MachineID: "m-2"}, false},
+		{"other host", PersistedRunningAgent{Hostname: "desktop", MachineID: "m-2"}, false},
+		{"hostname only", PersistedRunningAgent{Hostname: "laptop"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.agent.RunsOn(local); got != tt.want {
+				t.Errorf("RunsOn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostIdentity_Label(t *testing.T) {
+	if got := (HostIdentity{Hostname: "laptop", User: "alice"}).Label(); got != "alice@laptop" {
+		t.Errorf("Label() = %q", got)
+	}
+	if got := (HostIdentity{Hostname: "laptop"}).Label(); got != "laptop" {
+		t.Errorf("Label() without user = %q", got)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package domain
+
+import "fmt"
+
+// ContextProviderName identifies a source of extra prompt context.
+type ContextProviderName string
+
+// Context providers a harness can enable in its context list.
+const (
+	ContextRepoDocs     ContextProviderName = "docs"          // AGENTS.md and CLAUDE.md of the work directory
+	ContextRelated      ContextProviderName = "related"       // parent epic and sibling tickets
+	ContextDependencies ContextProviderName = "dependencies"  // tickets this one blocks or is blocked by
+	ContextGitLog       ContextProviderName = "git_log"       // recent commits on the work directory's branch
+	ContextChangedFiles ContextProviderName = "changed_files" // files changed versus the main branch
+)
+
+// defaultContextLimits holds each provider's default size limit: bytes for
+// docs, tickets per list for related and dependencies, commits for git_log
+// and files for changed_files.
+var defaultContextLimits = map[ContextProviderName]int{
+	ContextRepoDocs:     16 * 1024,
+	ContextRelated:      10,
+	ContextDependencies: 10,
+	ContextGitLog:       10,
+	ContextChangedFiles: 50,
+}
+
+// ParseContextProviderName validates a provider name from the config.
+func ParseContextProviderName(name string) (ContextProviderName, error) {
+	p := ContextProviderName(name)
+	if _, ok := defaultContextLimits[p]; !ok {
+		return "", fmt.Errorf("unknown context provider %q (want docs, related, dependencies, git_log or changed_files)", name)
+	}
+	return p, nil
+}
+
+// ContextProvider enables one provider for a harness.
+type ContextProvider struct {
+	Name  ContextProviderName
+	Limit int // 0 means the provider's default
+}
+
+// EffectiveLimit returns the configured limit, or the provider's default.
+func (p ContextProvider) EffectiveLimit() int {
+	if p.Limit > 0 {
+		return p.Limit
+	}
+	return defaultContextLimits[p.Name]
+}
+
+// PromptContext is the extra context gathered by a harness's enabled
+// providers. It is embedded in TemplateContext, so templates reference its
+// fields directly, e.g. {{range .GitLog}}. Fields of disabled providers stay
+// empty.
+type PromptContext struct {
+	RepoDocs       string             // AGENTS.md / CLAUDE.md contents
+	ParentTicket   *TicketDependency  // parent epic, if any
+	SiblingTickets []TicketDependency // other children of the parent
+	BlockedBy      []TicketDependency // tickets this one depends on
+	Blocks         []TicketDependency // tickets depending on this one
+	GitLog         []string           // "<short hash> <subject>", newest first
+	ChangedFiles   []string           // paths changed versus the main branch
+
+	// Sections summarizes what each enabled provider contributed, for the
+	// launch preview.
+	Sections []ContextSection
+}
+
+// ContextSection describes the output of one provider.
+type ContextSection struct {
+	Provider  ContextProviderName
+	Summary   string // e.g. "AGENTS.md (2.1 KB)"
+	Truncated bool   // the provider's limit cut its output
+	Err       error
+}
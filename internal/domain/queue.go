@@ -0,0 +1,98 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ConcurrencyLimits cap how many agents run at once. Harnesses can add a
+// limit of their own with Harness.MaxConcurrentAgents. Zero means no limit.
+type ConcurrencyLimits struct {
+	Agents   int            // all agents
+	PerModel map[string]int // agents of a model, whatever the harness
+}
+
+// Limits returns the global concurrency limits of g, which may be nil.
+func (g *GeneralConfig) Limits() ConcurrencyLimits {
+	if g == nil {
+		return ConcurrencyLimits{}
+	}
+	return ConcurrencyLimits{Agents: g.MaxConcurrentAgents, PerModel: g.MaxConcurrentPerModel}
+}
+
+// AgentSlot is a running or starting agent as counted against the limits.
+type AgentSlot struct {
+	Harness string
+	Model   string
+}
+
+// Check returns why an agent of harness h with model cannot start while
+// the running agents run, or "" if it can.
+func (l ConcurrencyLimits) Check(h Harness, model string, running []AgentSlot) string {
+	if l.Agents > 0 && len(running) >= l.Agents {
+		return fmt.Sprintf("%d of %d agents running", len(running), l.Agents)
+	}
+	if limit := h.MaxConcurrentAgents; limit > 0 {
+		n := 0
+		for _, s := range running {
+			if s.Harness == h.Name {
+				n++
+			}
+		}
+		if n >= limit {
+			return fmt.Sprintf("%d of %d %s agents running", n, limit, h.Name)
+		}
+	}
+	if limit := l.PerModel[model]; model != "" && limit > 0 {
+		n := 0
+		for _, s := range running {
+			if s.Model == model {
+				n++
+			}
+		}
+		if n >= limit {
+			return fmt.Sprintf("%d of %d %s agents running", n, limit, model)
+		}
+	}
+	return ""
+}
+
+// QueuedLaunch is a rendered launch held back by a concurrency limit. It
+// starts once the limits allow, in queue order, and is saved so the queue
+// survives restarts. The harness's env is taken from the config when it
+// starts, like for a restart.
+type QueuedLaunch struct {
+	ID       int // unique within the queue
+	QueuedAt time.Time
+
+	ProjectDir   string
+	WorktreePath string
+	TicketID     string
+	TicketTitle  string
+	HarnessName  string
+	Model        string
+	Agent        string
+	EnvFile      string
+
+	RenderedCommand string
+	RenderedPrompt  string
+	LauncherID      string
+	WorkDir         string
+	PreLaunch       []string
+	PostExit        []string
+
+	// Failed is why the launch failed to start. A failed launch is not
+	// started again until it is retried.
+	Failed string `json:",omitempty"`
+}
+
+// Slot returns the agent slot the launch takes when it starts.
+func (q QueuedLaunch) Slot() AgentSlot {
+	return AgentSlot{Harness: q.HarnessName, Model: q.Model}
+}
@@ -0,0 +1,37 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package domain
+
+import "testing"
+
+func TestConcurrencyLimits_Check(t *testing.T) {
+	claude := Harness{Name: "claude", MaxConcurrentAgents: 1}
+	codex := Harness{Name: "codex"}
+	running := []AgentSlot{{Harness: "claude", Model: "opus"}, {Harness: "codex", Model: "gpt"}}
+
+	tests := []struct {
+		name    string
+		limits  ConcurrencyLimits
+		harness Harness
+		model   string
+		want    string
+	}{
+		{"unlimited", ConcurrencyLimits{}, codex, "gpt", ""},
+		{"global", ConcurrencyLimits{Agents: 2}, codex, "gpt", "2 of 2 agents running"},
+		{"under global", ConcurrencyLimits{Agents: 3}, codex, "gpt", ""},
+		{"harness", ConcurrencyLimits{}, claude, "sonnet", "1 of 1 claude agents running"},
+		{"model", ConcurrencyLimits{PerModel: map[string]int{"opus": 1}}, codex, "opus", "1 of 1 opus agents running"},
+		{"other model", ConcurrencyLimits{PerModel: map[string]int{"opus": 1}}, codex, "gpt", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.limits.Check(tt.harness, tt.model, running); got != tt.want {
+				t.Errorf("Check() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
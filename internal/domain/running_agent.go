@@ -24,19 +24,57 @@ func (t LauncherType) String() string {
 }
 
 // PersistedRunningAgent represents one row in the running_agents table.
+// Rows whose Status is no longer AgentRunning are kept as history; ExitCode
+// and EndedAt are nil while the agent runs or when the exit was not observed.
+// RenderedCommand and RenderedPrompt are kept so the agent can be restarted,
+// and PreLaunch, PostExit and HookDir so its hooks still run after bdb is
+// restarted.
+// Hostname, MachineID and Owner record where the process runs; see RunsOn.
 type PersistedRunningAgent struct {
-	ID            int
-	ProjectDir    string
-	WorktreePath  string
-	PID           int
-	LauncherType  LauncherType
-	LauncherID    string
-	Ticket        string
-	TicketTitle   string
-	HarnessName   string
-	HarnessBinary string
-	Model         string
-	Agent         string
-	StartedAt     time.Time
-	LastSeen      time.Time
+	ID              int
+	ProjectDir      string
+	WorktreePath    string
+	PID             int
+	LauncherType    LauncherType
+	LauncherID      string
+	WindowID        string
+	SessionName     string
+	Ticket          string
+	TicketTitle     string
+	HarnessName     string
+	HarnessBinary   string
+	Model           string
+	Agent           string
+	Status          AgentStatus
+	ExitCode        *int
+	RenderedCommand string
+	RenderedPrompt  string
+	PreLaunch       []string
+	PostExit        []string
+	HookDir         string
+	Hostname        string
+	MachineID       string
+	Owner           string
+	StartedAt       time.Time
+	EndedAt         *time.Time
+	LastSeen        time.Time
+}
+
+// EndedSession builds a history entry for an agent whose end was not
+// observed. The end time is approximated by LastSeen.
+func (a PersistedRunningAgent) EndedSession(status AgentStatus) AgentSession {
+	return AgentSession{
+		ProjectDir:   a.ProjectDir,
+		WorktreePath: a.WorktreePath,
+		LauncherID:   a.LauncherID,
+		Ticket:       a.Ticket,
+		TicketTitle:  a.TicketTitle,
+		HarnessName:  a.HarnessName,
+		Model:        a.Model,
+		Agent:        a.Agent,
+		Status:       status,
+		ExitCode:     a.ExitCode,
+		StartedAt:    a.StartedAt,
+		EndedAt:      a.LastSeen,
+	}
 }
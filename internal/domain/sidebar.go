@@ -98,12 +98,75 @@ type AgentInfo struct {
 	ID           string
 	Name         string
 	LauncherID   string
+	WindowID     string
+	SessionName  string
+	PID          int
+	ProjectDir   string
 	WorktreePath string
 	Status       AgentStatus
 	StartedAt    time.Time
+	EndedAt      time.Time
+	ExitCode     *int
 	TicketID     string
 	TicketTitle  string
 	HarnessName  string
 	ModelName    string
 	AgentName    string
+
+	// RenderedCommand and RenderedPrompt are what the agent was launched
+	// with, kept so it can be restarted with the same LaunchSpec.
+	RenderedCommand string
+	RenderedPrompt  string
+
+	// Attention is detected from pane output while the agent runs.
+	Attention AttentionState
+
+	// Verify is the result of the project's verify_command for this run.
+	Verify VerifyState
+
+	// Remote agents run on another host sharing the Beads database. They
+	// are shown read-only: their windows and processes are not reachable.
+	Remote   bool
+	Hostname string
+	Owner    string
+}
+
+// VerifyState is the result of running a project's verify_command on an
+// agent's worktree.
+type VerifyState int
+
+const (
+	VerifyNone VerifyState = iota
+	VerifyRunning
+	VerifyPassed
+	VerifyFailed
+)
+
+// String returns a lowercase name for the state.
+func (s VerifyState) String() string {
+	switch s {
+	case VerifyRunning:
+		return "running"
+	case VerifyPassed:
+		return "passed"
+	case VerifyFailed:
+		return "failed"
+	default:
+		return "none"
+	}
+}
+
+// HostLabel returns "owner@hostname" for a remote agent.
+func (a *AgentInfo) HostLabel() string {
+	return HostIdentity{Hostname: a.Hostname, User: a.Owner}.Label()
+}
+
+// TmuxTarget returns the tmux target used to address the agent's window.
+// The window ID is preferred because it survives renames and is unique
+// across sessions; the launcher ID (window name) is a legacy fallback.
+func (a *AgentInfo) TmuxTarget() string {
+	if a.WindowID != "" {
+		return a.WindowID
+	}
+	return a.LauncherID
 }
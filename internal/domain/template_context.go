@@ -105,4 +105,7 @@ type TemplateContext struct {
 	// and can be referenced in command_template using {{.Prompt}}
 	// If no prompt_template is configured, this field will be empty.
 	Prompt string
+
+	// Context gathered by the harness's context providers
+	PromptContext
 }
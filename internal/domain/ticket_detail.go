@@ -0,0 +1,39 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package domain
+
+import "time"
+
+// TicketDetail is the full record of a ticket shown in the detail pane.
+// Fields a ticket source does not provide are left empty.
+type TicketDetail struct {
+	Ticket
+	Design             string
+	AcceptanceCriteria string
+	Notes              string
+	URL                string // web page of the issue, for remote sources
+	Labels             []string
+	Dependencies       []TicketDependency // tickets this one depends on
+	Dependents         []TicketDependency // tickets that depend on this one
+	Comments           []TicketComment
+}
+
+// TicketDependency is a ticket on the other end of a dependency edge.
+// Title and Status are empty when the ticket is not in the store.
+type TicketDependency struct {
+	ID     string
+	Title  string
+	Status string
+	Type   string // blocks, parent-child, related, ...
+}
+
+// TicketComment is a comment on a ticket, oldest first.
+type TicketComment struct {
+	Author    string
+	Text      string
+	CreatedAt time.Time
+}
@@ -22,15 +22,74 @@ type Ticket struct {
 	UpdatedAt   time.Time
 }
 
+// Ticket statuses and issue types offered when creating or editing tickets,
+// as used by bd. Priorities range from 0 (critical) to 4 (backlog).
+var (
+	TicketStatuses   = []string{"open", "in_progress", "blocked", "deferred", "closed"}
+	TicketIssueTypes = []string{"task", "bug", "feature", "epic", "chore"}
+)
+
+const (
+	MinTicketPriority = 0
+	MaxTicketPriority = 4
+)
+
 // Harness defines a development environment configuration that can be
 // launched in a tmux window.
 type Harness struct {
 	Name            string
 	CommandTemplate string
 	PromptTemplate  string
+	PromptTemplates PromptTemplates // per issue type, preferred over PromptTemplate
 	SupportedModels []string
 	SupportedAgents []string
 	Env             map[string]string
+	AttentionRules  []AttentionRule // evaluated in order against pane output
+	PromptSnippets  []PromptSnippet // saved prompts offered on the confirm screen
+	Context         []ContextProvider
+	When            HarnessConditions
+	Hooks           Hooks
+
+	// MaxConcurrentAgents caps the running agents of this harness; 0 for
+	// no limit.
+	MaxConcurrentAgents int
+}
+
+// Hooks are shell commands run around an agent's launch. They are
+// templates, rendered with the launch's TemplateContext, and run in its
+// work directory.
+type Hooks struct {
+	PreLaunch []string // run in order before the launch; a failure aborts it
+	PostExit  []string // run in order once the agent has ended
+}
+
+// HarnessConditions restrict when a harness is offered. All conditions that
+// are set must hold; an empty field always matches.
+type HarnessConditions struct {
+	Projects   []string // project name globs, any of which must match
+	IssueTypes []string // lowercased issue types, any of which must match
+	Installed  bool     // the harness's binary must be on PATH
+	Env        []string // environment variables that must be set
+}
+
+// IsZero reports whether no condition is set.
+func (c HarnessConditions) IsZero() bool {
+	return len(c.Projects) == 0 && len(c.IssueTypes) == 0 && !c.Installed && len(c.Env) == 0
+}
+
+// DefaultPromptTemplateKey is the PromptTemplates key used for issue types
+// without their own entry.
+const DefaultPromptTemplateKey = "default"
+
+// PromptTemplates maps a ticket issue type (bug, feature, ...) or
+// DefaultPromptTemplateKey to a prompt template.
+type PromptTemplates map[string]string
+
+// PromptSnippet is a reusable prompt saved for a harness. Its text is
+// rendered as a template, like prompt_template.
+type PromptSnippet struct {
+	Name string
+	Text string
 }
 
 // Selection captures the user's complete choice of ticket, harness,
@@ -40,6 +99,18 @@ type Selection struct {
 	Harness Harness
 	Model   string
 	Agent   string
+
+	// ProjectPromptTemplates are the prompt templates of the ticket's
+	// project, which take precedence over the harness's.
+	ProjectPromptTemplates PromptTemplates
+
+	// ProjectEnvFile is the .env file of the ticket's project, read when
+	// the harness is launched; empty for none.
+	ProjectEnvFile string
+
+	// ProjectHooks are the hooks of the ticket's project. Its pre_launch
+	// hooks run before the harness's, its post_exit hooks after them.
+	ProjectHooks Hooks
 }
 
 // LaunchSpec is a fully resolved selection ready for execution.
@@ -47,29 +118,63 @@ type LaunchSpec struct {
 	Selection       Selection
 	RenderedCommand string
 	RenderedPrompt  string
+	PromptSource    string // which template the prompt was rendered from; empty if given verbatim
 	LauncherID      string
 	WorkDir         string
+
+	// PreLaunch and PostExit are the rendered hooks of the project and the
+	// harness, in the order they run.
+	PreLaunch []string
+	PostExit  []string
 }
 
 // LaunchResult captures the outcome of a launch attempt.
+// LauncherID is the (deduplicated) window name; WindowID is the stable
+// tmux window identifier (e.g. "@12") used for status tracking.
 type LaunchResult struct {
 	LauncherID   string
 	LauncherType LauncherType
+	WindowID     string
+	SessionName  string
 	PID          int
 	Error        error
 }
 
 // LauncherConfig controls how new tmux windows are created.
+// Session names the dedicated tmux session agent windows are created in;
+// an empty value targets the session blunderbust itself runs in.
 type LauncherConfig struct {
-	Target string
+	Target  string
+	Session string
 }
 
 // GeneralConfig holds general application settings.
 type GeneralConfig struct {
-	AutostartDolt bool
+	AutostartDolt   bool
+	AttentionNotify string // one of the AttentionNotify* modes
+	AgentState      string // one of the AgentState* backends
+
+	// MaxConcurrentAgents and MaxConcurrentPerModel cap the running agents,
+	// in all and per model; 0 for no limit.
+	MaxConcurrentAgents   int
+	MaxConcurrentPerModel map[string]int
+
+	// CommandTimeout bounds each hook, verify_command and context provider
+	// the TUI runs.
+	CommandTimeout time.Duration
 }
 
-// Defaults holds optional default selections for quickdraw/blitzdraw modes.
+// DefaultCommandTimeout is GeneralConfig.CommandTimeout when unset.
+const DefaultCommandTimeout = 10 * time.Minute
+
+// Agent state backends for GeneralConfig.AgentState.
+const (
+	AgentStateDolt  = "dolt"  // running_agents and agent_sessions tables in the Beads database
+	AgentStateLocal = "local" // bbolt file in the local state directory
+)
+
+// Defaults holds optional default selections: the harness, model and agent
+// preselected in the TUI, and used by quickdraw/blitzdraw modes.
 type Defaults struct {
 	Harness string
 	Model   string
@@ -83,6 +188,10 @@ type Config struct {
 	Defaults  *Defaults
 	General   *GeneralConfig
 	Workspace Workspace
+
+	// Files are the config file and the template files it read, in the
+	// order they were read. Unset when the config was not loaded from disk.
+	Files []string
 }
 
 // Workspace represents a collection of projects defined in configuration.
@@ -93,8 +202,52 @@ type Workspace struct {
 
 // Project represents a single codebase with its own ticket store.
 type Project struct {
-	Dir  string
-	Name string
+	Dir     string
+	Name    string
+	Tickets TicketSource
+
+	// PromptTemplates override the harnesses' prompt templates for this
+	// project's tickets.
+	PromptTemplates PromptTemplates
+
+	// EnvFile is an absolute path to a .env file whose variables are added
+	// to the env of harnesses launched for this project; empty for none.
+	EnvFile string
+
+	// Hooks run around the launch of any harness for this project's tickets.
+	Hooks Hooks
+
+	// Verify checks the work of this project's agents once they end.
+	Verify Verify
+}
+
+// Verify is a project's verify_command, run with sh in an agent's worktree
+// once the agent has ended. A zero exit status passes.
+type Verify struct {
+	Command string // empty for none
+	Manual  bool   // run only on request, not when the agent ends
+	Comment bool   // add the result as a comment on the agent's ticket
+}
+
+// Ticket sources for TicketSource.Type.
+const (
+	TicketSourceBeads  = "beads" // .beads database or issues.jsonl (default)
+	TicketSourceGitHub = "github"
+	TicketSourceGitLab = "gitlab"
+)
+
+// TicketSource selects where a project's tickets come from. The zero value
+// means the project's Beads database.
+type TicketSource struct {
+	Type     string // one of the TicketSource* constants; empty means beads
+	Repo     string // "owner/name" on GitHub, project path or numeric ID on GitLab
+	URL      string // API base URL; empty for github.com or gitlab.com
+	TokenEnv string // environment variable holding the API token
+}
+
+// IsRemote reports whether tickets come from an issue tracker API.
+func (s TicketSource) IsRemote() bool {
+	return s.Type == TicketSourceGitHub || s.Type == TicketSourceGitLab
 }
 
 // AppOptions configure the application at a global level.
@@ -107,6 +260,20 @@ type AppOptions struct {
 	DSN           string
 	Demo          bool
 	AutostartDolt bool
-	TargetProject string // Optional: project path from CLI positional arg
-	Theme         string // UI Theme preference
+	// SkipMigrations opens the Dolt store without applying schema migrations.
+	SkipMigrations bool
+	TargetProject  string // Optional: project path from CLI positional arg
+	Theme          string // UI Theme preference
+
+	AttentionNotify string // How to notify when an agent needs attention
+	AgentState      string // Where running agents and session history are stored
+
+	// Limits cap the running agents; launches beyond them are queued.
+	Limits ConcurrencyLimits
+
+	CommandTimeout time.Duration // bounds hooks, verify_command and context providers
+
+	// Defaults are preselected when a project becomes active, with the
+	// project config's defaults taking precedence.
+	Defaults Defaults
 }
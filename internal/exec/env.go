@@ -0,0 +1,186 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package exec
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// EnvVar is an environment variable of a launch, resolved from a harness
+// env value or a project env file.
+type EnvVar struct {
+	Name  string
+	Value string
+
+	// Secret is set for values that did not appear literally in the config:
+	// expanded ${VAR} references, !cmd output and env file entries. Secrets
+	// must not be shown or passed on a command line.
+	Secret bool
+}
+
+// Redacted is the placeholder shown for the value of a secret.
+const Redacted = "<redacted>"
+
+// Display returns NAME=value, with the value of a secret redacted.
+func (v EnvVar) Display() string {
+	if v.Secret {
+		return v.Name + "=" + Redacted
+	}
+	return v.Name + "=" + v.Value
+}
+
+// envRef matches ${VAR} references and the $$ escape.
+var envRef = regexp.MustCompile(`\$\$|\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ResolveEnv resolves a harness's env for a launch in dir, sorted by name.
+//
+// Values are taken literally, except:
+//
+//   - ${VAR} is replaced by VAR from envFile, else from bdb's environment;
+//     it is an error when VAR is set in neither. $$ is a literal $.
+//   - A value starting with ! is a shell command run in dir; its output,
+//     without the trailing newline, is the value. !! starts a literal !.
+//
+// envFile, when not empty, is a .env file whose variables are added first;
+// the harness env replaces variables it also sets. With dryRun, commands
+// are not run and resolve to an empty secret.
+func ResolveEnv(ctx context.Context, env map[string]string, envFile, dir string, dryRun bool) ([]EnvVar, error) {
+	fileVars := map[string]string{}
+	if envFile != "" {
+		var err error
+		if fileVars, err = ReadEnvFile(envFile); err != nil {
+			return nil, err
+		}
+	}
+
+	resolved := make(map[string]EnvVar, len(fileVars)+len(env))
+	for name, value := range fileVars {
+		resolved[name] = EnvVar{Name: name, Value: value, Secret: true}
+	}
+	for name, value := range env {
+		v, err := resolveEnvValue(ctx, value, fileVars, dir, dryRun)
+		if err != nil {
+			return nil, fmt.Errorf("env %s: %w", name, err)
+		}
+		v.Name = name
+		resolved[name] = v
+	}
+
+	vars := make([]EnvVar, 0, len(resolved))
+	for _, v := range resolved {
+		vars = append(vars, v)
+	}
+	slices.SortFunc(vars, func(a, b EnvVar) int { return strings.Compare(a.Name, b.Name) })
+	return vars, nil
+}
+
+func resolveEnvValue(ctx context.Context, value string, fileVars map[string]string, dir string, dryRun bool) (EnvVar, error) {
+	if strings.HasPrefix(value, "!!") {
+		return EnvVar{Value: value[1:]}, nil
+	}
+	if command, ok := strings.CutPrefix(value, "!"); ok {
+		if dryRun {
+			return EnvVar{Secret: true}, nil
+		}
+		out, err := runEnvCommand(ctx, command, dir)
+		return EnvVar{Value: out, Secret: true}, err
+	}
+
+	var missing []string
+	secret := false
+	expanded := envRef.ReplaceAllStringFunc(value, func(ref string) string {
+		if ref == "$$" {
+			return "$"
+		}
+		secret = true
+		name := ref[2 : len(ref)-1]
+		if v, ok := fileVars[name]; ok {
+			return v
+		}
+		if v, ok := os.LookupEnv(name); ok {
+			return v
+		}
+		missing = append(missing, name)
+		return ""
+	})
+	if len(missing) > 0 {
+		return EnvVar{}, fmt.Errorf("${%s} is not set", missing[0])
+	}
+	return EnvVar{Value: expanded, Secret: secret}, nil
+}
+
+// runEnvCommand runs command with sh in dir and returns its output without
+// the trailing newline.
+func runEnvCommand(ctx context.Context, command, dir string) (string, error) {
+	cmd := osexec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("command %q failed: %w: %s", command, err, msg)
+		}
+		return "", fmt.Errorf("command %q failed: %w", command, err)
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(string(out), "\n"), "\r"), nil
+}
+
+// ReadEnvFile reads a .env file: NAME=value lines, optionally prefixed with
+// export. Blank lines and lines starting with # are skipped, and values may
+// be wrapped in single or double quotes.
+func ReadEnvFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || !envName.MatchString(name) {
+			return nil, fmt.Errorf("%s:%d: expected NAME=value", path, lineNo)
+		}
+		vars[name] = unquoteEnvValue(strings.TrimSpace(value))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+	return vars, nil
+}
+
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func unquoteEnvValue(value string) string {
+	if len(value) >= 2 {
+		switch q := value[0]; {
+		case q == '\'' && value[len(value)-1] == q:
+			return value[1 : len(value)-1]
+		case q == '"' && value[len(value)-1] == q:
+			return strings.NewReplacer(`\n`, "\n", `\"`, `"`, `\\`, `\`).Replace(value[1 : len(value)-1])
+		}
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package exec
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveEnv(t *testing.T) {
+	t.Setenv("BDB_TEST_HOME", "/home/me")
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, ".env")
+	content := "# keys\nexport API_KEY='abc 123'\nREGION=eu # default\nLOG=warn\n\nQUOTED=\"a\\nb\"\n"
+	if err := os.WriteFile(envFile, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	env := map[string]string{
+		"LOG":     "info",
+		"CACHE":   "${BDB_TEST_HOME}/.cache",
+		"AUTH":    "Bearer ${API_KEY}",
+		"PRICE":   "$$5 and ${BDB_TEST_HOME}",
+		"WHERE":   "!pwd",
+		"BANG":    "!!important",
+		"LITERAL": "$HOME",
+	}
+	got, err := ResolveEnv(context.Background(), env, envFile, dir, false)
+	if err != nil {
+		t.Fatalf("ResolveEnv() error = %v", err)
+	}
+	want := []EnvVar{
+		{Name: "API_KEY", Value: "abc 123", Secret: true},
+		{Name: "AUTH", Value: "Bearer abc 123", Secret: true},
+		{Name: "BANG", Value: "!important"},
+		{Name: "CACHE", Value: "/home/me/.cache", Secret: true},
+		{Name: "LITERAL", Value: "$HOME"},
+		{Name: "LOG", Value: "info"},
+		{Name: "PRICE", Value: "$5 and /home/me", Secret: true},
+		{Name: "QUOTED", Value: "a\nb", Secret: true},
+		{Name: "REGION", Value: "eu", Secret: true},
+		{Name: "WHERE", Value: dir, Secret: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveEnv() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestResolveEnv_DryRun(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "ran")
+	got, err := ResolveEnv(context.Background(), map[string]string{"TOKEN": "!touch " + marker}, "", "", true)
+	if err != nil {
+		t.Fatalf("ResolveEnv() error = %v", err)
+	}
+	if len(got) != 1 || !got[0].Secret || got[0].Display() != "TOKEN="+Redacted {
+		t.Errorf("Expected a redacted secret, got %+v", got)
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Errorf("Expected the command not to run in a dry run")
+	}
+}
+
+func TestResolveEnv_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		envFile string
+		wantErr string
+	}{
+		{"unset variable", map[string]string{"A": "${BDB_TEST_UNSET_VAR}"}, "", "env A: ${BDB_TEST_UNSET_VAR} is not set"},
+		{"failing command", map[string]string{"A": "!echo nope >&2; exit 3"}, "", "exit status 3: nope"},
+		{"missing env file", nil, "/nonexistent/.env", "failed to read env file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ResolveEnv(context.Background(), tt.env, tt.envFile, "", false)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ResolveEnv() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadEnvFile_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("OK=1\nnot a variable\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	_, err := ReadEnvFile(path)
+	if err == nil || !strings.Contains(err.Error(), ".env:2: expected NAME=value") {
+		t.Errorf("Expected the bad line reported, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// maxHookOutput bounds the command output kept by RunHook and RunCapture.
+const maxHookOutput = 4096
+
+// ErrTimeout is returned by RunHook and RunCapture when the context's
+// deadline passed before the command finished.
+var ErrTimeout = errors.New("timed out")
+
+// waitDelay is how long RunCapture waits for the output of a killed
+// command to be closed, should a process outside its group hold it open.
+const waitDelay = 5 * time.Second
+
+// RunHook runs a rendered hook command with sh in dir, with env added to
+// bdb's environment. When the command fails, the error carries the end of
+// its combined output.
+func RunHook(ctx context.Context, command, dir string, env ...string) error {
+	output, err := RunCapture(ctx, command, dir, env...)
+	if err == nil {
+		return nil
+	}
+	if output == "" {
+		return fmt.Errorf("hook %q failed: %w", command, err)
+	}
+	return fmt.Errorf("hook %q failed: %w\n%s", command, err, output)
+}
+
+// RunCapture runs command with sh in dir, with env added to bdb's
+// environment, and returns the end of its combined output, trimmed,
+// whether or not it fails. The command and the processes it started are
+// killed when ctx is done.
+func RunCapture(ctx context.Context, command, dir string, env ...string) (string, error) {
+	cmd := osexec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error { return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL) }
+	cmd.WaitDelay = waitDelay
+	out, err := cmd.CombinedOutput()
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = ErrTimeout
+	}
+
+	output := strings.TrimSpace(string(out))
+	if len(output) > maxHookOutput {
+		output = "..." + output[len(output)-maxHookOutput:]
+	}
+	return output, err
+}
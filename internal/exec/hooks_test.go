@@ -0,0 +1,66 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package exec
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunHook(t *testing.T) {
+	dir := t.TempDir()
+	if err := RunHook(context.Background(), `echo "$BDB_TEST_HOOK" > out`, dir, "BDB_TEST_HOOK=ok"); err != nil {
+		t.Fatalf("RunHook() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "out"))
+	if err != nil || string(data) != "ok\n" {
+		t.Errorf("Expected the hook run in dir with env, got %q, %v", data, err)
+	}
+
+	err = RunHook(context.Background(), "echo installing; echo broken >&2; exit 2", dir)
+	if err == nil {
+		t.Fatal("Expected the failing hook reported")
+	}
+	for _, want := range []string{"exit status 2", "installing", "broken"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in the error, got %v", want, err)
+		}
+	}
+}
+
+func TestRunCapture(t *testing.T) {
+	out, err := RunCapture(context.Background(), "echo ok 1 passed", t.TempDir())
+	if err != nil || out != "ok 1 passed" {
+		t.Errorf("RunCapture() = %q, %v", out, err)
+	}
+
+	out, err = RunCapture(context.Background(), "head -c 5000 /dev/zero | tr '\\0' x; echo; echo FAIL >&2; exit 1", t.TempDir())
+	if err == nil || !strings.HasSuffix(out, "FAIL") || !strings.HasPrefix(out, "...") || len(out) != maxHookOutput+3 {
+		t.Errorf("Expected the truncated output of a failure, got %d bytes, %v", len(out), err)
+	}
+}
+
+func TestRunCapture_Timeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	out, err := RunCapture(ctx, "echo started; sleep 30", t.TempDir())
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("Expected ErrTimeout, got %v", err)
+	}
+	if out != "started" {
+		t.Errorf("Expected the output so far, got %q", out)
+	}
+	if elapsed := time.Since(start); elapsed >= waitDelay {
+		t.Errorf("Expected the command killed, took %v", elapsed)
+	}
+}
@@ -9,6 +9,8 @@ package tmux
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 )
 
 // OutputCapture manages tmux pane output capture using capture-pane.
@@ -54,6 +56,27 @@ func (c *OutputCapture) ReadOutput() ([]byte, error) {
 	return []byte(out), nil
 }
 
+// SaveHistory writes the pane's full scrollback to path, creating parent
+// directories as needed. Used to keep an agent's output after its window closes.
+func (c *OutputCapture) SaveHistory(ctx context.Context, path string) error {
+	if c.windowID == "" {
+		return fmt.Errorf("window string is empty")
+	}
+
+	out, err := c.runner.Run(ctx, "tmux", "capture-pane", "-p", "-S", "-", "-t", c.windowID)
+	if err != nil {
+		return fmt.Errorf("failed to capture pane history: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	if err := os.WriteFile(path, out, 0o644); err != nil {
+		return fmt.Errorf("failed to write output log: %w", err)
+	}
+	return nil
+}
+
 // FilePath returns an empty string since we no longer use a temporary file.
 func (c *OutputCapture) FilePath() string {
 	return ""
@@ -8,6 +8,8 @@ package tmux
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -65,3 +67,35 @@ func TestOutputCapture_ReadOutput(t *testing.T) {
 		t.Error("capture-pane command not found in executed commands")
 	}
 }
+
+func TestOutputCapture_SaveHistory(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.SetOutput("tmux", []string{"capture-pane", "-p", "-S", "-", "-t", "@123"}, []byte("line 1\nline 2\n"))
+	capture := NewOutputCapture(fake, "@123")
+
+	path := filepath.Join(t.TempDir(), "logs", "bb-1.log")
+	if err := capture.SaveHistory(context.Background(), path); err != nil {
+		t.Fatalf("SaveHistory() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read log: %v", err)
+	}
+	if string(content) != "line 1\nline 2\n" {
+		t.Errorf("log content = %q", string(content))
+	}
+}
+
+func TestOutputCapture_SaveHistory_CaptureError(t *testing.T) {
+	fake := NewFakeRunner()
+	capture := NewOutputCapture(fake, "@123")
+
+	path := filepath.Join(t.TempDir(), "bb-1.log")
+	if err := capture.SaveHistory(context.Background(), path); err == nil {
+		t.Fatal("expected error when capture-pane fails")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("no log file should be written on capture failure")
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package tmux
+
+import (
+	"context"
+	"fmt"
+)
+
+// WindowController performs interactive actions on a launched agent's window.
+// target is a window ID ("@12") or, for legacy agents, a window name.
+type WindowController struct {
+	runner CommandRunner
+}
+
+// NewWindowController creates a new WindowController.
+func NewWindowController(runner CommandRunner) *WindowController {
+	return &WindowController{runner: runner}
+}
+
+// Select makes the window current and switches the client to it, which also
+// moves the client across sessions when the agent runs in a dedicated one.
+func (c *WindowController) Select(ctx context.Context, target string) error {
+	if _, err := c.runner.Run(ctx, "tmux", "select-window", "-t", target); err != nil {
+		return fmt.Errorf("failed to select window %s: %w", target, err)
+	}
+	if _, err := c.runner.Run(ctx, "tmux", "switch-client", "-t", target); err != nil {
+		return fmt.Errorf("failed to switch to window %s: %w", target, err)
+	}
+	return nil
+}
+
+// SendText types text into the window's pane and presses Enter. The text is
+// sent literally so tmux does not interpret words like "Enter" as key names.
+func (c *WindowController) SendText(ctx context.Context, target, text string) error {
+	if _, err := c.runner.Run(ctx, "tmux", "send-keys", "-t", target, "-l", text); err != nil {
+		return fmt.Errorf("failed to send text to window %s: %w", target, err)
+	}
+	if _, err := c.runner.Run(ctx, "tmux", "send-keys", "-t", target, "Enter"); err != nil {
+		return fmt.Errorf("failed to send Enter to window %s: %w", target, err)
+	}
+	return nil
+}
+
+// Interrupt sends Ctrl-C to the window's pane.
+func (c *WindowController) Interrupt(ctx context.Context, target string) error {
+	if _, err := c.runner.Run(ctx, "tmux", "send-keys", "-t", target, "C-c"); err != nil {
+		return fmt.Errorf("failed to interrupt window %s: %w", target, err)
+	}
+	return nil
+}
+
+// Kill destroys the window and the process running in it.
+func (c *WindowController) Kill(ctx context.Context, target string) error {
+	if _, err := c.runner.Run(ctx, "tmux", "kill-window", "-t", target); err != nil {
+		return fmt.Errorf("failed to kill window %s: %w", target, err)
+	}
+	return nil
+}
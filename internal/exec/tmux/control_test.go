@@ -0,0 +1,75 @@
+// Copyright (C) 2026 megatherium
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+
+package tmux
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWindowController_Select(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.SetOutput("tmux", []string{"select-window", "-t", "@7"}, nil)
+	fake.SetOutput("tmux", []string{"switch-client", "-t", "@7"}, nil)
+
+	if err := NewWindowController(fake).Select(context.Background(), "@7"); err != nil {
+		t.Fatalf("Select() error = %v", err)
+	}
+
+	want := []string{"tmux select-window -t @7", "tmux switch-client -t @7"}
+	if !reflect.DeepEqual(fake.Commands, want) {
+		t.Errorf("commands = %v, want %v", fake.Commands, want)
+	}
+}
+
+func TestWindowController_Select_WindowGone(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.SetError("tmux", []string{"select-window", "-t", "@7"}, errors.New("can't find window: @7"))
+
+	if err := NewWindowController(fake).Select(context.Background(), "@7"); err == nil {
+		t.Fatal("expected error when the window is gone")
+	}
+	if len(fake.Commands) != 1 {
+		t.Errorf("expected no switch-client after a failed select, got %v", fake.Commands)
+	}
+}
+
+func TestWindowController_SendText(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.SetOutput("tmux", []string{"send-keys", "-t", "@7", "-l", "also update the docs"}, nil)
+	fake.SetOutput("tmux", []string{"send-keys", "-t", "@7", "Enter"}, nil)
+
+	if err := NewWindowController(fake).SendText(context.Background(), "@7", "also update the docs"); err != nil {
+		t.Fatalf("SendText() error = %v", err)
+	}
+
+	want := []string{"tmux send-keys -t @7 -l also update the docs", "tmux send-keys -t @7 Enter"}
+	if !reflect.DeepEqual(fake.Commands, want) {
+		t.Errorf("commands = %v, want %v", fake.Commands, want)
+	}
+}
+
+func TestWindowController_InterruptAndKill(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.SetOutput("tmux", []string{"send-keys", "-t", "@7", "C-c"}, nil)
+	fake.SetOutput("tmux", []string{"kill-window", "-t", "@7"}, nil)
+	c := NewWindowController(fake)
+
+	if err := c.Interrupt(context.Background(), "@7"); err != nil {
+		t.Fatalf("Interrupt() error = %v", err)
+	}
+	if err := c.Kill(context.Background(), "@7"); err != nil {
+		t.Fatalf("Kill() error = %v", err)
+	}
+
+	want := []string{"tmux send-keys -t @7 C-c", "tmux kill-window -t @7"}
+	if !reflect.DeepEqual(fake.Commands, want) {
+		t.Errorf("commands = %v, want %v", fake.Commands, want)
+	}
+}
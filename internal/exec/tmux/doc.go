@@ -8,5 +8,5 @@
 //
 // This package includes a tmux-based Launcher implementation that spawns
 // tmux windows with rendered harness commands, along with utilities for
-// monitoring window status.
+// monitoring window status and interacting with launched windows.
 package tmux
@@ -23,13 +23,16 @@ type Launcher struct {
 	dryRun        bool
 	skipTmuxCheck bool
 	target        string
+	session       string
 }
 
 // NewTmuxLauncher creates a new tmux-based launcher.
 // If dryRun is true, commands are printed but not executed.
 // If skipTmuxCheck is true, the TMUX environment guard is disabled.
 // target specifies whether to focus on the new window: "foreground" or "background".
-func NewTmuxLauncher(runner CommandRunner, dryRun, skipTmuxCheck bool, target string) *Launcher {
+// session names the dedicated tmux session agent windows are created in; it is
+// created on demand. An empty session uses the session bdb is running in.
+func NewTmuxLauncher(runner CommandRunner, dryRun, skipTmuxCheck bool, target, session string) *Launcher {
 	validTargets := map[string]bool{
 		"foreground": true,
 		"background": true,
@@ -44,6 +47,7 @@ func NewTmuxLauncher(runner CommandRunner, dryRun, skipTmuxCheck bool, target st
 		dryRun:        dryRun,
 		skipTmuxCheck: skipTmuxCheck,
 		target:        target,
+		session:       session,
 	}
 }
 
@@ -56,14 +60,29 @@ func (l *Launcher) Launch(
 		return nil, err
 	}
 
-	command := l.buildCommand(spec)
+	env, err := exec.ResolveEnv(ctx, spec.Selection.Harness.Env, spec.Selection.ProjectEnvFile, spec.WorkDir, l.dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve harness env: %w", err)
+	}
 
 	if l.dryRun {
-		return l.dryRunLaunch(spec, command)
+		return l.dryRunLaunch(spec, env, l.buildCommand(spec, env, "<secrets file>", false))
+	}
+
+	secretsFile, err := writeSecretsFile(env)
+	if err != nil {
+		return nil, err
 	}
 
+	newSession := l.session != "" && !l.sessionExists(ctx)
+	spec.LauncherID = l.uniqueWindowName(ctx, spec.LauncherID)
+	command := l.buildCommand(spec, env, secretsFile, newSession)
+
 	output, err := l.runner.Run(ctx, command[0], command[1:]...)
 	if err != nil {
+		if secretsFile != "" {
+			_ = os.Remove(secretsFile)
+		}
 		return &domain.LaunchResult{
 			LauncherID: spec.LauncherID,
 			Error:      fmt.Errorf("failed to launch tmux window: %w", err),
@@ -71,16 +90,67 @@ func (l *Launcher) Launch(
 	}
 
 	windowID := l.parseLauncherID(string(output))
-	_, pid, _ := l.fetchPaneMetadata(ctx, windowID, spec.LauncherID)
+	if windowID != "" {
+		// Keep the pane around after the harness exits so the status checker
+		// can read #{pane_dead_status}. Best-effort: older tmux still works,
+		// it just reports the window as gone.
+		_, _ = l.runner.Run(ctx, "tmux", "set-option", "-w", "-t", windowID, "remain-on-exit", "on")
+	}
+	_, pid, session := l.fetchPaneMetadata(ctx, windowID, spec.LauncherID)
+	if session == "" {
+		session = l.session
+	}
+
+	// Windows created in another session do not steal focus from the client,
+	// so switch explicitly when the user asked for the foreground.
+	if l.session != "" && l.target == "foreground" && windowID != "" {
+		_, _ = l.runner.Run(ctx, "tmux", "switch-client", "-t", windowID)
+	}
 
 	return &domain.LaunchResult{
-		LauncherID:   spec.LauncherID, // Use window name as stable identifier
+		LauncherID:   spec.LauncherID,
 		LauncherType: domain.LauncherTypeTmux,
+		WindowID:     windowID,
+		SessionName:  session,
 		PID:          pid,
 		Error:        nil,
 	}, nil
 }
 
+// sessionExists reports whether the dedicated launcher session is present.
+// The "=" prefix forces an exact match instead of tmux's prefix matching.
+func (l *Launcher) sessionExists(ctx context.Context) bool {
+	_, err := l.runner.Run(ctx, "tmux", "has-session", "-t", "="+l.session)
+	return err == nil
+}
+
+// uniqueWindowName returns name, or name suffixed with -2, -3, ... if a window
+// with that name already exists in any session. Best-effort only: if the
+// window list cannot be read, the name is returned unchanged.
+func (l *Launcher) uniqueWindowName(ctx context.Context, name string) string {
+	if name == "" {
+		return name
+	}
+
+	out, err := l.runner.Run(ctx, "tmux", "list-windows", "-a", "-F", "#{window_name}")
+	if err != nil {
+		return name
+	}
+
+	taken := make(map[string]bool)
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			taken[line] = true
+		}
+	}
+
+	candidate := name
+	for i := 2; taken[candidate]; i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	return candidate
+}
+
 // validateTmuxContext checks if bdb is running inside a tmux session.
 func (l *Launcher) validateTmuxContext() error {
 	if l.skipTmuxCheck {
@@ -95,19 +165,37 @@ func (l *Launcher) validateTmuxContext() error {
 }
 
 // buildCommand constructs the full tmux command with environment variables.
-func (l *Launcher) buildCommand(spec domain.LaunchSpec) []string {
-	args := make([]string, 0, 15)
-
-	args = append(args, "tmux", "new-window")
+// When newSession is true the dedicated session does not exist yet and the
+// window is created together with it via new-session.
+//
+// Literal env values are passed with -e. Secrets never appear in the tmux
+// arguments: the command sources them from secretsFile, which it deletes
+// before running the harness.
+func (l *Launcher) buildCommand(spec domain.LaunchSpec, env []exec.EnvVar, secretsFile string, newSession bool) []string {
+	args := make([]string, 0, 18)
+
+	switch {
+	case newSession:
+		args = append(args, "tmux", "new-session", "-d", "-s", l.session)
+	case l.session != "":
+		args = append(args, "tmux", "new-window", "-t", l.session+":")
+	default:
+		args = append(args, "tmux", "new-window")
+	}
 
-	if l.target == "background" {
+	if l.target == "background" && !newSession {
 		args = append(args, "-d")
 	}
 
 	args = append(args, "-P", "-F", "#{window_id}", "-e", "LINES=", "-e", "COLUMNS=")
 
-	for key, val := range spec.Selection.Harness.Env {
-		args = append(args, "-e", fmt.Sprintf("%s=%s", key, val))
+	hasSecrets := false
+	for _, v := range env {
+		if v.Secret {
+			hasSecrets = true
+			continue
+		}
+		args = append(args, "-e", v.Name+"="+v.Value)
 	}
 
 	if spec.WorkDir != "" {
@@ -120,16 +208,65 @@ func (l *Launcher) buildCommand(spec domain.LaunchSpec) []string {
 	if command != "" {
 		command = "exec " + command
 	}
+	if hasSecrets && secretsFile != "" {
+		if command == "" {
+			command = `exec "${SHELL:-sh}"`
+		}
+		quoted := shellQuote(secretsFile)
+		command = fmt.Sprintf(". %s && rm -f %s && %s", quoted, quoted, command)
+	}
 	args = append(args, "-n", spec.LauncherID, command)
 	return args
 }
 
-// dryRunLaunch prints the command and returns a fake result.
+// writeSecretsFile writes the secrets of env as shell exports to a file
+// only the user can read, and returns its path. It returns an empty path
+// when env has no secrets.
+func writeSecretsFile(env []exec.EnvVar) (string, error) {
+	var b strings.Builder
+	for _, v := range env {
+		if v.Secret {
+			fmt.Fprintf(&b, "export %s=%s\n", v.Name, shellQuote(v.Value))
+		}
+	}
+	if b.Len() == 0 {
+		return "", nil
+	}
+
+	f, err := os.CreateTemp("", "bdb-env-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create secrets file: %w", err)
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write secrets file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("failed to write secrets file: %w", err)
+	}
+	return f.Name(), nil
+}
+
+// shellQuote quotes s for POSIX sh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// dryRunLaunch prints the command, and the env it sets with secrets
+// redacted, and returns a fake result.
 func (l *Launcher) dryRunLaunch(
 	spec domain.LaunchSpec,
+	env []exec.EnvVar,
 	command []string,
 ) (*domain.LaunchResult, error) {
 	fmt.Printf("[DRY RUN] Would execute: %s\n", strings.Join(command, " "))
+	for _, v := range env {
+		if v.Secret {
+			fmt.Printf("[DRY RUN] With secret: %s\n", v.Display())
+		}
+	}
 
 	return &domain.LaunchResult{
 		LauncherID:   spec.LauncherID,
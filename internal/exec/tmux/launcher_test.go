@@ -10,16 +10,18 @@ import (
 	"context"
 	"errors"
 	"os"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/exec"
 )
 
 func TestNewTmuxLauncher(t *testing.T) {
 	fake := NewFakeRunner()
-	launcher := NewTmuxLauncher(fake, false, false, "foreground")
+	launcher := NewTmuxLauncher(fake, false, false, "foreground", "")
 
 	if launcher == nil {
 		t.Fatal("NewTmuxLauncher returned nil")
@@ -44,7 +46,7 @@ func TestNewTmuxLauncher(t *testing.T) {
 
 func TestNewTmuxLauncher_WithOptions(t *testing.T) {
 	fake := NewFakeRunner()
-	launcher := NewTmuxLauncher(fake, true, true, "background")
+	launcher := NewTmuxLauncher(fake, true, true, "background", "")
 
 	if !launcher.dryRun {
 		t.Error("dryRun should be true")
@@ -61,7 +63,7 @@ func TestNewTmuxLauncher_WithOptions(t *testing.T) {
 
 func TestNewTmuxLauncher_InvalidTargetDefaultsToForeground(t *testing.T) {
 	fake := NewFakeRunner()
-	launcher := NewTmuxLauncher(fake, false, false, "invalid")
+	launcher := NewTmuxLauncher(fake, false, false, "invalid", "")
 
 	if launcher.target != "foreground" {
 		t.Errorf("Expected target to default to 'foreground', got %q", launcher.target)
@@ -70,7 +72,7 @@ func TestNewTmuxLauncher_InvalidTargetDefaultsToForeground(t *testing.T) {
 
 func TestLauncher_validateTmuxContext_WithoutTmux(t *testing.T) {
 	fake := NewFakeRunner()
-	launcher := NewTmuxLauncher(fake, false, false, "foreground")
+	launcher := NewTmuxLauncher(fake, false, false, "foreground", "")
 
 	oldTmux := os.Getenv("TMUX")
 	defer func() { os.Setenv("TMUX", oldTmux) }()
@@ -90,7 +92,7 @@ func TestLauncher_validateTmuxContext_WithoutTmux(t *testing.T) {
 
 func TestLauncher_validateTmuxContext_WithTmux(t *testing.T) {
 	fake := NewFakeRunner()
-	launcher := NewTmuxLauncher(fake, false, false, "foreground")
+	launcher := NewTmuxLauncher(fake, false, false, "foreground", "")
 
 	oldTmux := os.Getenv("TMUX")
 	defer func() { os.Setenv("TMUX", oldTmux) }()
@@ -105,7 +107,7 @@ func TestLauncher_validateTmuxContext_WithTmux(t *testing.T) {
 
 func TestLauncher_validateTmuxContext_SkipCheck(t *testing.T) {
 	fake := NewFakeRunner()
-	launcher := NewTmuxLauncher(fake, false, true, "foreground")
+	launcher := NewTmuxLauncher(fake, false, true, "foreground", "")
 
 	oldTmux := os.Getenv("TMUX")
 	defer func() { os.Setenv("TMUX", oldTmux) }()
@@ -122,7 +124,7 @@ func TestLauncher_Launch_DryRun(t *testing.T) {
 	fake := NewFakeRunner()
 	// Justification: When testing Launch in a dry run outside of tmux, we must skip the tmux context check
 	// because the CI pipeline or local test environment might not be running within a tmux session.
-	launcher := NewTmuxLauncher(fake, true, true, "foreground")
+	launcher := NewTmuxLauncher(fake, true, true, "foreground", "")
 
 	spec := domain.LaunchSpec{
 		Selection: domain.Selection{
@@ -165,7 +167,7 @@ func TestLauncher_Launch_Success(t *testing.T) {
 	fake := NewFakeRunner()
 	fake.SetOutput("tmux", []string{"new-window", "-P", "-F", "#{window_id}", "-e", "LINES=", "-e", "COLUMNS=", "-n", "bb-3zg", "exec opencode --model claude-sonnet"}, []byte("@1\n"))
 	fake.SetOutput("tmux", []string{"list-panes", "-t", "@1", "-F", "#{pane_id} #{pane_pid} #{session_name}"}, []byte("%1 4321 session-a\n"))
-	launcher := NewTmuxLauncher(fake, false, true, "foreground")
+	launcher := NewTmuxLauncher(fake, false, true, "foreground", "")
 
 	spec := domain.LaunchSpec{
 		Selection: domain.Selection{
@@ -207,13 +209,110 @@ func TestLauncher_Launch_Success(t *testing.T) {
 		t.Errorf("Expected no error, got %v", result.Error)
 	}
 
-	if len(fake.Commands) != 2 {
-		t.Errorf("Expected 2 commands to be executed, got %d", len(fake.Commands))
+	if result.WindowID != "@1" {
+		t.Errorf("Expected window ID @1, got %q", result.WindowID)
+	}
+
+	if result.SessionName != "session-a" {
+		t.Errorf("Expected session session-a, got %q", result.SessionName)
+	}
+
+	if len(fake.Commands) != 4 {
+		t.Errorf("Expected 4 commands to be executed, got %d", len(fake.Commands))
 	}
 
 	expectedCmd := "tmux new-window -P -F #{window_id} -e LINES= -e COLUMNS= -n bb-3zg exec opencode --model claude-sonnet"
-	if fake.Commands[0] != expectedCmd {
-		t.Errorf("Expected command %q, got %q", expectedCmd, fake.Commands[0])
+	if fake.Commands[1] != expectedCmd {
+		t.Errorf("Expected command %q, got %q", expectedCmd, fake.Commands[1])
+	}
+
+	expectedRemain := "tmux set-option -w -t @1 remain-on-exit on"
+	if fake.Commands[2] != expectedRemain {
+		t.Errorf("Expected command %q, got %q", expectedRemain, fake.Commands[2])
+	}
+}
+
+func TestLauncher_Launch_DeduplicatesWindowName(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.SetOutput("tmux", []string{"list-windows", "-a", "-F", "#{window_name}"}, []byte("bdb\nbb-3zg\nbb-3zg-2\n"))
+	fake.SetOutput("tmux", []string{"new-window", "-P", "-F", "#{window_id}", "-e", "LINES=", "-e", "COLUMNS=", "-n", "bb-3zg-3", "exec opencode"}, []byte("@7\n"))
+	fake.SetOutput("tmux", []string{"list-panes", "-t", "@7", "-F", "#{pane_id} #{pane_pid} #{session_name}"}, []byte("%7 99 work\n"))
+	launcher := NewTmuxLauncher(fake, false, true, "foreground", "")
+
+	spec := domain.LaunchSpec{
+		RenderedCommand: "opencode",
+		LauncherID:      "bb-3zg",
+	}
+
+	result, err := launcher.Launch(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.LauncherID != "bb-3zg-3" {
+		t.Errorf("Expected launcher ID bb-3zg-3, got %q", result.LauncherID)
+	}
+
+	if result.WindowID != "@7" {
+		t.Errorf("Expected window ID @7, got %q", result.WindowID)
+	}
+}
+
+func TestLauncher_Launch_CreatesDedicatedSession(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.SetError("tmux", []string{"has-session", "-t", "=agents"}, errors.New("can't find session"))
+	fake.SetOutput("tmux", []string{"list-windows", "-a", "-F", "#{window_name}"}, []byte("bdb\n"))
+	fake.SetOutput("tmux", []string{"new-session", "-d", "-s", "agents", "-P", "-F", "#{window_id}", "-e", "LINES=", "-e", "COLUMNS=", "-n", "bb-3zg", "exec opencode"}, []byte("@4\n"))
+	fake.SetOutput("tmux", []string{"list-panes", "-t", "@4", "-F", "#{pane_id} #{pane_pid} #{session_name}"}, []byte("%4 1234 agents\n"))
+	fake.SetOutput("tmux", []string{"switch-client", "-t", "@4"}, nil)
+	launcher := NewTmuxLauncher(fake, false, true, "foreground", "agents")
+
+	spec := domain.LaunchSpec{
+		RenderedCommand: "opencode",
+		LauncherID:      "bb-3zg",
+	}
+
+	result, err := launcher.Launch(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.SessionName != "agents" {
+		t.Errorf("Expected session agents, got %q", result.SessionName)
+	}
+
+	last := fake.Commands[len(fake.Commands)-1]
+	if last != "tmux switch-client -t @4" {
+		t.Errorf("Expected foreground launch to switch client, got %q", last)
+	}
+}
+
+func TestLauncher_Launch_ExistingSessionBackground(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.SetOutput("tmux", []string{"has-session", "-t", "=agents"}, nil)
+	fake.SetOutput("tmux", []string{"list-windows", "-a", "-F", "#{window_name}"}, []byte(""))
+	fake.SetOutput("tmux", []string{"new-window", "-t", "agents:", "-d", "-P", "-F", "#{window_id}", "-e", "LINES=", "-e", "COLUMNS=", "-n", "bb-3zg", "exec opencode"}, []byte("@5\n"))
+	fake.SetOutput("tmux", []string{"list-panes", "-t", "@5", "-F", "#{pane_id} #{pane_pid} #{session_name}"}, []byte("%5 1234 agents\n"))
+	launcher := NewTmuxLauncher(fake, false, true, "background", "agents")
+
+	spec := domain.LaunchSpec{
+		RenderedCommand: "opencode",
+		LauncherID:      "bb-3zg",
+	}
+
+	result, err := launcher.Launch(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.WindowID != "@5" {
+		t.Errorf("Expected window ID @5, got %q", result.WindowID)
+	}
+
+	for _, cmd := range fake.Commands {
+		if strings.Contains(cmd, "switch-client") {
+			t.Errorf("Background launch should not switch client, got %q", cmd)
+		}
 	}
 }
 
@@ -222,7 +321,7 @@ func TestLauncher_Launch_CommandError(t *testing.T) {
 	fake.SetError("tmux", []string{"new-window", "-P", "-F", "#{window_id}", "-e", "LINES=", "-e", "COLUMNS=", "-n", "bb-3zg", "exec opencode"},
 		errors.New("tmux command failed"))
 
-	launcher := NewTmuxLauncher(fake, false, true, "foreground")
+	launcher := NewTmuxLauncher(fake, false, true, "foreground", "")
 
 	spec := domain.LaunchSpec{
 		Selection: domain.Selection{
@@ -257,7 +356,7 @@ func TestLauncher_Launch_CommandError(t *testing.T) {
 
 func TestLauncher_Launch_NotInTmux(t *testing.T) {
 	fake := NewFakeRunner()
-	launcher := NewTmuxLauncher(fake, false, false, "foreground")
+	launcher := NewTmuxLauncher(fake, false, false, "foreground", "")
 
 	oldTmux := os.Getenv("TMUX")
 	defer func() { os.Setenv("TMUX", oldTmux) }()
@@ -291,7 +390,7 @@ func TestLauncher_Launch_NotInTmux(t *testing.T) {
 
 func TestLauncher_buildCommand(t *testing.T) {
 	fake := NewFakeRunner()
-	launcher := NewTmuxLauncher(fake, false, true, "foreground")
+	launcher := NewTmuxLauncher(fake, false, true, "foreground", "")
 
 	now := time.Now()
 	spec := domain.LaunchSpec{
@@ -319,7 +418,7 @@ func TestLauncher_buildCommand(t *testing.T) {
 		LauncherID:      "bb-3zg",
 	}
 
-	cmd := launcher.buildCommand(spec)
+	cmd := launcher.buildCommand(spec, nil, "", false)
 
 	if len(cmd) != 12 {
 		t.Fatalf("Expected 12 arguments, got %d: %v", len(cmd), cmd)
@@ -365,7 +464,7 @@ func TestLauncher_buildCommand(t *testing.T) {
 
 func TestLauncher_buildCommand_BackgroundMode(t *testing.T) {
 	fake := NewFakeRunner()
-	launcher := NewTmuxLauncher(fake, false, true, "background")
+	launcher := NewTmuxLauncher(fake, false, true, "background", "")
 
 	now := time.Now()
 	spec := domain.LaunchSpec{
@@ -393,7 +492,7 @@ func TestLauncher_buildCommand_BackgroundMode(t *testing.T) {
 		LauncherID:      "bb-3zg",
 	}
 
-	cmd := launcher.buildCommand(spec)
+	cmd := launcher.buildCommand(spec, nil, "", false)
 
 	if len(cmd) != 13 {
 		t.Fatalf("Expected 13 arguments, got %d: %v", len(cmd), cmd)
@@ -496,14 +595,14 @@ some footer`
 
 func TestLauncher_buildCommand_Escaping(t *testing.T) {
 	fake := NewFakeRunner()
-	launcher := NewTmuxLauncher(fake, false, true, "foreground")
+	launcher := NewTmuxLauncher(fake, false, true, "foreground", "")
 
 	spec := domain.LaunchSpec{
 		RenderedCommand: "echo 'hello world' && ls -la",
 		LauncherID:      "test-window",
 	}
 
-	cmd := launcher.buildCommand(spec)
+	cmd := launcher.buildCommand(spec, nil, "", false)
 	cmdStr := strings.Join(cmd, " ")
 
 	if !contains(cmdStr, "exec echo 'hello world' && ls -la") {
@@ -514,3 +613,115 @@ func TestLauncher_buildCommand_Escaping(t *testing.T) {
 		t.Errorf("Command should contain window name: %q", cmdStr)
 	}
 }
+
+func TestLauncher_Launch_Secrets(t *testing.T) {
+	t.Setenv("BDB_TEST_TOKEN", "s3cr'et")
+	fake := NewFakeRunner()
+	fake.AlwaysReturn = []byte("@1\n")
+	launcher := NewTmuxLauncher(fake, false, true, "foreground", "")
+
+	spec := domain.LaunchSpec{
+		Selection: domain.Selection{
+			Harness: domain.Harness{
+				Name: "opencode",
+				Env:  map[string]string{"LOG": "info", "TOKEN": "${BDB_TEST_TOKEN}"},
+			},
+		},
+		RenderedCommand: "opencode",
+		LauncherID:      "bb-1",
+	}
+	if _, err := launcher.Launch(context.Background(), spec); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	newWindow := fake.Commands[1]
+	if !contains(newWindow, "-e LOG=info") {
+		t.Errorf("Expected the literal value passed with -e, got %q", newWindow)
+	}
+	if contains(newWindow, "s3cr") {
+		t.Fatalf("Secret leaked into the tmux arguments: %q", newWindow)
+	}
+	match := regexp.MustCompile(`^\. '([^']+)' && rm -f '[^']+' && exec opencode$`).FindStringSubmatch(newWindow[strings.LastIndex(newWindow, "bb-1 ")+5:])
+	if match == nil {
+		t.Fatalf("Expected the command to source and delete a secrets file, got %q", newWindow)
+	}
+	defer os.Remove(match[1])
+
+	info, err := os.Stat(match[1])
+	if err != nil {
+		t.Fatalf("Secrets file not written: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected the secrets file readable by the user only, got %v", info.Mode().Perm())
+	}
+	data, _ := os.ReadFile(match[1])
+	if string(data) != "export TOKEN='s3cr'\\''et'\n" {
+		t.Errorf("Unexpected secrets file: %q", data)
+	}
+}
+
+func TestLauncher_Launch_SecretsRemovedOnError(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.AlwaysError = errors.New("tmux failed")
+	launcher := NewTmuxLauncher(fake, false, true, "foreground", "")
+
+	spec := domain.LaunchSpec{
+		Selection: domain.Selection{
+			Harness: domain.Harness{Env: map[string]string{"TOKEN": "!echo secret"}},
+		},
+		RenderedCommand: "opencode",
+		LauncherID:      "bb-1",
+	}
+	if _, err := launcher.Launch(context.Background(), spec); err == nil {
+		t.Fatal("Expected the tmux error")
+	}
+	newWindow := fake.Commands[len(fake.Commands)-1]
+	path := regexp.MustCompile(`\. '([^']+)'`).FindStringSubmatch(newWindow)
+	if path == nil {
+		t.Fatalf("Expected a secrets file in %q", newWindow)
+	}
+	if _, err := os.Stat(path[1]); !os.IsNotExist(err) {
+		t.Errorf("Expected the secrets file removed after a failed launch, got %v", err)
+	}
+}
+
+func TestLauncher_Launch_EnvError(t *testing.T) {
+	fake := NewFakeRunner()
+	launcher := NewTmuxLauncher(fake, false, true, "foreground", "")
+
+	spec := domain.LaunchSpec{
+		Selection: domain.Selection{
+			Harness: domain.Harness{Env: map[string]string{"TOKEN": "${BDB_TEST_UNSET_VAR}"}},
+		},
+		RenderedCommand: "opencode",
+	}
+	_, err := launcher.Launch(context.Background(), spec)
+	if err == nil || !contains(err.Error(), "env TOKEN: ${BDB_TEST_UNSET_VAR} is not set") {
+		t.Errorf("Expected the unset variable reported, got %v", err)
+	}
+	if len(fake.Commands) != 0 {
+		t.Errorf("Expected nothing launched, got %v", fake.Commands)
+	}
+}
+
+func TestLauncher_buildCommand_Secrets(t *testing.T) {
+	launcher := NewTmuxLauncher(NewFakeRunner(), false, true, "foreground", "")
+	env := []exec.EnvVar{
+		{Name: "LOG", Value: "info"},
+		{Name: "TOKEN", Value: "s3cret", Secret: true},
+	}
+
+	cmd := launcher.buildCommand(domain.LaunchSpec{LauncherID: "bb-1"}, env, "/tmp/env", false)
+	cmdStr := strings.Join(cmd, " ")
+	if contains(cmdStr, "s3cret") {
+		t.Errorf("Secret leaked into the tmux arguments: %q", cmdStr)
+	}
+	if cmd[len(cmd)-1] != `. '/tmp/env' && rm -f '/tmp/env' && exec "${SHELL:-sh}"` {
+		t.Errorf("Expected the shell started after sourcing the secrets, got %q", cmd[len(cmd)-1])
+	}
+
+	cmd = launcher.buildCommand(domain.LaunchSpec{LauncherID: "bb-1"}, env[:1], "", false)
+	if cmd[len(cmd)-1] != "" {
+		t.Errorf("Expected no wrapper without secrets, got %q", cmd[len(cmd)-1])
+	}
+}
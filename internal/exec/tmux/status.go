@@ -8,6 +8,7 @@ package tmux
 
 import (
 	"context"
+	"strconv"
 	"strings"
 )
 
@@ -18,6 +19,9 @@ const (
 	Running TmuxWindowStatus = iota
 	Dead
 	Unknown
+	// Exited means the window still exists but its pane process has ended.
+	// Launched windows set remain-on-exit so the exit status can be read.
+	Exited
 )
 
 // String returns a human-readable representation of the status.
@@ -29,6 +33,8 @@ func (s TmuxWindowStatus) String() string {
 		return "Dead"
 	case Unknown:
 		return "Unknown"
+	case Exited:
+		return "Exited"
 	default:
 		return "Invalid"
 	}
@@ -46,12 +52,33 @@ func NewStatusChecker(runner CommandRunner) *StatusChecker {
 	}
 }
 
+// WindowState is the result of a window status query.
+// ExitCode is only meaningful when Status is Exited; it is -1 when the pane
+// was terminated without an exit status (e.g. by a signal).
+type WindowState struct {
+	Status   TmuxWindowStatus
+	ExitCode int
+}
+
+// windowStatusFormat lists window ID, pane liveness, exit status and name.
+// Fields are separated by '|' because pane_dead_status is empty while the
+// pane is alive.
+const windowStatusFormat = "#{window_id}|#{pane_dead}|#{pane_dead_status}|#{window_name}"
+
 // CheckStatus determines if a tmux window is running.
-// Uses `tmux list-windows -F '#{window_name} #{window_id}'` to query window status.
-func (c *StatusChecker) CheckStatus(ctx context.Context, windowName string) TmuxWindowStatus {
-	output, err := c.runner.Run(ctx, "tmux", "list-windows", "-F", "#{window_name} #{window_id}")
+// See CheckWindow for how target is matched.
+func (c *StatusChecker) CheckStatus(ctx context.Context, target string) TmuxWindowStatus {
+	return c.CheckWindow(ctx, target).Status
+}
+
+// CheckWindow reports whether a tmux window is running, has exited (with its
+// exit status), or is gone. target is either a window ID ("@12"), which is
+// matched exactly, or a legacy window name. Windows are listed across all
+// sessions with `tmux list-windows -a`.
+func (c *StatusChecker) CheckWindow(ctx context.Context, target string) WindowState {
+	output, err := c.runner.Run(ctx, "tmux", "list-windows", "-a", "-F", windowStatusFormat)
 	if err != nil {
-		return Unknown
+		return WindowState{Status: Unknown}
 	}
 
 	lines := strings.Split(string(output), "\n")
@@ -61,15 +88,31 @@ func (c *StatusChecker) CheckStatus(ctx context.Context, windowName string) Tmux
 			continue
 		}
 
-		parts := strings.Fields(line)
-		if len(parts) == 0 {
+		parts := strings.SplitN(line, "|", 4)
+		if len(parts) < 4 {
 			continue
 		}
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
 
-		if parts[0] == windowName {
-			return Running
+		key := parts[3]
+		if strings.HasPrefix(target, "@") {
+			key = parts[0]
+		}
+		if key != target {
+			continue
+		}
+
+		if parts[1] != "1" {
+			return WindowState{Status: Running}
+		}
+		code, err := strconv.Atoi(parts[2])
+		if err != nil {
+			code = -1
 		}
+		return WindowState{Status: Exited, ExitCode: code}
 	}
 
-	return Dead
+	return WindowState{Status: Dead}
 }
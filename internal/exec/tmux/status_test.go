@@ -13,8 +13,8 @@ import (
 
 func TestStatusChecker_CheckStatus_Running(t *testing.T) {
 	fake := NewFakeRunner()
-	fake.SetOutput("tmux", []string{"list-windows", "-F", "#{window_name} #{window_id}"},
-		[]byte("bb-abc @1\nbb-def @2\nbb-3zg @3\n"))
+	fake.SetOutput("tmux", []string{"list-windows", "-a", "-F", "#{window_id}|#{pane_dead}|#{pane_dead_status}|#{window_name}"},
+		[]byte("@1|0||bb-abc\n@2|0||bb-def\n@3|0||bb-3zg\n"))
 
 	checker := NewStatusChecker(fake)
 	ctx := context.Background()
@@ -27,8 +27,8 @@ func TestStatusChecker_CheckStatus_Running(t *testing.T) {
 
 func TestStatusChecker_CheckStatus_Dead(t *testing.T) {
 	fake := NewFakeRunner()
-	fake.SetOutput("tmux", []string{"list-windows", "-F", "#{window_name} #{window_id}"},
-		[]byte("bb-abc @1\nbb-def @2\n"))
+	fake.SetOutput("tmux", []string{"list-windows", "-a", "-F", "#{window_id}|#{pane_dead}|#{pane_dead_status}|#{window_name}"},
+		[]byte("@1|0||bb-abc\n@2|0||bb-def\n"))
 
 	checker := NewStatusChecker(fake)
 	ctx := context.Background()
@@ -41,7 +41,7 @@ func TestStatusChecker_CheckStatus_Dead(t *testing.T) {
 
 func TestStatusChecker_CheckStatus_Unknown_Error(t *testing.T) {
 	fake := NewFakeRunner()
-	fake.SetError("tmux", []string{"list-windows", "-F", "#{window_name} #{window_id}"},
+	fake.SetError("tmux", []string{"list-windows", "-a", "-F", "#{window_id}|#{pane_dead}|#{pane_dead_status}|#{window_name}"},
 		&fakeError{"tmux command failed"})
 
 	checker := NewStatusChecker(fake)
@@ -55,7 +55,7 @@ func TestStatusChecker_CheckStatus_Unknown_Error(t *testing.T) {
 
 func TestStatusChecker_CheckStatus_Unknown_EmptyOutput(t *testing.T) {
 	fake := NewFakeRunner()
-	fake.SetOutput("tmux", []string{"list-windows", "-F", "#{window_name} #{window_id}"},
+	fake.SetOutput("tmux", []string{"list-windows", "-a", "-F", "#{window_id}|#{pane_dead}|#{pane_dead_status}|#{window_name}"},
 		[]byte(""))
 
 	checker := NewStatusChecker(fake)
@@ -69,8 +69,8 @@ func TestStatusChecker_CheckStatus_Unknown_EmptyOutput(t *testing.T) {
 
 func TestStatusChecker_CheckStatus_WhitespaceHandling(t *testing.T) {
 	fake := NewFakeRunner()
-	fake.SetOutput("tmux", []string{"list-windows", "-F", "#{window_name} #{window_id}"},
-		[]byte("  bb-abc  @1  \n  bb-def  @2  \n  bb-3zg  @3  \n"))
+	fake.SetOutput("tmux", []string{"list-windows", "-a", "-F", "#{window_id}|#{pane_dead}|#{pane_dead_status}|#{window_name}"},
+		[]byte("  @1 | 0 | | bb-abc  \n  @2 | 0 | | bb-def  \n  @3 | 0 | | bb-3zg  \n"))
 
 	checker := NewStatusChecker(fake)
 	ctx := context.Background()
@@ -81,6 +81,51 @@ func TestStatusChecker_CheckStatus_WhitespaceHandling(t *testing.T) {
 	}
 }
 
+func TestStatusChecker_CheckStatus_ByWindowID(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.SetOutput("tmux", []string{"list-windows", "-a", "-F", "#{window_id}|#{pane_dead}|#{pane_dead_status}|#{window_name}"},
+		[]byte("@1|0||bb-abc\n@3|0||renamed-by-user\n"))
+
+	checker := NewStatusChecker(fake)
+	ctx := context.Background()
+
+	if status := checker.CheckStatus(ctx, "@3"); status != Running {
+		t.Errorf("Expected Running for renamed window, got %v", status)
+	}
+	if status := checker.CheckStatus(ctx, "@2"); status != Dead {
+		t.Errorf("Expected Dead for missing window ID, got %v", status)
+	}
+}
+
+func TestStatusChecker_CheckWindow_Exited(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.SetOutput("tmux", []string{"list-windows", "-a", "-F", "#{window_id}|#{pane_dead}|#{pane_dead_status}|#{window_name}"},
+		[]byte("@1|1|0|bb-ok\n@2|1|3|bb-crash\n@3|1||bb-signal\n@4|0||bb-live\n"))
+
+	checker := NewStatusChecker(fake)
+	ctx := context.Background()
+
+	tests := []struct {
+		target string
+		want   WindowState
+	}{
+		{"@1", WindowState{Status: Exited, ExitCode: 0}},
+		{"@2", WindowState{Status: Exited, ExitCode: 3}},
+		{"@3", WindowState{Status: Exited, ExitCode: -1}},
+		{"@4", WindowState{Status: Running}},
+		{"bb-crash", WindowState{Status: Exited, ExitCode: 3}},
+		{"@9", WindowState{Status: Dead}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			if got := checker.CheckWindow(ctx, tt.target); got != tt.want {
+				t.Errorf("CheckWindow(%q) = %+v, want %+v", tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTmuxWindowStatus_String(t *testing.T) {
 	tests := []struct {
 		status TmuxWindowStatus
@@ -89,6 +134,7 @@ func TestTmuxWindowStatus_String(t *testing.T) {
 		{Running, "Running"},
 		{Dead, "Dead"},
 		{Unknown, "Unknown"},
+		{Exited, "Exited"},
 		{TmuxWindowStatus(999), "Invalid"},
 	}
 
@@ -1,10 +1,14 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/x/ansi"
 
@@ -136,6 +140,21 @@ func RebuildAgentNodesInSidebar(m *UIModel) {
 	RestoreSidebarCursorByPath(state, prevPath)
 }
 
+// refreshAttentionFilter re-applies the sidebar attention filter after an
+// agent's attention state changed, keeping the cursor on the same node.
+func refreshAttentionFilter(m *UIModel) {
+	state := m.sidebar.State()
+	if !state.AttentionOnly {
+		return
+	}
+	prevPath := ""
+	if node := state.CurrentNode(); node != nil {
+		prevPath = node.Path
+	}
+	state.RebuildFlatNodes()
+	RestoreSidebarCursorByPath(state, prevPath)
+}
+
 // RestoreSidebarCursorByPath restores the sidebar cursor to the previous path if possible
 func RestoreSidebarCursorByPath(state *SidebarState, path string) {
 	if path == "" {
@@ -199,12 +218,44 @@ func (m UIModel) HandleAgentSelected(msg AgentSelectedMsg) (tea.Model, tea.Cmd)
 }
 
 // HandleAgentStatus updates an agent's status in both the agents map and sidebar
+// The first transition out of AgentRunning records the end time and exit code,
+// persists the final status and starts the project's verify_command unless it
+// is manual. The queued launches that now fit the concurrency limits start.
 func (m UIModel) HandleAgentStatus(msg AgentStatusMsg) (tea.Model, tea.Cmd) {
-	if agent, ok := m.agents[msg.AgentID]; ok {
-		agent.Info.Status = msg.Status
-		UpdateAgentNodeStatus(&m, msg.AgentID, msg.Status)
+	m, cmd := m.updateAgentStatus(msg, true)
+	m, dispatchCmd := m.dispatchQueue()
+	return m, tea.Batch(cmd, dispatchCmd)
+}
+
+// updateAgentStatus implements HandleAgentStatus; autoVerify is false when
+// the ended run is about to be replaced and not worth verifying.
+func (m UIModel) updateAgentStatus(msg AgentStatusMsg, autoVerify bool) (UIModel, tea.Cmd) {
+	agent, ok := m.agents[msg.AgentID]
+	if !ok {
+		return m, nil
 	}
-	return m, nil
+
+	wasRunning := agent.Info.Status == domain.AgentRunning
+	agent.Info.Status = msg.Status
+	UpdateAgentNodeStatus(&m, msg.AgentID, msg.Status)
+
+	if !wasRunning || msg.Status == domain.AgentRunning {
+		return m, nil
+	}
+	agent.Info.Attention = domain.AttentionNone
+	refreshAttentionFilter(&m)
+	agent.Info.EndedAt = time.Now()
+	agent.Info.ExitCode = msg.ExitCode
+
+	var verifyCmd tea.Cmd
+	if verify := m.projectVerify(agent.Info); autoVerify && verify.Command != "" && !verify.Manual {
+		verifyCmd = m.startVerify(agent, verify)
+	}
+	return m, tea.Batch(
+		finishRunningAgentCmd(m.app, *agent.Info, agent.Capture),
+		postExitHooksCmd(agent.PostExit, agent.HookDir, commandTimeout(m.app), *agent.Info),
+		verifyCmd,
+	)
 }
 
 // HandleAgentTick monitors an agent's status and output
@@ -229,10 +280,15 @@ func (m UIModel) HandleAgentTick(msg agentTickMsg) (tea.Model, tea.Cmd) {
 	}
 
 	if agent.Info.Status == domain.AgentRunning {
+		var attentionCmd tea.Cmd
+		if rules := m.attentionRules(agent.Info.HarnessName); agent.Capture != nil && len(rules) > 0 {
+			attentionCmd = detectAttentionCmd(agentID, agent.Capture, rules)
+		}
 		return m, tea.Batch(
-			pollAgentStatusCmd(m.app, agentID, agent.Info.LauncherID),
+			pollAgentStatusCmd(m.app, agentID, agent.Info.TmuxTarget()),
 			startAgentMonitoringCmd(agentID),
 			readOutputCmd,
+			attentionCmd,
 		)
 	}
 
@@ -250,6 +306,32 @@ func (m UIModel) HandleAgentOutput(msg agentOutputMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// attentionRules returns the attention rules configured for a harness.
+func (m UIModel) attentionRules(harnessName string) []domain.AttentionRule {
+	for _, h := range m.harnesses {
+		if h.Name == harnessName {
+			return h.AttentionRules
+		}
+	}
+	return nil
+}
+
+// HandleAgentAttention records a detected attention state. The user is
+// notified when a running agent starts needing attention or changes state.
+func (m UIModel) HandleAgentAttention(msg agentAttentionMsg) (tea.Model, tea.Cmd) {
+	agent, ok := m.agents[msg.agentID]
+	if !ok || agent.Info.Status != domain.AgentRunning || agent.Info.Attention == msg.state {
+		return m, nil
+	}
+
+	agent.Info.Attention = msg.state
+	refreshAttentionFilter(&m)
+	if msg.state == domain.AttentionNone || m.app == nil {
+		return m, nil
+	}
+	return m, notifyAttentionCmd(m.runner(), m.app.AttentionNotify(), *agent.Info)
+}
+
 // HandleAgentCleared removes an agent from the UI when cleared
 func (m UIModel) HandleAgentCleared(msg AgentClearedMsg) (tea.Model, tea.Cmd) {
 	delete(m.agents, msg.AgentID)
@@ -283,7 +365,86 @@ func (m UIModel) HandleAllStoppedAgentsCleared(msg AllStoppedAgentsClearedMsg) (
 	return m, nil
 }
 
+// runner returns the app's tmux command runner, or nil when no app is attached.
+func (m UIModel) runner() tmux.CommandRunner {
+	if m.app == nil {
+		return nil
+	}
+	return m.app.Runner()
+}
+
+// selectedAgent returns the running agent under the sidebar cursor, if any.
+func (m UIModel) selectedAgent() *RunningAgent {
+	node := m.sidebar.State().CurrentNode()
+	if node == nil || node.Type != domain.NodeTypeAgent || node.AgentInfo == nil {
+		return nil
+	}
+	return m.agents[node.AgentInfo.ID]
+}
+
+// newAgentPromptInput creates the text input for follow-up prompts.
+func newAgentPromptInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "Follow-up prompt"
+	ti.Prompt = "> "
+	ti.CharLimit = 0
+	ti.Cursor.SetMode(cursor.CursorStatic)
+	ti.Focus()
+	return ti
+}
+
+// closeAgentPrompt leaves the follow-up prompt input, returning to the agent
+// output view if it was open.
+func (m UIModel) closeAgentPrompt() UIModel {
+	m.promptAgentID = ""
+	m.agentPrompt.Blur()
+	if m.viewingAgentID != "" {
+		m.state = ViewStateAgentOutput
+	} else {
+		m.state = ViewStateMatrix
+	}
+	return m
+}
+
+// restartLaunchSpec rebuilds the LaunchSpec an agent was started with.
+// The harness environment is taken from the current config because it is
+// not persisted.
+func restartLaunchSpec(info domain.AgentInfo, harnesses []domain.Harness) (domain.LaunchSpec, error) {
+	if info.RenderedCommand == "" {
+		return domain.LaunchSpec{}, fmt.Errorf("agent %s has no recorded command to restart", info.Name)
+	}
+	harness := domain.Harness{Name: info.HarnessName}
+	for _, h := range harnesses {
+		if h.Name == info.HarnessName {
+			harness = h
+			break
+		}
+	}
+	launcherID := info.TicketID
+	if launcherID == "" {
+		launcherID = info.LauncherID
+	}
+	return domain.LaunchSpec{
+		Selection: domain.Selection{
+			Ticket:  domain.Ticket{ID: info.TicketID, Title: info.TicketTitle},
+			Harness: harness,
+			Model:   info.ModelName,
+			Agent:   info.AgentName,
+		},
+		RenderedCommand: info.RenderedCommand,
+		RenderedPrompt:  info.RenderedPrompt,
+		LauncherID:      launcherID,
+		WorkDir:         info.WorktreePath,
+	}, nil
+}
+
 // HandleSidebarAgentKeysMsg handles key presses when sidebar is focused
+//
+// On an agent node: c clears a stopped agent, g jumps to its tmux window,
+// s sends a follow-up prompt, x sends Ctrl-C, X kills the window, R
+// restarts the agent with the same LaunchSpec and v runs the project's
+// verify_command on its worktree. C clears all stopped agents.
+// Agents running on other hosts are read-only and only support c.
 func (m UIModel) HandleSidebarAgentKeysMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 	if m.focus != FocusSidebar {
 		return m, nil, false
@@ -294,23 +455,116 @@ func (m UIModel) HandleSidebarAgentKeysMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd,
 		node := m.sidebar.State().CurrentNode()
 		if node != nil && node.Type == domain.NodeTypeAgent && node.AgentInfo != nil {
 			var capture *tmux.OutputCapture
+			var exitedWindowID string
 			if agent, ok := m.agents[node.AgentInfo.ID]; ok {
 				capture = agent.Capture
+				if agent.Info.Status != domain.AgentRunning {
+					exitedWindowID = agent.Info.WindowID
+				}
 			}
-			return m, clearAgentCmd(node.AgentInfo.ID, capture), true
+			return m, clearAgentCmd(node.AgentInfo.ID, capture, m.runner(), exitedWindowID), true
 		}
 	case "C":
 		var toClear []agentToClear
 		for id, agent := range m.agents {
 			if agent.Info.Status != domain.AgentRunning {
-				toClear = append(toClear, agentToClear{id: id, capture: agent.Capture})
+				toClear = append(toClear, agentToClear{id: id, capture: agent.Capture, windowID: agent.Info.WindowID})
 			}
 		}
 		if len(toClear) > 0 {
-			return m, clearAllStoppedAgentsCmd(toClear), true
+			return m, clearAllStoppedAgentsCmd(toClear, m.runner()), true
 		}
 		return m, nil, true
+	case "g", "s", "x", "X", "R", "v":
+		agent := m.selectedAgent()
+		if agent == nil {
+			return m, nil, false
+		}
+		return m.handleAgentActionKey(msg.String(), agent)
 	}
 
 	return m, nil, false
 }
+
+// handleAgentActionKey performs an interactive action on the selected agent.
+func (m UIModel) handleAgentActionKey(k string, agent *RunningAgent) (tea.Model, tea.Cmd, bool) {
+	info := agent.Info
+	if info.Remote {
+		return m, warningCmd(fmt.Errorf("agent %s runs on %s and is read-only", info.Name, info.HostLabel())), true
+	}
+	target := info.TmuxTarget()
+	running := info.Status == domain.AgentRunning
+
+	if k == "R" {
+		spec, err := restartLaunchSpec(*info, m.harnesses)
+		if err != nil {
+			return m, warningCmd(err), true
+		}
+		if m.app != nil {
+			if project, ok := m.app.ProjectConfig(info.ProjectDir); ok {
+				spec.Selection.ProjectEnvFile = project.EnvFile
+			}
+		}
+		spec.PreLaunch = agent.PreLaunch
+		spec.PostExit = agent.PostExit
+		// The restart holds a slot against the concurrency limits until its
+		// result arrives. Restarting a running agent takes over its slot;
+		// a stopped one needs a free slot and is refused without one.
+		slot := domain.AgentSlot{Harness: spec.Selection.Harness.Name, Model: spec.Selection.Model}
+		if !running {
+			if reason := m.limitReason(spec.Selection.Harness, spec.Selection.Model); reason != "" {
+				return m, warningCmd(fmt.Errorf("agent %s not restarted: %s", info.Name, reason)), true
+			}
+			m.starting = append(m.starting, slot)
+			return m, restartAgentCmd(m.app, spec, *info, &slot), true
+		}
+		// Record the old run as stopped before its window is replaced.
+		newM, finishCmd := m.updateAgentStatus(AgentStatusMsg{AgentID: info.ID, Status: domain.AgentFailed}, false)
+		newM.starting = append(newM.starting, slot)
+		return newM, tea.Sequence(finishCmd, restartAgentCmd(m.app, spec, *info, &slot)), true
+	}
+
+	if k == "v" {
+		verify := m.projectVerify(info)
+		switch {
+		case verify.Command == "":
+			return m, warningCmd(fmt.Errorf("project of agent %s has no verify_command", info.Name)), true
+		case running:
+			return m, warningCmd(fmt.Errorf("agent %s is still running", info.Name)), true
+		case info.Verify == domain.VerifyRunning:
+			return m, nil, true
+		}
+		return m, m.startVerify(agent, verify), true
+	}
+
+	if target == "" {
+		return m, warningCmd(fmt.Errorf("agent %s has no tmux window", info.Name)), true
+	}
+	if k != "g" && !running {
+		return m, warningCmd(fmt.Errorf("agent %s is not running", info.Name)), true
+	}
+
+	switch k {
+	case "g":
+		return m, windowActionCmd(m.runner(), func(ctx context.Context, c *tmux.WindowController) error {
+			return c.Select(ctx, target)
+		}), true
+	case "s":
+		m.promptAgentID = info.ID
+		m.agentPrompt = newAgentPromptInput()
+		m.state = ViewStateAgentPrompt
+		return m, nil, true
+	case "x":
+		return m, windowActionCmd(m.runner(), func(ctx context.Context, c *tmux.WindowController) error {
+			return c.Interrupt(ctx, target)
+		}), true
+	case "X":
+		// Killing the window loses the pane, so the final status is recorded
+		// (and the output saved) first.
+		newM, finishCmd := m.HandleAgentStatus(AgentStatusMsg{AgentID: info.ID, Status: domain.AgentFailed})
+		return newM, tea.Sequence(finishCmd, windowActionCmd(m.runner(), func(ctx context.Context, c *tmux.WindowController) error {
+			return c.Kill(ctx, target)
+		})), true
+	}
+	return m, nil, false
+}
@@ -1,12 +1,19 @@
 package ui
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/megatherium/blunderbust/internal/app"
 	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/exec/tmux"
 )
 
 func TestPersistedAgentID(t *testing.T) {
@@ -284,6 +291,65 @@ func TestHandleAgentStatus(t *testing.T) {
 	assert.Equal(t, domain.AgentCompleted, newModel.(UIModel).agents["agent-123"].Info.Status)
 }
 
+func TestHandleAgentStatus_RecordsExitCode(t *testing.T) {
+	m := NewTestModel()
+	m.agents = make(map[string]*RunningAgent)
+	m.agents["agent-123"] = &RunningAgent{
+		Info: &domain.AgentInfo{
+			ID:     "agent-123",
+			Status: domain.AgentRunning,
+		},
+	}
+
+	exitCode := 2
+	newModel, cmd := m.HandleAgentStatus(AgentStatusMsg{
+		AgentID:  "agent-123",
+		Status:   domain.AgentFailed,
+		ExitCode: &exitCode,
+	})
+
+	info := newModel.(UIModel).agents["agent-123"].Info
+	assert.Equal(t, domain.AgentFailed, info.Status)
+	assert.NotNil(t, info.ExitCode)
+	assert.Equal(t, 2, *info.ExitCode)
+	assert.False(t, info.EndedAt.IsZero())
+	assert.NotNil(t, cmd, "final status should be persisted")
+
+	// A repeated poll result must not overwrite the recorded end.
+	endedAt := info.EndedAt
+	_, cmd = newModel.(UIModel).HandleAgentStatus(AgentStatusMsg{AgentID: "agent-123", Status: domain.AgentFailed})
+	assert.Nil(t, cmd)
+	assert.Equal(t, endedAt, info.EndedAt)
+}
+
+func TestHandleAgentStatus_RunsPostExitHooks(t *testing.T) {
+	dir := t.TempDir()
+	m := NewTestModel()
+	m.agents = map[string]*RunningAgent{
+		"agent-123": {
+			Info:     &domain.AgentInfo{ID: "agent-123", Name: "bb-1", Status: domain.AgentRunning},
+			PostExit: []string{`echo "$BDB_AGENT_STATUS $BDB_EXIT_CODE" > done`, "exit 3", "touch never"},
+			HookDir:  dir,
+		},
+	}
+
+	exitCode := 1
+	_, cmd := m.HandleAgentStatus(AgentStatusMsg{AgentID: "agent-123", Status: domain.AgentFailed, ExitCode: &exitCode})
+	assert.NotNil(t, cmd)
+
+	msg := postExitHooksCmd(m.agents["agent-123"].PostExit, dir, time.Minute, *m.agents["agent-123"].Info)()
+	warning, ok := msg.(warningMsg)
+	if assert.True(t, ok, "a failing hook is reported") {
+		assert.Contains(t, warning.err.Error(), `agent bb-1: post_exit hook "exit 3" failed`)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "done"))
+	assert.NoError(t, err)
+	assert.Equal(t, "failed 1\n", string(data))
+	assert.NoFileExists(t, filepath.Join(dir, "never"), "hooks after a failing one are skipped")
+
+	assert.Nil(t, postExitHooksCmd(nil, dir, time.Minute, domain.AgentInfo{}))
+}
+
 func TestUpdateAgentNodeStatus(t *testing.T) {
 	m := NewTestModel()
 	m.agents = make(map[string]*RunningAgent)
@@ -620,3 +686,320 @@ func TestHandleSidebarAgentKeysMsg(t *testing.T) {
 		assert.Equal(t, FocusTickets, newModel.(UIModel).focus)
 	})
 }
+
+// newAgentActionModel returns a model with one agent node selected in the
+// sidebar and a fake tmux runner attached.
+func newAgentActionModel(t *testing.T, status domain.AgentStatus) (*UIModel, *tmux.FakeRunner) {
+	t.Helper()
+	fake := tmux.NewFakeRunner()
+	application, err := app.NewApp(&mockConfigLoader{}, &mockLauncher{}, nil, fake, nil, domain.AppOptions{Demo: true})
+	if err != nil {
+		t.Fatalf("NewApp() error = %v", err)
+	}
+
+	m := NewTestModel()
+	m.app = application
+	m.agents = make(map[string]*RunningAgent)
+	m.harnesses = []domain.Harness{{Name: "claude", Env: map[string]string{"FOO": "bar"}}}
+	m.sidebar.State().SetNodes([]domain.SidebarNode{{
+		Type:       domain.NodeTypeProject,
+		Path:       "/repo",
+		IsExpanded: true,
+		Children:   []domain.SidebarNode{{Type: domain.NodeTypeWorktree, Path: "/repo"}},
+	}})
+	m.sidebar.State().RebuildFlatNodes()
+
+	info := &domain.AgentInfo{
+		ID:              "bb-1",
+		Name:            "bb-1",
+		LauncherID:      "bb-1",
+		WindowID:        "@4",
+		WorktreePath:    "/repo",
+		Status:          status,
+		TicketID:        "bb-1",
+		HarnessName:     "claude",
+		ModelName:       "sonnet",
+		RenderedCommand: "claude --model sonnet",
+		RenderedPrompt:  "Work on bb-1",
+	}
+	m.agents[info.ID] = &RunningAgent{Info: info}
+	AddAgentNodeToSidebar(m, info)
+	if !m.sidebar.State().SelectByPath("agent:bb-1") {
+		t.Fatal("agent node not found in sidebar")
+	}
+	return m, fake
+}
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func TestHandleSidebarAgentKeysMsg_Jump(t *testing.T) {
+	m, fake := newAgentActionModel(t, domain.AgentRunning)
+	fake.SetOutput("tmux", []string{"select-window", "-t", "@4"}, nil)
+	fake.SetOutput("tmux", []string{"switch-client", "-t", "@4"}, nil)
+
+	_, cmd, handled := m.HandleSidebarAgentKeysMsg(runeKey('g'))
+	assert.True(t, handled)
+	assert.Nil(t, cmd())
+	assert.Equal(t, []string{"tmux select-window -t @4", "tmux switch-client -t @4"}, fake.Commands)
+}
+
+func TestHandleSidebarAgentKeysMsg_Interrupt(t *testing.T) {
+	m, fake := newAgentActionModel(t, domain.AgentRunning)
+	fake.SetOutput("tmux", []string{"send-keys", "-t", "@4", "C-c"}, nil)
+
+	_, cmd, handled := m.HandleSidebarAgentKeysMsg(runeKey('x'))
+	assert.True(t, handled)
+	assert.Nil(t, cmd())
+	assert.Equal(t, []string{"tmux send-keys -t @4 C-c"}, fake.Commands)
+}
+
+func TestHandleSidebarAgentKeysMsg_InterruptStoppedAgent(t *testing.T) {
+	m, fake := newAgentActionModel(t, domain.AgentCompleted)
+
+	_, cmd, handled := m.HandleSidebarAgentKeysMsg(runeKey('x'))
+	assert.True(t, handled)
+	_, isWarning := cmd().(warningMsg)
+	assert.True(t, isWarning)
+	assert.Empty(t, fake.Commands)
+}
+
+func TestHandleSidebarAgentKeysMsg_RemoteAgentIsReadOnly(t *testing.T) {
+	for _, k := range []rune{'g', 's', 'x', 'X', 'R'} {
+		m, fake := newAgentActionModel(t, domain.AgentRunning)
+		info := m.agents["bb-1"].Info
+		info.Remote, info.Hostname, info.Owner = true, "desktop", "bob"
+
+		newModel, cmd, handled := m.HandleSidebarAgentKeysMsg(runeKey(k))
+		assert.True(t, handled, "key %c", k)
+		_, isWarning := cmd().(warningMsg)
+		assert.True(t, isWarning, "key %c", k)
+		assert.Empty(t, fake.Commands, "key %c", k)
+		assert.Equal(t, domain.AgentRunning, newModel.(UIModel).agents["bb-1"].Info.Status, "key %c", k)
+	}
+}
+
+func TestHandleSidebarAgentKeysMsg_Kill(t *testing.T) {
+	m, _ := newAgentActionModel(t, domain.AgentRunning)
+
+	newModel, cmd, handled := m.HandleSidebarAgentKeysMsg(runeKey('X'))
+	assert.True(t, handled)
+	assert.NotNil(t, cmd)
+
+	agent := newModel.(UIModel).agents["bb-1"]
+	assert.Equal(t, domain.AgentFailed, agent.Info.Status)
+	assert.False(t, agent.Info.EndedAt.IsZero())
+}
+
+func TestHandleSidebarAgentKeysMsg_SendPrompt(t *testing.T) {
+	m, fake := newAgentActionModel(t, domain.AgentRunning)
+	fake.SetOutput("tmux", []string{"send-keys", "-t", "@4", "-l", "add tests"}, nil)
+	fake.SetOutput("tmux", []string{"send-keys", "-t", "@4", "Enter"}, nil)
+
+	newModel, _, handled := m.HandleSidebarAgentKeysMsg(runeKey('s'))
+	assert.True(t, handled)
+	model := newModel.(UIModel)
+	assert.Equal(t, ViewStateAgentPrompt, model.state)
+
+	// Keys typed into the prompt must not trigger global bindings like quit.
+	for _, r := range "add tests" {
+		var next tea.Model
+		next, _, handled = model.handleKeyMsg(runeKey(r))
+		assert.True(t, handled)
+		model = next.(UIModel)
+	}
+	assert.Equal(t, ViewStateAgentPrompt, model.state)
+
+	next, cmd, _ := model.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	model = next.(UIModel)
+	assert.Equal(t, ViewStateMatrix, model.state)
+	assert.Equal(t, "", model.promptAgentID)
+	assert.Nil(t, cmd())
+	assert.Equal(t, []string{"tmux send-keys -t @4 -l add tests", "tmux send-keys -t @4 Enter"}, fake.Commands)
+}
+
+func TestHandleSidebarAgentKeysMsg_SendPromptCancel(t *testing.T) {
+	m, fake := newAgentActionModel(t, domain.AgentRunning)
+	m.state = ViewStateAgentOutput
+	m.viewingAgentID = "bb-1"
+
+	newModel, _, _ := m.HandleSidebarAgentKeysMsg(runeKey('s'))
+	next, cmd, handled := newModel.(UIModel).handleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, handled)
+	assert.Nil(t, cmd)
+	assert.Equal(t, ViewStateAgentOutput, next.(UIModel).state)
+	assert.Empty(t, fake.Commands)
+}
+
+func TestHandleSidebarAgentKeysMsg_Restart(t *testing.T) {
+	m, fake := newAgentActionModel(t, domain.AgentCompleted)
+	fake.SetOutput("tmux", []string{"kill-window", "-t", "@4"}, nil)
+
+	_, cmd, handled := m.HandleSidebarAgentKeysMsg(runeKey('R'))
+	assert.True(t, handled)
+
+	msg, ok := cmd().(launchResultMsg)
+	assert.True(t, ok)
+	assert.NoError(t, msg.err)
+	assert.Equal(t, "bb-1", msg.replacesAgentID)
+	assert.Equal(t, "/repo", msg.worktreePath)
+	assert.Equal(t, "claude --model sonnet", msg.spec.RenderedCommand)
+	assert.Equal(t, "Work on bb-1", msg.spec.RenderedPrompt)
+	assert.Equal(t, []string{"tmux kill-window -t @4"}, fake.Commands)
+
+	newModel, _ := m.handleLaunchResult(msg)
+	model := newModel.(UIModel)
+	_, oldExists := model.agents["bb-1"]
+	assert.False(t, oldExists)
+	restarted, ok := model.agents["mock-launcher"]
+	assert.True(t, ok)
+	assert.Equal(t, "/repo", restarted.Info.WorktreePath)
+	assert.Equal(t, "claude --model sonnet", restarted.Info.RenderedCommand)
+}
+
+func TestHandleSidebarAgentKeysMsg_RestartWithoutWindowID(t *testing.T) {
+	m, fake := newAgentActionModel(t, domain.AgentCompleted)
+	m.agents["bb-1"].Info.WindowID = ""
+	fake.SetOutput("tmux", []string{"kill-window", "-t", "bb-1"}, nil)
+
+	_, cmd, handled := m.HandleSidebarAgentKeysMsg(runeKey('R'))
+	assert.True(t, handled)
+
+	msg, ok := cmd().(launchResultMsg)
+	assert.True(t, ok)
+	assert.NoError(t, msg.err)
+	assert.Equal(t, []string{"tmux kill-window -t bb-1"}, fake.Commands)
+}
+
+func TestHandleSidebarAgentKeysMsg_RestartRunsPreLaunch(t *testing.T) {
+	m, fake := newAgentActionModel(t, domain.AgentCompleted)
+	agent := m.agents["bb-1"]
+	agent.Info.WorktreePath = t.TempDir()
+	agent.PreLaunch = []string{"exit 3"}
+	fake.SetOutput("tmux", []string{"kill-window", "-t", "@4"}, nil)
+
+	_, cmd, handled := m.HandleSidebarAgentKeysMsg(runeKey('R'))
+	assert.True(t, handled)
+
+	msg, ok := cmd().(launchResultMsg)
+	assert.True(t, ok)
+	if !assert.Error(t, msg.err) {
+		return
+	}
+	assert.Contains(t, msg.err.Error(), "launch aborted by pre_launch")
+	assert.Nil(t, msg.res, "a failing pre_launch hook must abort the restart")
+}
+
+func TestHandleSidebarAgentKeysMsg_RestartAtLimit(t *testing.T) {
+	m, fake := newAgentActionModel(t, domain.AgentCompleted)
+	m.app.Opts.Limits = domain.ConcurrencyLimits{Agents: 1}
+	m.agents["other"] = &RunningAgent{Info: &domain.AgentInfo{ID: "other", Status: domain.AgentRunning, HarnessName: "codex"}}
+
+	newModel, cmd, handled := m.HandleSidebarAgentKeysMsg(runeKey('R'))
+	assert.True(t, handled)
+	msg, isWarning := cmd().(warningMsg)
+	assert.True(t, isWarning)
+	assert.Contains(t, msg.err.Error(), "not restarted")
+	assert.Empty(t, newModel.(UIModel).starting)
+	assert.Empty(t, fake.Commands)
+}
+
+func TestHandleSidebarAgentKeysMsg_RestartHoldsSlot(t *testing.T) {
+	m, fake := newAgentActionModel(t, domain.AgentCompleted)
+	m.app.Opts.Limits = domain.ConcurrencyLimits{Agents: 1}
+	fake.SetOutput("tmux", []string{"kill-window", "-t", "@4"}, nil)
+
+	newModel, cmd, _ := m.HandleSidebarAgentKeysMsg(runeKey('R'))
+	model := newModel.(UIModel)
+	assert.Equal(t, []domain.AgentSlot{{Harness: "claude", Model: "sonnet"}}, model.starting)
+	assert.NotEmpty(t, model.limitReason(domain.Harness{Name: "codex"}, ""), "the restart counts against the limit while it starts")
+
+	msg, ok := cmd().(launchResultMsg)
+	assert.True(t, ok)
+	newModel, _ = model.handleLaunchResult(msg)
+	assert.Empty(t, newModel.(UIModel).starting, "the slot is released once the restart is done")
+}
+
+func TestRestartLaunchSpec(t *testing.T) {
+	harnesses := []domain.Harness{{Name: "claude", Env: map[string]string{"FOO": "bar"}}}
+	info := domain.AgentInfo{
+		Name:            "bb-1",
+		LauncherID:      "bb-1-2",
+		WorktreePath:    "/repo/wt",
+		TicketID:        "bb-1",
+		TicketTitle:     "Fix it",
+		HarnessName:     "claude",
+		ModelName:       "sonnet",
+		AgentName:       "coder",
+		RenderedCommand: "claude",
+		RenderedPrompt:  "prompt",
+	}
+
+	spec, err := restartLaunchSpec(info, harnesses)
+	assert.NoError(t, err)
+	assert.Equal(t, "bb-1", spec.LauncherID)
+	assert.Equal(t, "/repo/wt", spec.WorkDir)
+	assert.Equal(t, "Fix it", spec.Selection.Ticket.Title)
+	assert.Equal(t, "bar", spec.Selection.Harness.Env["FOO"])
+	assert.Equal(t, "sonnet", spec.Selection.Model)
+	assert.Equal(t, "coder", spec.Selection.Agent)
+
+	info.RenderedCommand = ""
+	_, err = restartLaunchSpec(info, harnesses)
+	assert.Error(t, err)
+}
+
+func TestHandleAgentAttention(t *testing.T) {
+	m, fake := newAgentActionModel(t, domain.AgentRunning)
+	m.app.Opts.AttentionNotify = domain.AttentionNotifyDisplayMessage
+	fake.SetOutput("tmux", []string{"display-message", "bdb: bb-1 is waiting for approval"}, nil)
+
+	newModel, cmd := m.HandleAgentAttention(agentAttentionMsg{agentID: "bb-1", state: domain.AttentionApproval})
+	model := newModel.(UIModel)
+	assert.Equal(t, domain.AttentionApproval, model.agents["bb-1"].Info.Attention)
+	assert.NotNil(t, cmd)
+	assert.Nil(t, cmd())
+	assert.Equal(t, []string{"tmux display-message bdb: bb-1 is waiting for approval"}, fake.Commands)
+
+	// The same state again does not notify twice.
+	_, cmd = model.HandleAgentAttention(agentAttentionMsg{agentID: "bb-1", state: domain.AttentionApproval})
+	assert.Nil(t, cmd)
+
+	// Clearing the state does not notify.
+	newModel, cmd = model.HandleAgentAttention(agentAttentionMsg{agentID: "bb-1", state: domain.AttentionNone})
+	assert.Nil(t, cmd)
+	assert.Equal(t, domain.AttentionNone, newModel.(UIModel).agents["bb-1"].Info.Attention)
+}
+
+func TestHandleAgentAttention_StoppedAgent(t *testing.T) {
+	m, _ := newAgentActionModel(t, domain.AgentCompleted)
+
+	newModel, cmd := m.HandleAgentAttention(agentAttentionMsg{agentID: "bb-1", state: domain.AttentionIdle})
+	assert.Nil(t, cmd)
+	assert.Equal(t, domain.AttentionNone, newModel.(UIModel).agents["bb-1"].Info.Attention)
+}
+
+func TestNotifyAttentionCmd_Bell(t *testing.T) {
+	var buf bytes.Buffer
+	prev := attentionBell
+	attentionBell = &buf
+	defer func() { attentionBell = prev }()
+
+	info := domain.AgentInfo{Name: "bb-1", Attention: domain.AttentionIdle}
+	assert.Nil(t, notifyAttentionCmd(nil, domain.AttentionNotifyBell, info)())
+	assert.Equal(t, "\a", buf.String())
+
+	buf.Reset()
+	assert.Nil(t, notifyAttentionCmd(nil, domain.AttentionNotifyNone, info)())
+	assert.Empty(t, buf.String())
+}
+
+func TestDetectAttentionCmd(t *testing.T) {
+	fake := tmux.NewFakeRunner()
+	fake.AlwaysReturn = []byte("Edit main.go?\nDo you want to proceed?\n")
+	rules := []domain.AttentionRule{{State: domain.AttentionApproval, Pattern: regexp.MustCompile(`Do you want to proceed\?`)}}
+
+	msg := detectAttentionCmd("bb-1", tmux.NewOutputCapture(fake, "@4"), rules)()
+	assert.Equal(t, agentAttentionMsg{agentID: "bb-1", state: domain.AttentionApproval}, msg)
+}
@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// configTickCmd schedules the next check of the config files. Nothing is
+// watched when no config file is in use.
+func configTickCmd(a *app.App) tea.Cmd {
+	if a == nil || a.Opts.ConfigPath == "" {
+		return nil
+	}
+	return tea.Tick(configPollInterval, func(t time.Time) tea.Msg {
+		return configCheckMsg{}
+	})
+}
+
+// checkConfigCmd reloads the global config and the layer of project when
+// any of the stamped files changed. With no stamps yet, it only stamps the
+// files of the current config.
+func checkConfigCmd(a *app.App, project string, stamps map[string]config.FileStamp) tea.Cmd {
+	return func() tea.Msg {
+		msg := configCheckedMsg{project: project, stamps: stamps}
+		if stamps != nil && !config.FilesChanged(stamps) {
+			return msg
+		}
+		msg.changed = stamps != nil
+
+		// The project config is watched even before it exists.
+		var files []string
+		if project != "" {
+			files = append(files, filepath.Join(project, config.ProjectConfigFile))
+		}
+		msg.cfg, msg.err = a.Loader.Load(a.Opts.ConfigPath)
+		if msg.err != nil {
+			// Keep watching the files of the last good config, and the
+			// config file itself, so that fixing the edit is noticed.
+			files = append(files, a.Opts.ConfigPath)
+			for path := range stamps {
+				files = append(files, path)
+			}
+			msg.stamps = config.StatFiles(files)
+			return msg
+		}
+		files = append(files, msg.cfg.Files...)
+		if project != "" {
+			msg.layer, msg.layerErr = a.ProjectLayer(project)
+			if msg.layer != nil {
+				files = append(files, msg.layer.Files...)
+			}
+		}
+		msg.stamps = config.StatFiles(files)
+		return msg
+	}
+}
+
+// handleConfigChecked rebuilds the harnesses and the general settings from
+// a reloaded config, and starts the queued launches raised limits allow. An
+// invalid config is reported and the last good one is kept, as are general
+// settings that need a restart, with a warning. Reloaded defaults apply the
+// next time a project becomes active.
+func (m UIModel) handleConfigChecked(msg configCheckedMsg) (tea.Model, tea.Cmd) {
+	next := configTickCmd(m.app)
+	if msg.project != m.configProject {
+		// The project changed during the check; its layer is already
+		// applied, so only restamp.
+		m.configStamps = nil
+		return m, next
+	}
+	m.configStamps = msg.stamps
+	if !msg.changed {
+		return m, next
+	}
+	if msg.err != nil {
+		return m, tea.Batch(next, warningCmd(fmt.Errorf("config reload failed, keeping the last good config: %w", msg.err)))
+	}
+
+	cmds := []tea.Cmd{next}
+	if msg.layerErr != nil {
+		cmds = append(cmds, warningCmd(fmt.Errorf("project config ignored: %w", msg.layerErr)))
+	}
+	m.app.RefreshProjects(msg.cfg.Workspace.Projects)
+	m.baseHarnesses = msg.cfg.Harnesses
+	m.baseDefaults = domain.Defaults{}
+	if msg.cfg.Defaults != nil {
+		m.baseDefaults = *msg.cfg.Defaults
+	}
+	if restart := m.app.SetGeneral(msg.cfg.General); len(restart) > 0 {
+		cmds = append(cmds, warningCmd(fmt.Errorf("config reloaded; restart bdb to apply %s", strings.Join(restart, ", "))))
+	}
+	m.projectLayered = msg.layer != nil
+	m = m.setHarnesses(msg.layer.ApplyHarnesses(m.baseHarnesses))
+	m, dispatchCmd := m.dispatchQueue()
+	return m, tea.Batch(append(cmds, dispatchCmd)...)
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func TestConfigReload(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	promptPath := filepath.Join(dir, "prompt.md")
+	writeFile := func(path, content string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	writeFile(configPath, "harnesses:\n  - name: claude\n    command_template: claude\n    prompt_template: \"@prompt.md\"\n")
+	writeFile(promptPath, "Work on {{.TicketID}}")
+
+	myApp := newTestApp()
+	myApp.Loader = config.NewYAMLLoader()
+	myApp.Opts.ConfigPath = configPath
+	m := NewUIModel(myApp, []domain.Harness{{Name: "claude", CommandTemplate: "claude", PromptTemplate: "Work on {{.TicketID}}"}})
+	m.selection.Harness = m.harnesses[0]
+
+	check := func() configCheckedMsg {
+		t.Helper()
+		msg, ok := checkConfigCmd(m.app, m.configProject, m.configStamps)().(configCheckedMsg)
+		require.True(t, ok)
+		newModel, _ := m.handleConfigChecked(msg)
+		m = newModel.(UIModel)
+		return msg
+	}
+
+	msg := check()
+	assert.False(t, msg.changed, "the first check only stamps the files")
+	assert.Contains(t, m.configStamps, promptPath)
+	assert.False(t, check().changed)
+
+	writeFile(promptPath, "Fix {{.TicketID}} and add a test")
+	msg = check()
+	require.True(t, msg.changed, "an edited template file triggers a reload")
+	require.NoError(t, msg.err)
+	assert.Equal(t, "Fix {{.TicketID}} and add a test", m.harnesses[0].PromptTemplate)
+	assert.Equal(t, "Fix {{.TicketID}} and add a test", m.selection.Harness.PromptTemplate)
+
+	writeFile(configPath, "harnesses:\n  - name: claude\n")
+	msg = check()
+	require.Error(t, msg.err, "an invalid edit is reported")
+	assert.Equal(t, "Fix {{.TicketID}} and add a test", m.harnesses[0].PromptTemplate, "the last good config is kept")
+	assert.False(t, check().changed, "an invalid config is reported once")
+
+	writeFile(configPath, "harnesses:\n  - name: claude\n    command_template: claude --fixed\n  - name: aider\n    command_template: aider\n")
+	msg = check()
+	require.NoError(t, msg.err)
+	require.Len(t, m.harnesses, 2)
+	assert.Equal(t, "claude --fixed", m.selection.Harness.CommandTemplate)
+	assert.Len(t, m.harnessList.Items(), 2)
+}
+
+func TestConfigReload_GeneralSettings(t *testing.T) {
+	myApp := newTestApp()
+	myApp.Opts.AgentState = domain.AgentStateDolt
+	m := NewUIModel(myApp, []domain.Harness{{Name: "claude", CommandTemplate: "claude"}})
+
+	newModel, cmd := m.handleConfigChecked(configCheckedMsg{
+		project: m.configProject,
+		changed: true,
+		cfg: &domain.Config{
+			Harnesses: m.harnesses,
+			General: &domain.GeneralConfig{
+				AttentionNotify: domain.AttentionNotifyBell,
+				AgentState:      domain.AgentStateLocal,
+			},
+		},
+	})
+	m = newModel.(UIModel)
+	assert.Equal(t, domain.AttentionNotifyBell, m.app.AttentionNotify(), "the notification applies right away")
+	assert.Equal(t, domain.AgentStateDolt, m.app.Opts.AgentState, "the agent state backend is kept")
+
+	var warnings []string
+	var collect func(tea.Cmd)
+	collect = func(cmd tea.Cmd) {
+		if cmd == nil {
+			return
+		}
+		switch msg := cmd().(type) {
+		case tea.BatchMsg:
+			for _, c := range msg {
+				collect(c)
+			}
+		case warningMsg:
+			warnings = append(warnings, msg.err.Error())
+		}
+	}
+	collect(cmd)
+	assert.Equal(t, []string{"config reloaded; restart bdb to apply general.agent_state"}, warnings)
+}
@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
-	"github.com/megatherium/blunderbust/internal/config"
 	"github.com/megatherium/blunderbust/internal/domain"
 )
 
@@ -19,7 +18,24 @@ var (
 				MarginBottom(1)
 )
 
-func confirmView(selection domain.Selection, renderer *config.Renderer, dryRun bool, workDir string, theme ThemePalette) string {
+// ConfirmConfig holds configuration for rendering the launch confirmation
+type ConfirmConfig struct {
+	Selection domain.Selection
+	Spec      *domain.LaunchSpec // nil when there is no renderer
+	SpecErr   error
+	DryRun    bool
+	WorkDir   string
+	Edits     string // status of the prompt/command edits
+	NameInput string // rendered snippet name input while naming a snippet
+	Theme     ThemePalette
+
+	Context        []domain.ContextSection // what each context provider added
+	ContextLoading bool
+}
+
+func confirmView(cfg ConfirmConfig) string {
+	selection, dryRun, workDir, theme := cfg.Selection, cfg.DryRun, cfg.WorkDir, cfg.Theme
+
 	// Arcade-style styles using theme colors
 	readyTextStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -77,13 +93,37 @@ func confirmView(selection domain.Selection, renderer *config.Renderer, dryRun b
 		s += fmt.Sprintf("WorkDir: %s\n\n", itemStyle.Render(workDir))
 	}
 
-	if renderer != nil {
-		spec, err := renderer.RenderSelection(selection, workDir)
-		if err == nil && spec != nil {
+	if cfg.ContextLoading {
+		s += themeTitleStyle.Render("Context:") + "\n"
+		s += itemStyle.Render("Gathering context...") + "\n\n"
+	} else if len(cfg.Context) > 0 {
+		s += themeTitleStyle.Render("Context:") + "\n"
+		for _, section := range cfg.Context {
+			s += itemStyle.Render(contextSectionLine(section)) + "\n"
+		}
+		s += "\n"
+	}
+
+	if spec, err := cfg.Spec, cfg.SpecErr; spec != nil || err != nil {
+		if err == nil {
 			s += themeTitleStyle.Render("Rendered Command:") + "\n"
 			s += itemStyle.Render(fmt.Sprintf("```bash\n%s\n```", spec.RenderedCommand)) + "\n\n"
+			if len(spec.PreLaunch) > 0 || len(spec.PostExit) > 0 {
+				s += themeTitleStyle.Render("Hooks:") + "\n"
+				for _, hook := range spec.PreLaunch {
+					s += itemStyle.Render("pre_launch  "+hook) + "\n"
+				}
+				for _, hook := range spec.PostExit {
+					s += itemStyle.Render("post_exit   "+hook) + "\n"
+				}
+				s += "\n"
+			}
 			if spec.RenderedPrompt != "" {
-				s += themeTitleStyle.Render("Rendered Prompt:") + "\n"
+				header := "Rendered Prompt:"
+				if spec.PromptSource != "" {
+					header = fmt.Sprintf("Rendered Prompt (from %s):", spec.PromptSource)
+				}
+				s += themeTitleStyle.Render(header) + "\n"
 				promptLines := strings.Split(spec.RenderedPrompt, "\n")
 				for _, line := range promptLines {
 					s += itemStyle.Render(line) + "\n"
@@ -102,6 +142,35 @@ func confirmView(selection domain.Selection, renderer *config.Renderer, dryRun b
 		launchButtonStyle.Render("LAUNCH"),
 	)
 	s += readyPanelStyle.Render(readyBlock) + "\n"
-	s += lipgloss.NewStyle().Faint(true).Render("[Press Enter to launch, esc to go back]")
+	if cfg.Edits != "" {
+		s += lipgloss.NewStyle().Foreground(theme.FocusIndicator).Render(cfg.Edits) + "\n"
+	}
+	if cfg.NameInput != "" {
+		s += cfg.NameInput + "\n"
+		s += lipgloss.NewStyle().Faint(true).Render("[enter save • esc cancel]")
+		return s
+	}
+	if cfg.ContextLoading {
+		s += lipgloss.NewStyle().Faint(true).Render("[Gathering context, esc to go back]") + "\n"
+	} else {
+		s += lipgloss.NewStyle().Faint(true).Render("[Press Enter to launch, esc to go back]") + "\n"
+	}
+	editKeys := "[e edit prompt • E edit command • s save prompt as snippet"
+	if n := len(selection.Harness.PromptSnippets); n > 0 {
+		editKeys += fmt.Sprintf(" • p next snippet (%d)", n)
+	}
+	s += lipgloss.NewStyle().Faint(true).Render(editKeys + "]")
 	return s
 }
+
+// contextSectionLine summarizes one context provider for the preview.
+func contextSectionLine(section domain.ContextSection) string {
+	line := fmt.Sprintf("%-14s %s", section.Provider, section.Summary)
+	if section.Truncated {
+		line += " (truncated)"
+	}
+	if section.Err != nil {
+		line = fmt.Sprintf("%-14s error: %v", section.Provider, section.Err)
+	}
+	return line
+}
@@ -18,7 +18,7 @@ func TestConfirmView_ShowsReadyPanelAndLaunchButton(t *testing.T) {
 		Agent:   "build",
 	}
 
-	s := confirmView(selection, nil, false, "/tmp/worktree", TokyoNightTheme)
+	s := confirmView(ConfirmConfig{Selection: selection, WorkDir: "/tmp/worktree", Theme: TokyoNightTheme})
 
 	assert.Contains(t, s, "Confirm Launch Spec")
 	assert.Contains(t, s, "READY?")
@@ -32,6 +32,6 @@ func TestConfirmView_ShowsDryRunBadge(t *testing.T) {
 		Harness: domain.Harness{Name: "codex"},
 	}
 
-	s := confirmView(selection, nil, true, "", TokyoNightTheme)
+	s := confirmView(ConfirmConfig{Selection: selection, DryRun: true, Theme: TokyoNightTheme})
 	assert.Contains(t, s, "[DRY RUN]")
 }
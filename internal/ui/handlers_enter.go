@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"fmt"
+
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/megatherium/blunderbust/internal/domain"
@@ -19,8 +21,12 @@ func (m UIModel) handleEnterKey() (tea.Model, tea.Cmd) {
 	case ViewStateMatrix:
 		return m.handleMatrixEnterKey()
 	case ViewStateConfirm:
+		if m.launchEdit.contextLoading {
+			// Launching now would render the prompt without its context.
+			return m, nil
+		}
 		m.state = ViewStateMatrix
-		return m, m.launchCmd()
+		return m.launchSelection()
 	}
 	return m, nil
 }
@@ -61,11 +67,17 @@ func (m UIModel) handleSidebarEnterKey() (tea.Model, tea.Cmd) {
 // handleTicketsEnterKey handles Enter key when tickets column is focused
 func (m UIModel) handleTicketsEnterKey() (tea.Model, tea.Cmd) {
 	if i, ok := m.ticketList.SelectedItem().(ticketItem); ok {
+		issueTypeChanged := i.ticket.IssueType != m.selection.Ticket.IssueType
 		m.selection.Ticket = i.ticket
+		if issueTypeChanged && m.app != nil {
+			m = m.setHarnesses(m.harnesses)
+		}
 
-		if len(m.harnesses) == 1 {
-			m.selection.Harness = m.harnesses[0]
-			m, _ = m.handleModelSkip()
+		if items := m.harnessList.Items(); len(items) == 1 {
+			if hi, ok := items[0].(harnessItem); ok && hi.unavailable == "" {
+				m.selection.Harness = hi.harness
+				m, _ = m.handleModelSkip()
+			}
 		}
 
 		if m.focus < FocusAgent {
@@ -79,6 +91,9 @@ func (m UIModel) handleTicketsEnterKey() (tea.Model, tea.Cmd) {
 // handleHarnessEnterKey handles Enter key when harness column is focused
 func (m UIModel) handleHarnessEnterKey() (tea.Model, tea.Cmd) {
 	if i, ok := m.harnessList.SelectedItem().(harnessItem); ok {
+		if i.unavailable != "" {
+			return m, warningCmd(fmt.Errorf("harness %s is unavailable: %s", i.harness.Name, i.unavailable))
+		}
 		m.selection.Harness = i.harness
 		m, _ = m.handleModelSkip()
 		m, _ = m.handleAgentSkip()
@@ -106,9 +121,25 @@ func (m UIModel) handleModelEnterKey() (tea.Model, tea.Cmd) {
 // handleAgentEnterKey handles Enter key when agent column is focused
 func (m UIModel) handleAgentEnterKey() (tea.Model, tea.Cmd) {
 	if i, ok := m.agentList.SelectedItem().(agentItem); ok {
+		if reason := m.harnessUnavailable(m.selection.Harness.Name); reason != "" {
+			return m, warningCmd(fmt.Errorf("harness %s is unavailable: %s", m.selection.Harness.Name, reason))
+		}
 		m.selection.Agent = i.name
+		m.selection.ProjectPromptTemplates = nil
+		m.selection.ProjectEnvFile = ""
+		m.selection.ProjectHooks = domain.Hooks{}
+		if m.app != nil {
+			if project, ok := m.app.ProjectConfig(m.app.ActiveProject); ok {
+				m.selection.ProjectPromptTemplates = project.PromptTemplates
+				m.selection.ProjectEnvFile = project.EnvFile
+				m.selection.ProjectHooks = project.Hooks
+			}
+		}
 		m.state = ViewStateConfirm
-		return m, nil
+		m.launchEdit = launchEdit{}
+		cmd := m.loadPromptContextCmd()
+		m.launchEdit.contextLoading = cmd != nil
+		return m, cmd
 	}
 	return m, nil
 }
@@ -2,9 +2,12 @@ package ui
 
 import (
 	"fmt"
+	"io"
+	"path/filepath"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/megatherium/blunderbust/internal/config"
 	"github.com/megatherium/blunderbust/internal/discovery"
 	"github.com/megatherium/blunderbust/internal/domain"
 )
@@ -17,12 +20,19 @@ const (
 type harnessItem struct {
 	harness  domain.Harness
 	registry *discovery.Registry
+
+	// unavailable is why the harness's when: conditions do not hold, e.g. a
+	// missing binary; empty when it can be launched.
+	unavailable string
 }
 
 func (i harnessItem) Title() string { return i.harness.Name }
 
 func (i harnessItem) Description() string {
 	modelCount := i.getModelCount()
+	if i.unavailable != "" {
+		return fmt.Sprintf("Models: %d\nUnavailable: %s", modelCount, i.unavailable)
+	}
 	return fmt.Sprintf("Models: %d\nAgents: %d", modelCount, len(i.harness.SupportedAgents))
 }
 
@@ -69,21 +79,75 @@ func (i harnessItem) getModelCount() int {
 }
 
 func newHarnessList(harnesses []domain.Harness, registry *discovery.Registry, theme ...*ThemePalette) list.Model {
+	l := list.New(harnessItems(harnesses, registry, config.WhenFacts{}), newHarnessDelegate(theme...), 0, 0)
+	l.Title = "Select a Harness"
+	l.SetShowTitle(false)
+	return l
+}
+
+// harnessItems returns the harness column's items: the harnesses whose
+// when: conditions do not hide them for facts.
+func harnessItems(harnesses []domain.Harness, registry *discovery.Registry, facts config.WhenFacts) []list.Item {
 	items := make([]list.Item, 0, len(harnesses))
 	for i := range harnesses {
+		hidden, reason := config.CheckWhen(harnesses[i], facts)
+		if hidden {
+			continue
+		}
 		items = append(items, harnessItem{
-			harness:  harnesses[i],
-			registry: registry,
+			harness:     harnesses[i],
+			registry:    registry,
+			unavailable: reason,
 		})
 	}
+	return items
+}
 
+// harnessDelegate renders unavailable harnesses dimmed.
+type harnessDelegate struct {
+	list.DefaultDelegate
+}
+
+func newHarnessDelegate(theme ...*ThemePalette) harnessDelegate {
 	delegate := newGradientDelegate(theme...)
 	// SetHeight(3) is required to prevent visual clipping of the 2-line description ("Models: X\nAgents: Y").
 	// Default list delegates assume 1 line description (height 2 total).
 	delegate.SetHeight(3)
+	return harnessDelegate{delegate}
+}
 
-	l := list.New(items, delegate, 0, 0)
-	l.Title = "Select a Harness"
-	l.SetShowTitle(false)
-	return l
+func (d harnessDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	if i, ok := item.(harnessItem); ok && i.unavailable != "" {
+		dimmed := d.Styles.DimmedTitle.GetForeground()
+		d.Styles.NormalTitle = d.Styles.DimmedTitle
+		d.Styles.NormalDesc = d.Styles.DimmedDesc
+		d.Styles.SelectedTitle = d.Styles.SelectedTitle.Foreground(dimmed)
+		d.Styles.SelectedDesc = d.Styles.SelectedDesc.Foreground(dimmed)
+	}
+	d.DefaultDelegate.Render(w, m, index, item)
+}
+
+// harnessWhenFacts returns what the harnesses' when: conditions are checked
+// against: the active project and the selected ticket's issue type.
+func (m UIModel) harnessWhenFacts() config.WhenFacts {
+	facts := config.WhenFacts{IssueType: m.selection.Ticket.IssueType}
+	if m.app == nil || m.app.ActiveProject == "" {
+		return facts
+	}
+	facts.Project = filepath.Base(m.app.ActiveProject)
+	if project, ok := m.app.ProjectConfig(m.app.ActiveProject); ok && project.Name != "" {
+		facts.Project = project.Name
+	}
+	return facts
+}
+
+// harnessUnavailable returns why the named harness cannot be launched, or
+// an empty string.
+func (m UIModel) harnessUnavailable(name string) string {
+	for _, item := range m.harnessList.Items() {
+		if i, ok := item.(harnessItem); ok && i.harness.Name == name {
+			return i.unavailable
+		}
+	}
+	return ""
 }
@@ -2,11 +2,13 @@ package ui
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/megatherium/blunderbust/internal/discovery"
 	"github.com/megatherium/blunderbust/internal/domain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHarnessItem_getModelCount(t *testing.T) {
@@ -196,3 +198,46 @@ func setupMockRegistry() *discovery.Registry {
 	})
 	return registry
 }
+
+func harnessNames(m UIModel) []string {
+	var names []string
+	for _, item := range m.harnessList.Items() {
+		names = append(names, item.(harnessItem).harness.Name)
+	}
+	return names
+}
+
+func TestHarnessColumn_WhenConditions(t *testing.T) {
+	project := filepath.Join(t.TempDir(), "api")
+	require.NoError(t, os.Mkdir(project, 0o755))
+
+	myApp := newTestApp()
+	myApp.Opts.ConfigPath = filepath.Join(t.TempDir(), "config.yaml")
+	m := NewUIModel(myApp, []domain.Harness{
+		{Name: "shell", CommandTemplate: "sh -c {{.Prompt}}", When: domain.HarnessConditions{Installed: true}},
+		{Name: "ghost", CommandTemplate: "bdb-no-such-binary", When: domain.HarnessConditions{Installed: true}},
+		{Name: "bugfixer", CommandTemplate: "sh", When: domain.HarnessConditions{IssueTypes: []string{"bug"}}},
+		{Name: "web", CommandTemplate: "sh", When: domain.HarnessConditions{Projects: []string{"web-*"}}},
+	})
+	assert.Equal(t, []string{"shell", "ghost", "bugfixer", "web"}, harnessNames(m), "nothing is hidden before the project and ticket are known")
+
+	myApp.ActiveProject = project
+	m, _ = m.applyProjectConfig()
+	assert.Equal(t, []string{"shell", "ghost", "bugfixer"}, harnessNames(m), "harnesses for other projects are hidden")
+
+	m.selection.Ticket = domain.Ticket{ID: "bb-1", IssueType: "feature"}
+	m = m.setHarnesses(m.harnesses)
+	assert.Equal(t, []string{"shell", "ghost"}, harnessNames(m), "harnesses for other issue types are hidden")
+
+	ghost := m.harnessList.Items()[1].(harnessItem)
+	assert.Equal(t, "bdb-no-such-binary or ghost not installed", ghost.unavailable)
+	assert.Contains(t, ghost.Description(), "Unavailable: bdb-no-such-binary or ghost not installed")
+	assert.Empty(t, m.harnessList.Items()[0].(harnessItem).unavailable)
+
+	m.harnessList.Select(1)
+	_, cmd := m.handleHarnessEnterKey()
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(warningMsg)
+	require.True(t, ok, "an unavailable harness cannot be selected")
+	assert.Contains(t, msg.err.Error(), "harness ghost is unavailable")
+}
@@ -1,10 +1,17 @@
 package ui
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/megatherium/blunderbust/internal/data"
 	"github.com/megatherium/blunderbust/internal/data/dolt"
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/exec/tmux"
 )
 
 func (m UIModel) handleModalKeyMsg() (tea.Model, tea.Cmd, bool) {
@@ -49,15 +56,54 @@ func (m UIModel) handleBackKeyMsg() (tea.Model, tea.Cmd, bool) {
 	return m, nil, false
 }
 
+// handleInfoKeyMsg opens the detail view for the selected ticket. In zoom
+// mode it docks or undocks the detail pane instead.
 func (m UIModel) handleInfoKeyMsg() (tea.Model, tea.Cmd, bool) {
-	if m.state == ViewStateMatrix && m.focus == FocusTickets {
-		if i, ok := m.ticketList.SelectedItem().(ticketItem); ok {
-			m.showModal = true
-			m.modalContent = "Loading bd show..."
-			return m, loadModalCmd(i.ticket.ID), true
+	if m.state != ViewStateMatrix || m.focus != FocusTickets {
+		return m, nil, false
+	}
+	if m.ticketZoomEnabled {
+		m.detailDocked = !m.detailDocked
+		m.layout = m.computeLayout(m.layout.TermWidth, m.layout.TermHeight)
+		m.updateSizes()
+		m.dirtyTicket = true
+		if !m.detailDocked {
+			m.detail = ticketDetailPane{}
+			return m, nil, true
 		}
+		model, cmd := m.syncDockedDetail()
+		return model, cmd, true
 	}
-	return m, nil, false
+
+	i, ok := m.ticketList.SelectedItem().(ticketItem)
+	if !ok {
+		return m, nil, false
+	}
+	m.state = ViewStateTicketDetail
+	m.detail = newTicketDetailPane(i.ticket.ID)
+	return m, m.loadTicketDetail(i.ticket), true
+}
+
+// syncDockedDetail points the docked detail pane at the selected ticket,
+// loading it if the selection changed.
+func (m UIModel) syncDockedDetail() (UIModel, tea.Cmd) {
+	if !m.detailDocked || m.layout.DWidth == 0 {
+		return m, nil
+	}
+	i, ok := m.ticketList.SelectedItem().(ticketItem)
+	if !ok || i.ticket.ID == m.detail.ticketID {
+		return m, nil
+	}
+	m.detail = newTicketDetailPane(i.ticket.ID)
+	return m, m.loadTicketDetail(i.ticket)
+}
+
+func (m UIModel) loadTicketDetail(ticket domain.Ticket) tea.Cmd {
+	var store data.TicketStore
+	if project := m.app.Project(); project != nil {
+		store = project.Store()
+	}
+	return loadTicketDetailCmd(store, ticket)
 }
 
 func (m UIModel) handleToggleSidebarKeyMsg() (tea.Model, tea.Cmd, bool) {
@@ -70,7 +116,7 @@ func (m UIModel) handleToggleThemeKeyMsg() (tea.Model, tea.Cmd, bool) {
 	m.animState.nextTheme()
 	m.currentTheme = m.animState.getCurrentTheme()
 	m.ticketList.SetDelegate(newGradientDelegate(m.currentTheme))
-	m.harnessList.SetDelegate(newGradientDelegate(m.currentTheme))
+	m.harnessList.SetDelegate(newHarnessDelegate(m.currentTheme))
 	m.modelList.SetDelegate(newGradientDelegate(m.currentTheme))
 	m.agentList.SetDelegate(newGradientDelegate(m.currentTheme))
 	m.dirtyTicket = true
@@ -94,7 +140,7 @@ func (m UIModel) handleZoomKeyMsg() (tea.Model, tea.Cmd, bool) {
 	}
 
 	// Recalculate layout with new zoom state
-	m.layout = Compute(m.layout.TermWidth, m.layout.TermHeight, m.showSidebar, m.ticketZoomEnabled)
+	m.layout = m.computeLayout(m.layout.TermWidth, m.layout.TermHeight)
 	m.updateSizes()
 
 	// Mark all columns dirty since widths changed
@@ -170,11 +216,279 @@ func (m UIModel) handleErrorStateKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, boo
 	return m, nil, true
 }
 
+// handleHistoryKeyMsg handles keys while the agent history view is open.
+// All keys are consumed so the matrix underneath does not react.
+func (m UIModel) handleHistoryKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if m.state != ViewStateHistory {
+		return m, nil, false
+	}
+	switch {
+	case msg.String() == "ctrl+c":
+		return m, tea.Quit, true
+	case key.Matches(msg, m.keys.Back), key.Matches(msg, m.keys.Quit), key.Matches(msg, m.keys.History):
+		m.state = ViewStateMatrix
+	case key.Matches(msg, m.keys.Up):
+		if m.historyOffset > 0 {
+			m.historyOffset--
+		}
+	case key.Matches(msg, m.keys.Down):
+		if m.historyOffset < len(m.history)-1 {
+			m.historyOffset++
+		}
+	case key.Matches(msg, m.keys.Refresh):
+		return m, loadAgentHistoryCmd(m.app), true
+	}
+	return m, nil, true
+}
+
+// handleTicketDetailKeyMsg handles keys while the full-screen ticket detail
+// view is open. All keys are consumed so the matrix underneath does not
+// react. In the matrix, ctrl+d and ctrl+u scroll the docked pane.
+func (m UIModel) handleTicketDetailKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	height := m.detailPaneHeight()
+	if m.state == ViewStateMatrix && m.layout.DWidth > 0 && !isFocusedListFiltering(m) {
+		switch msg.String() {
+		case "ctrl+d":
+			m.detail.scroll(height/2, height)
+			return m, nil, true
+		case "ctrl+u":
+			m.detail.scroll(-height/2, height)
+			return m, nil, true
+		}
+	}
+	if m.state != ViewStateTicketDetail {
+		return m, nil, false
+	}
+
+	if m.detail.searching {
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return m, tea.Quit, true
+		case tea.KeyEsc:
+			m.detail.searching = false
+		case tea.KeyEnter:
+			m.detail.searching = false
+			m.detail.setQuery(strings.TrimSpace(m.detail.search.Value()))
+			m.detail.firstMatchFromOffset(height)
+		default:
+			var cmd tea.Cmd
+			m.detail.search, cmd = m.detail.search.Update(msg)
+			return m, cmd, true
+		}
+		return m, nil, true
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit, true
+	case "esc":
+		if m.detail.query != "" {
+			m.detail.setQuery("")
+			return m, nil, true
+		}
+		m.state = ViewStateMatrix
+	case "q", "i":
+		m.state = ViewStateMatrix
+	case "up", "k":
+		m.detail.scroll(-1, height)
+	case "down", "j":
+		m.detail.scroll(1, height)
+	case "ctrl+u", "pgup":
+		m.detail.scroll(-height/2, height)
+	case "ctrl+d", "pgdown", " ":
+		m.detail.scroll(height/2, height)
+	case "g", "home":
+		m.detail.scroll(-len(m.detail.lines), height)
+	case "G", "end":
+		m.detail.scroll(len(m.detail.lines), height)
+	case "/":
+		m.detail.searching = true
+		m.detail.search = newTicketSearchInput(m.detail.query)
+	case "n":
+		m.detail.jumpToMatch(1, height)
+	case "N":
+		m.detail.jumpToMatch(-1, height)
+	case "r":
+		if i, ok := m.ticketList.SelectedItem().(ticketItem); ok && i.ticket.ID == m.detail.ticketID {
+			m.detail.setDetail(nil, nil)
+			return m, m.loadTicketDetail(i.ticket), true
+		}
+	}
+	return m, nil, true
+}
+
+// handleTicketFormKeyMsg handles keys while the ticket form is open. All
+// keys are consumed; text goes to the focused input.
+func (m UIModel) handleTicketFormKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if m.state != ViewStateTicketForm {
+		return m, nil, false
+	}
+	if m.ticketForm.saving {
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit, true
+		}
+		return m, nil, true
+	}
+
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit, true
+	case "esc":
+		m.state = ViewStateMatrix
+		m.ticketForm = ticketForm{}
+		return m, nil, true
+	case "tab", "down":
+		m.ticketForm.setFocus(m.ticketForm.focus + 1)
+		return m, nil, true
+	case "shift+tab", "up":
+		m.ticketForm.setFocus(m.ticketForm.focus - 1)
+		return m, nil, true
+	case "enter":
+		if !m.ticketForm.lastField() {
+			m.ticketForm.setFocus(m.ticketForm.focus + 1)
+			return m, nil, true
+		}
+		return m.submitTicketForm()
+	case "ctrl+s":
+		return m.submitTicketForm()
+	}
+	return m, m.ticketForm.update(msg), true
+}
+
+// submitTicketForm writes the form's ticket. The form stays open until the
+// write succeeds, so a failure can be corrected.
+func (m UIModel) submitTicketForm() (tea.Model, tea.Cmd, bool) {
+	writer, err := m.ticketWriter()
+	if err != nil {
+		m.ticketForm.err = err
+		return m, nil, true
+	}
+
+	if m.ticketForm.ticket == nil {
+		t, err := m.ticketForm.newTicket()
+		if err != nil {
+			m.ticketForm.err = err
+			return m, nil, true
+		}
+		m.ticketForm.saving = true
+		return m, createTicketCmd(writer, t), true
+	}
+
+	u := m.ticketForm.ticketUpdate()
+	if u.IsEmpty() {
+		m.state = ViewStateMatrix
+		m.ticketForm = ticketForm{}
+		return m, nil, true
+	}
+	m.ticketForm.saving = true
+	return m, updateTicketCmd(writer, m.ticketForm.ticket.ID, u), true
+}
+
+// handleTicketEditKeyMsg handles the ticket column's editing keys: 'n' opens
+// the form for a new ticket, 'e' the form for the selected one, and '+' and
+// '-' change the selected ticket's priority in place.
+func (m UIModel) handleTicketEditKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if m.state != ViewStateMatrix || m.focus != FocusTickets || isFocusedListFiltering(m) {
+		return m, nil, false
+	}
+
+	if key.Matches(msg, m.keys.NewTicket) {
+		m.state = ViewStateTicketForm
+		m.ticketForm = newCreateTicketForm("")
+		return m, nil, true
+	}
+
+	i, ok := m.ticketList.SelectedItem().(ticketItem)
+	if !ok {
+		return m, nil, false
+	}
+	switch {
+	case key.Matches(msg, m.keys.EditTicket):
+		m.state = ViewStateTicketForm
+		m.ticketForm = newEditTicketForm(i.ticket)
+		return m, nil, true
+	case key.Matches(msg, m.keys.PriorityUp):
+		return m.changeTicketPriority(i.ticket, -1)
+	case key.Matches(msg, m.keys.PriorityDown):
+		return m.changeTicketPriority(i.ticket, 1)
+	}
+	return m, nil, false
+}
+
+// changeTicketPriority moves ticket's priority by delta; P0 is the highest.
+func (m UIModel) changeTicketPriority(ticket domain.Ticket, delta int) (tea.Model, tea.Cmd, bool) {
+	p := ticket.Priority + delta
+	if p < domain.MinTicketPriority || p > domain.MaxTicketPriority {
+		return m, nil, true
+	}
+	writer, err := m.ticketWriter()
+	if err != nil {
+		return m, warningCmd(err), true
+	}
+	return m, updateTicketCmd(writer, ticket.ID, data.TicketUpdate{Priority: &p}), true
+}
+
+// ticketWriter returns the active project's store if it can write tickets.
+func (m UIModel) ticketWriter() (data.TicketWriter, error) {
+	project := m.app.Project()
+	if project == nil || project.Store() == nil {
+		return nil, fmt.Errorf("no project selected")
+	}
+	writer, ok := project.Store().(data.TicketWriter)
+	if !ok {
+		return nil, fmt.Errorf("this project's ticket store is read-only")
+	}
+	return writer, nil
+}
+
+// handleAgentPromptKeyMsg handles keys while the follow-up prompt input is
+// open. Enter sends the text to the agent's window, Esc cancels.
+func (m UIModel) handleAgentPromptKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if m.state != ViewStateAgentPrompt {
+		return m, nil, false
+	}
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		return m, tea.Quit, true
+	case tea.KeyEsc:
+		return m.closeAgentPrompt(), nil, true
+	case tea.KeyEnter:
+		text := strings.TrimSpace(m.agentPrompt.Value())
+		agent, ok := m.agents[m.promptAgentID]
+		m = m.closeAgentPrompt()
+		if text == "" || !ok {
+			return m, nil, true
+		}
+		target := agent.Info.TmuxTarget()
+		return m, windowActionCmd(m.runner(), func(ctx context.Context, c *tmux.WindowController) error {
+			return c.SendText(ctx, target, text)
+		}), true
+	}
+
+	var cmd tea.Cmd
+	m.agentPrompt, cmd = m.agentPrompt.Update(msg)
+	return m, cmd, true
+}
+
 func (m UIModel) handleGlobalKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 	if key.Matches(msg, m.keys.Quit) {
 		return m.handleQuitKeyMsg()
 	}
 
+	if key.Matches(msg, m.keys.History) && m.state == ViewStateMatrix {
+		m.state = ViewStateHistory
+		m.history = nil
+		m.historyErr = nil
+		m.historyOffset = 0
+		return m, loadAgentHistoryCmd(m.app), true
+	}
+
+	if key.Matches(msg, m.keys.Queue) && m.state == ViewStateMatrix {
+		m.state = ViewStateQueue
+		m.clampQueueCursor()
+		return m, nil, true
+	}
+
 	if key.Matches(msg, m.keys.Refresh) {
 		if model, cmd, handled := m.handleRefreshKeyMsg(); handled {
 			return model, cmd, true
@@ -13,7 +13,13 @@ type KeyMap struct {
 	Zoom          key.Binding
 	Back          key.Binding
 	Refresh       key.Binding
+	History       key.Binding
+	Queue         key.Binding
 	Quit          key.Binding
+	NewTicket     key.Binding
+	EditTicket    key.Binding
+	PriorityUp    key.Binding
+	PriorityDown  key.Binding
 }
 
 // ShortHelp returns keybindings to be shown in the mini help view.
@@ -25,7 +31,8 @@ func (k KeyMap) ShortHelp() []key.Binding {
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Enter, k.Info, k.ToggleSidebar, k.ToggleTheme, k.Zoom},
-		{k.Back, k.Refresh, k.Quit},
+		{k.Back, k.Refresh, k.History, k.Queue, k.Quit},
+		{k.NewTicket, k.EditTicket, k.PriorityUp, k.PriorityDown},
 	}
 }
 
@@ -44,7 +51,7 @@ var keys = KeyMap{
 	),
 	Info: key.NewBinding(
 		key.WithKeys("i"),
-		key.WithHelp("i", "info (bd show)"),
+		key.WithHelp("i", "ticket detail"),
 	),
 	ToggleSidebar: key.NewBinding(
 		key.WithKeys("p"),
@@ -66,10 +73,34 @@ var keys = KeyMap{
 		key.WithKeys("r"),
 		key.WithHelp("r", "refresh"),
 	),
+	History: key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "agent history"),
+	),
+	Queue: key.NewBinding(
+		key.WithKeys("Q"),
+		key.WithHelp("Q", "launch queue"),
+	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "ctrl+c"),
 		key.WithHelp("q", "quit"),
 	),
+	NewTicket: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "new ticket"),
+	),
+	EditTicket: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "edit ticket"),
+	),
+	PriorityUp: key.NewBinding(
+		key.WithKeys("+"),
+		key.WithHelp("+", "raise priority"),
+	),
+	PriorityDown: key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "lower priority"),
+	),
 }
 
 // DefaultKeyMap returns the default keybindings for the UI.
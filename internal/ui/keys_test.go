@@ -15,11 +15,11 @@ func TestKeyMapShortHelp(t *testing.T) {
 
 func TestKeyMapFullHelp(t *testing.T) {
 	help := keys.FullHelp()
-	if len(help) != 2 {
-		t.Errorf("FullHelp() returned %d rows, want 2", len(help))
+	if len(help) != 3 {
+		t.Fatalf("FullHelp() returned %d rows, want 3", len(help))
 	}
-	if len(help[0]) != 7 || len(help[1]) != 3 {
-		t.Errorf("FullHelp() rows have wrong length: got %d, %d, want 7, 3", len(help[0]), len(help[1]))
+	if len(help[0]) != 7 || len(help[1]) != 5 || len(help[2]) != 4 {
+		t.Errorf("FullHelp() rows have wrong length: got %d, %d, %d, want 7, 5, 4", len(help[0]), len(help[1]), len(help[2]))
 	}
 }
 
@@ -33,7 +33,13 @@ func TestKeyMapBindingsExist(t *testing.T) {
 		keys.Zoom,
 		keys.Back,
 		keys.Refresh,
+		keys.History,
+		keys.Queue,
 		keys.Quit,
+		keys.NewTicket,
+		keys.EditTicket,
+		keys.PriorityUp,
+		keys.PriorityDown,
 	}
 
 	for i, b := range bindings {
@@ -0,0 +1,349 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// launchEdit holds the changes made to the launch spec on the confirm
+// screen. It is reset whenever the confirm screen is entered.
+type launchEdit struct {
+	prompt  *string // prompt edited in $EDITOR, used verbatim
+	command *string // command edited in $EDITOR, used verbatim
+	snippet int     // 1-based index of the harness snippet in use; 0 for prompt_template
+
+	naming      bool // the snippet name input has focus
+	snippetName textinput.Model
+	saved       string // file the last snippet was saved to
+	savedFrom   string // what the last snippet was saved from
+
+	// Prompt context gathered by the harness's context providers.
+	context        *domain.PromptContext
+	contextLoading bool
+}
+
+// spec renders the launch spec for sel with the edits applied. An edited or
+// snippet prompt replaces prompt_template, and the command is re-rendered
+// with it as {{.Prompt}} unless the command itself was edited.
+func (e launchEdit) spec(r *config.Renderer, sel domain.Selection, workDir string) (*domain.LaunchSpec, error) {
+	ctx := config.BuildTemplateContext(sel, workDir)
+	if e.context != nil {
+		ctx.PromptContext = *e.context
+	}
+
+	var spec *domain.LaunchSpec
+	var err error
+	switch {
+	case e.prompt != nil:
+		spec, err = r.RenderSelectionWithPrompt(sel, ctx, *e.prompt)
+	case e.snippet > 0 && e.snippet <= len(sel.Harness.PromptSnippets):
+		var prompt string
+		prompt, err = r.RenderSnippet(sel.Harness, sel.Harness.PromptSnippets[e.snippet-1], ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render prompt: %w", err)
+		}
+		spec, err = r.RenderSelectionWithPrompt(sel, ctx, prompt)
+	default:
+		spec, err = r.RenderSelectionContext(sel, ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if e.command != nil {
+		spec.RenderedCommand = *e.command
+	}
+	return spec, nil
+}
+
+// status describes the edits for the confirm screen.
+func (e launchEdit) status(harness domain.Harness) string {
+	var parts []string
+	switch {
+	case e.prompt != nil:
+		parts = append(parts, "prompt edited")
+	case e.snippet > 0 && e.snippet <= len(harness.PromptSnippets):
+		parts = append(parts, fmt.Sprintf("snippet %q (%d/%d)", harness.PromptSnippets[e.snippet-1].Name, e.snippet, len(harness.PromptSnippets)))
+	}
+	if e.command != nil {
+		parts = append(parts, "command edited")
+	}
+	if e.saved != "" {
+		parts = append(parts, fmt.Sprintf("snippet saved from %s to %s", e.savedFrom, e.saved))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// snippetSource returns the template a new snippet is saved from and what
+// it is: the active snippet's or prompt template's source, so the snippet
+// renders for other tickets too, or the edited prompt with its template
+// delimiters escaped, since it was written for this ticket.
+func (e launchEdit) snippetSource(sel domain.Selection) (string, string) {
+	switch {
+	case e.prompt != nil:
+		return escapeTemplate(*e.prompt), "edited prompt"
+	case e.snippet > 0 && e.snippet <= len(sel.Harness.PromptSnippets):
+		snippet := sel.Harness.PromptSnippets[e.snippet-1]
+		return snippet.Text, fmt.Sprintf("snippet %q", snippet.Name)
+	}
+	source, tmpl := config.SelectPromptTemplate(sel.Harness, sel.ProjectPromptTemplates, sel.Ticket.IssueType)
+	if source == "" {
+		source = "empty prompt_template"
+	}
+	return tmpl, source
+}
+
+// escapeTemplate makes text render as itself as a Go template.
+func escapeTemplate(text string) string {
+	return strings.ReplaceAll(text, "{{", `{{"{{"}}`)
+}
+
+func newSnippetNameInput() textinput.Model {
+	ti := textinput.New()
+	ti.Placeholder = "snippet name"
+	ti.Prompt = "Save prompt as: "
+	ti.CharLimit = 64
+	ti.Cursor.SetMode(cursor.CursorStatic)
+	ti.Focus()
+	return ti
+}
+
+// launchWorkDir is the directory the agent is launched in: the selected
+// worktree, else the repository root.
+func (m UIModel) launchWorkDir() string {
+	if m.selectedWorktree != "" {
+		return m.selectedWorktree
+	}
+	return app.ExtractRepoRoot(m.app.Opts.BeadsDir)
+}
+
+// launchSpec renders the spec the confirm screen shows and launches.
+func (m UIModel) launchSpec() (*domain.LaunchSpec, error) {
+	return m.launchEdit.spec(m.app.Renderer, m.selection, m.launchWorkDir())
+}
+
+// loadPromptContextCmd gathers the prompt context of the selected harness's
+// context providers. It returns nil when the harness enables none.
+func (m UIModel) loadPromptContextCmd() tea.Cmd {
+	providers := m.selection.Harness.Context
+	if len(providers) == 0 {
+		return nil
+	}
+	var store data.TicketStore
+	if project := m.app.Project(); project != nil {
+		store = project.Store()
+	}
+	ticketID, harness, workDir := m.selection.Ticket.ID, m.selection.Harness.Name, m.launchWorkDir()
+	timeout := commandTimeout(m.app)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		pc := data.NewContextGatherer(nil).Gather(ctx, store, ticketID, workDir, providers)
+		return promptContextLoadedMsg{ticketID: ticketID, harness: harness, context: pc}
+	}
+}
+
+// handlePromptContextLoaded stores gathered prompt context, unless the
+// confirm screen has since been left or opened for another selection.
+func (m UIModel) handlePromptContextLoaded(msg promptContextLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.state != ViewStateConfirm || !m.launchEdit.contextLoading ||
+		msg.ticketID != m.selection.Ticket.ID || msg.harness != m.selection.Harness.Name {
+		return m, nil
+	}
+	m.launchEdit.contextLoading = false
+	m.launchEdit.context = &msg.context
+	return m, nil
+}
+
+// editorCommand returns the command that opens path in the user's editor:
+// $VISUAL, else $EDITOR, else vi. The variable may include arguments, as in
+// "code --wait".
+func editorCommand(path string) *osexec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if strings.TrimSpace(editor) == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	args := strings.Fields(editor)
+	if len(args) == 0 {
+		args = []string{"vi"}
+	}
+	return osexec.Command(args[0], append(args[1:], path)...)
+}
+
+// editLaunchTextCmd suspends the TUI and opens text in the user's editor.
+// The edited text comes back as a launchTextEditedMsg.
+func editLaunchTextCmd(text string, command bool) tea.Cmd {
+	pattern := "bdb-prompt-*.md"
+	if command {
+		pattern = "bdb-command-*.sh"
+	}
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return warningCmd(fmt.Errorf("failed to create temp file for editing: %w", err))
+	}
+	path := f.Name()
+	_, err = f.WriteString(text)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return warningCmd(fmt.Errorf("failed to write temp file for editing: %w", err))
+	}
+
+	return tea.ExecProcess(editorCommand(path), func(err error) tea.Msg {
+		defer func() { _ = os.Remove(path) }()
+		if err != nil {
+			return launchTextEditedMsg{command: command, err: fmt.Errorf("editor failed: %w", err)}
+		}
+		edited, err := os.ReadFile(path)
+		if err != nil {
+			return launchTextEditedMsg{command: command, err: fmt.Errorf("failed to read edited text: %w", err)}
+		}
+		return launchTextEditedMsg{command: command, text: string(edited)}
+	})
+}
+
+// saveSnippetCmd saves a prompt snippet for harness next to the config file.
+// from describes what the snippet was saved from.
+func saveSnippetCmd(configPath, harness string, snippet domain.PromptSnippet, from string) tea.Cmd {
+	return func() tea.Msg {
+		if configPath == "" {
+			return snippetSavedMsg{harness: harness, snippet: snippet, err: fmt.Errorf("no config file to save snippets next to")}
+		}
+		path, err := config.SaveSnippet(filepath.Dir(configPath), harness, snippet)
+		return snippetSavedMsg{harness: harness, snippet: snippet, from: from, path: path, err: err}
+	}
+}
+
+// handleConfirmKeyMsg handles the confirm screen's editing keys: 'e' edits
+// the prompt and 'E' the command in $EDITOR, 'p' cycles through the harness's
+// snippets, and 's' saves the prompt's template as a new snippet.
+func (m UIModel) handleConfirmKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if m.state != ViewStateConfirm {
+		return m, nil, false
+	}
+
+	if m.launchEdit.naming {
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return m, tea.Quit, true
+		case tea.KeyEsc:
+			m.launchEdit.naming = false
+		case tea.KeyEnter:
+			name := strings.TrimSpace(m.launchEdit.snippetName.Value())
+			if name == "" {
+				return m, nil, true
+			}
+			m.launchEdit.naming = false
+			text, from := m.launchEdit.snippetSource(m.selection)
+			snippet := domain.PromptSnippet{Name: name, Text: text}
+			return m, saveSnippetCmd(m.app.Opts.ConfigPath, m.selection.Harness.Name, snippet, from), true
+		default:
+			var cmd tea.Cmd
+			m.launchEdit.snippetName, cmd = m.launchEdit.snippetName.Update(msg)
+			return m, cmd, true
+		}
+		return m, nil, true
+	}
+
+	switch msg.String() {
+	case "e", "E":
+		command := msg.String() == "E"
+		spec, err := m.launchSpec()
+		if err != nil {
+			return m, warningCmd(err), true
+		}
+		text := spec.RenderedPrompt
+		if command {
+			text = spec.RenderedCommand
+		}
+		return m, editLaunchTextCmd(text, command), true
+	case "p":
+		if n := len(m.selection.Harness.PromptSnippets); n > 0 {
+			m.launchEdit.snippet = (m.launchEdit.snippet + 1) % (n + 1)
+			m.launchEdit.prompt = nil
+			m.launchEdit.command = nil
+		}
+		return m, nil, true
+	case "s":
+		m.launchEdit.naming = true
+		m.launchEdit.snippetName = newSnippetNameInput()
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// handleLaunchTextEdited applies text edited in $EDITOR. A new prompt drops
+// an edited command, which would still contain the old prompt.
+func (m UIModel) handleLaunchTextEdited(msg launchTextEditedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.handleWarningMsg(warningMsg{msg.err})
+	}
+	if msg.command {
+		command := strings.TrimSpace(msg.text)
+		if command == "" {
+			return m.handleWarningMsg(warningMsg{fmt.Errorf("command is empty; edit discarded")})
+		}
+		m.launchEdit.command = &command
+		return m, nil
+	}
+
+	if spec, err := m.launchSpec(); err == nil && strings.TrimSpace(spec.RenderedPrompt) == strings.TrimSpace(msg.text) {
+		return m, nil
+	}
+	prompt := msg.text
+	m.launchEdit.prompt = &prompt
+	m.launchEdit.command = nil
+	return m, nil
+}
+
+// handleSnippetSaved adds a saved snippet to its harness, so it can be picked
+// with 'p' without reloading the config.
+func (m UIModel) handleSnippetSaved(msg snippetSavedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.handleWarningMsg(warningMsg{fmt.Errorf("failed to save snippet: %w", msg.err)})
+	}
+
+	add := func(h *domain.Harness) {
+		if h.Name != msg.harness {
+			return
+		}
+		for i, s := range h.PromptSnippets {
+			if s.Name == msg.snippet.Name {
+				h.PromptSnippets[i] = msg.snippet
+				return
+			}
+		}
+		h.PromptSnippets = append(h.PromptSnippets, msg.snippet)
+	}
+	add(&m.selection.Harness)
+	for i := range m.harnesses {
+		add(&m.harnesses[i])
+	}
+	for i := range m.baseHarnesses {
+		add(&m.baseHarnesses[i])
+	}
+	for i, item := range m.harnessList.Items() {
+		if hi, ok := item.(harnessItem); ok && hi.harness.Name == msg.harness {
+			add(&hi.harness)
+			m.harnessList.SetItem(i, hi)
+		}
+	}
+	m.dirtyHarness = true
+	m.launchEdit.saved = msg.path
+	m.launchEdit.savedFrom = msg.from
+	return m, nil
+}
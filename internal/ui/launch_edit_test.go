@@ -0,0 +1,237 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/data/fake"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func newConfirmTestModel(t *testing.T) UIModel {
+	t.Helper()
+	myApp := newTestApp()
+	myApp.Opts.ConfigPath = filepath.Join(t.TempDir(), "config.yaml")
+	m := NewUIModel(myApp, nil)
+	m.state = ViewStateConfirm
+	m.selectedWorktree = "/work"
+	m.selection = domain.Selection{
+		Ticket: domain.Ticket{ID: "bb-1", Title: "Fix it"},
+		Harness: domain.Harness{
+			Name:            "claude",
+			CommandTemplate: `claude -p "{{.Prompt}}"`,
+			PromptTemplate:  "Work on {{.TicketID}}",
+			PromptSnippets:  []domain.PromptSnippet{{Name: "review", Text: "Review {{.TicketID}}"}},
+		},
+	}
+	return m
+}
+
+func TestLaunchEdit_EditedPromptRerendersCommand(t *testing.T) {
+	m := newConfirmTestModel(t)
+
+	newModel, _ := m.Update(launchTextEditedMsg{text: "Only write tests"})
+	m = newModel.(UIModel)
+	spec, err := m.launchSpec()
+	require.NoError(t, err)
+	assert.Equal(t, "Only write tests", spec.RenderedPrompt)
+	assert.Equal(t, `claude -p "Only write tests"`, spec.RenderedCommand)
+	assert.Equal(t, "/work", spec.WorkDir)
+
+	newModel, _ = m.Update(launchTextEditedMsg{command: true, text: "claude --resume\n"})
+	m = newModel.(UIModel)
+	spec, err = m.launchSpec()
+	require.NoError(t, err)
+	assert.Equal(t, "claude --resume", spec.RenderedCommand, "an edited command is used verbatim")
+	assert.Contains(t, ansi.Strip(m.View()), "prompt edited, command edited")
+
+	newModel, _ = m.Update(launchTextEditedMsg{text: "Something else"})
+	m = newModel.(UIModel)
+	spec, _ = m.launchSpec()
+	assert.Equal(t, `claude -p "Something else"`, spec.RenderedCommand, "a new prompt drops the stale command edit")
+}
+
+func TestLaunchEdit_UnchangedPromptIsIgnored(t *testing.T) {
+	m := newConfirmTestModel(t)
+	newModel, _ := m.Update(launchTextEditedMsg{text: "Work on bb-1\n"})
+	assert.Nil(t, newModel.(UIModel).launchEdit.prompt)
+}
+
+func TestLaunchEdit_CycleSnippets(t *testing.T) {
+	m := newConfirmTestModel(t)
+
+	m, _ = pressKeys(t, m, runes("p"))
+	spec, err := m.launchSpec()
+	require.NoError(t, err)
+	assert.Equal(t, "Review bb-1", spec.RenderedPrompt, "snippets are rendered as templates")
+	assert.Contains(t, ansi.Strip(m.View()), `snippet "review" (1/1)`)
+
+	m, _ = pressKeys(t, m, runes("p"))
+	spec, _ = m.launchSpec()
+	assert.Equal(t, "Work on bb-1", spec.RenderedPrompt, "cycling past the last snippet returns to prompt_template")
+}
+
+func TestLaunchEdit_SaveSnippet(t *testing.T) {
+	m := newConfirmTestModel(t)
+	m.harnesses = []domain.Harness{m.selection.Harness}
+
+	m, _ = pressKeys(t, m, runes("s"), runes("tests"))
+	assert.Contains(t, ansi.Strip(m.View()), "Save prompt as: tests")
+	m, cmd := pressKeys(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, ViewStateConfirm, m.state, "enter should save the snippet, not launch")
+
+	newModel, _ := m.Update(cmd())
+	m = newModel.(UIModel)
+	path := filepath.Join(filepath.Dir(m.app.Opts.ConfigPath), "snippets", "claude", "tests.md")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Work on {{.TicketID}}", string(content), "the template is saved, not this ticket's prompt")
+	assert.Len(t, m.selection.Harness.PromptSnippets, 2)
+	assert.Len(t, m.harnesses[0].PromptSnippets, 2)
+	assert.Contains(t, ansi.Strip(m.View()), "snippet saved from prompt_template to")
+}
+
+func TestLaunchEdit_SaveEditedPromptSnippet(t *testing.T) {
+	m := newConfirmTestModel(t)
+	newModel, _ := m.Update(launchTextEditedMsg{text: "Keep {{.TicketID}} literal"})
+	m = newModel.(UIModel)
+
+	m, cmd := pressKeys(t, m, runes("s"), runes("edited"), tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	newModel, _ = m.Update(cmd())
+	m = newModel.(UIModel)
+	assert.Contains(t, ansi.Strip(m.View()), "snippet saved from edited prompt to")
+
+	// The edited text renders as itself, braces included.
+	m.launchEdit.prompt = nil
+	m.launchEdit.snippet = 2
+	spec, err := m.launchSpec()
+	require.NoError(t, err)
+	assert.Equal(t, "Keep {{.TicketID}} literal", spec.RenderedPrompt)
+}
+
+func TestLaunchEdit_EnteringConfirmResetsEdits(t *testing.T) {
+	m := newConfirmTestModel(t)
+	prompt := "stale"
+	m.launchEdit.prompt = &prompt
+	m.state = ViewStateMatrix
+	m.focus = FocusAgent
+	m.agentList.SetItems(nil)
+	m.agentList.InsertItem(0, agentItem{name: "coder"})
+
+	newModel, _ := m.handleAgentEnterKey()
+	m = newModel.(UIModel)
+	assert.Equal(t, ViewStateConfirm, m.state)
+	assert.Nil(t, m.launchEdit.prompt)
+}
+
+func TestLaunchEdit_ProjectPromptTemplates(t *testing.T) {
+	m := newConfirmTestModel(t)
+	m.app.Renderer = config.NewRenderer()
+	m.app.AddProject(domain.Project{Dir: "/proj", Name: "proj", PromptTemplates: domain.PromptTemplates{"bug": "Reproduce {{.TicketID}}"}})
+	m.app.ActiveProject = "/proj"
+	m.selection.Ticket.IssueType = "bug"
+	m.state = ViewStateMatrix
+	m.focus = FocusAgent
+	m.agentList.SetItems(nil)
+	m.agentList.InsertItem(0, agentItem{name: "coder"})
+
+	newModel, _ := m.handleAgentEnterKey()
+	m = newModel.(UIModel)
+	spec, err := m.launchSpec()
+	require.NoError(t, err)
+	assert.Equal(t, "Reproduce bb-1", spec.RenderedPrompt)
+	assert.Contains(t, ansi.Strip(m.View()), "Rendered Prompt (from project prompt_templates.bug):")
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+	cmd := editorCommand("/tmp/prompt.md")
+	assert.Equal(t, []string{"code", "--wait", "/tmp/prompt.md"}, cmd.Args)
+
+	t.Setenv("VISUAL", "nvim")
+	assert.Equal(t, []string{"nvim", "/tmp/prompt.md"}, editorCommand("/tmp/prompt.md").Args)
+
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	assert.Equal(t, "vi", editorCommand("/tmp/prompt.md").Args[0])
+}
+
+func TestLaunchEdit_PromptContext(t *testing.T) {
+	m := newTicketFormTestModel(t, fake.NewWithSampleData())
+	m.state = ViewStateConfirm
+	m.selection = domain.Selection{
+		Ticket: domain.Ticket{ID: "bb-004", Title: "Build TUI skeleton"},
+		Harness: domain.Harness{
+			Name:            "claude",
+			CommandTemplate: "claude",
+			PromptTemplate:  "{{range .BlockedBy}}after {{.ID}}{{end}}",
+			Context:         []domain.ContextProvider{{Name: domain.ContextDependencies}},
+		},
+	}
+	cmd := m.loadPromptContextCmd()
+	require.NotNil(t, cmd)
+	m.launchEdit.contextLoading = true
+	assert.Contains(t, ansi.Strip(m.View()), "Gathering context...")
+
+	newModel, launch := m.handleEnterKey()
+	assert.Nil(t, launch, "enter should wait for the context")
+	assert.Equal(t, ViewStateConfirm, newModel.(UIModel).state)
+
+	newModel, _ = m.Update(cmd())
+	m = newModel.(UIModel)
+	spec, err := m.launchSpec()
+	require.NoError(t, err)
+	assert.Equal(t, "after bb-002", spec.RenderedPrompt)
+	assert.Contains(t, ansi.Strip(m.View()), "blocked by 1, blocks 0")
+}
+
+func TestLaunchEdit_StalePromptContextIsDropped(t *testing.T) {
+	m := newConfirmTestModel(t)
+	m.launchEdit.contextLoading = true
+
+	newModel, _ := m.Update(promptContextLoadedMsg{ticketID: "bb-other", harness: "claude"})
+	m = newModel.(UIModel)
+	assert.True(t, m.launchEdit.contextLoading)
+	assert.Nil(t, m.launchEdit.context)
+}
+
+func TestLaunchCmd_PreLaunchHooks(t *testing.T) {
+	m := newConfirmTestModel(t)
+	m.app.Renderer = config.NewRenderer()
+	m.app.AddProject(domain.Project{Dir: "/proj", Name: "proj", Hooks: domain.Hooks{PreLaunch: []string{"true"}}})
+	m.app.ActiveProject = "/proj"
+	m.selectedWorktree = t.TempDir()
+	m.selection.Harness.Hooks = domain.Hooks{
+		PreLaunch: []string{"echo installing {{.TicketID}}; exit 1"},
+		PostExit:  []string{"go test ./..."},
+	}
+	m.state = ViewStateMatrix
+	m.focus = FocusAgent
+	m.agentList.SetItems(nil)
+	m.agentList.InsertItem(0, agentItem{name: "coder"})
+	newModel, _ := m.handleAgentEnterKey()
+	m = newModel.(UIModel)
+
+	view := ansi.Strip(m.View())
+	assert.Contains(t, view, "pre_launch  true")
+	assert.Contains(t, view, "pre_launch  echo installing bb-1; exit 1")
+	assert.Contains(t, view, "post_exit   go test ./...")
+
+	m.app.Opts.DryRun = false
+	msg, ok := m.launchCmd()().(launchResultMsg)
+	require.True(t, ok)
+	require.Error(t, msg.err)
+	assert.Contains(t, msg.err.Error(), "launch aborted by pre_launch hook")
+	assert.Contains(t, msg.err.Error(), "installing bb-1")
+	assert.Nil(t, msg.res, "the harness is not launched")
+}
@@ -13,6 +13,7 @@ type LayoutDimensions struct {
 	HWidth       int
 	MWidth       int
 	AWidth       int
+	DWidth       int // docked ticket detail pane, 0 when not shown
 
 	InnerListHeight int
 }
@@ -121,3 +122,15 @@ func Compute(termW, termH int, showSidebar bool, zoom bool) LayoutDimensions {
 		InnerListHeight: innerListHeight,
 	}
 }
+
+// WithDetailPane splits the ticket column in half to dock the ticket detail
+// pane beside it. Ticket columns too narrow to split are left alone.
+func (l LayoutDimensions) WithDetailPane() LayoutDimensions {
+	const spacer = 2
+	if l.TWidth < 2*minDetailWidth+spacer {
+		return l
+	}
+	l.DWidth = (l.TWidth - spacer) / 2
+	l.TWidth -= l.DWidth + spacer
+	return l
+}
@@ -70,3 +70,13 @@ func TestLayoutDimensions_Compute_ShowSidebarChangesWidths(t *testing.T) {
 	assert.Greater(t, layoutWithSidebar.SidebarWidth, 0)
 	assert.Equal(t, 0, layoutWithoutSidebar.SidebarWidth)
 }
+
+func TestLayoutDimensions_WithDetailPane(t *testing.T) {
+	layout := Compute(160, 40, false, true)
+	docked := layout.WithDetailPane()
+	assert.Positive(t, docked.DWidth)
+	assert.Equal(t, layout.TWidth, docked.TWidth+docked.DWidth+2)
+
+	narrow := LayoutDimensions{TWidth: 2 * minDetailWidth}
+	assert.Zero(t, narrow.WithDetailPane().DWidth, "narrow ticket columns should not be split")
+}
@@ -105,18 +105,55 @@ func (m UIModel) handleWarningMsg(msg warningMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m UIModel) handleLaunchResult(msg launchResultMsg) (tea.Model, tea.Cmd) {
-	m.launchResult = msg.res
-	m.err = msg.err
+	var dispatchCmd tea.Cmd
+	if msg.slot != nil {
+		m.starting = removeAgentSlot(m.starting, *msg.slot)
+		if msg.err != nil || msg.res == nil || msg.res.LauncherID == "" {
+			// Nothing was started in the slot, so a queued launch may fit.
+			m, dispatchCmd = m.dispatchQueue()
+		}
+	}
 
-	if msg.err != nil {
-		m.state = ViewStateError
-		return m, nil
+	if msg.queued != nil {
+		if msg.err != nil {
+			m, saveCmd := m.requeueFailed(*msg.queued, msg.err)
+			err := fmt.Errorf("queued launch of %s failed, it stays in the queue: %w", msg.queued.TicketID, msg.err)
+			return m, tea.Batch(dispatchCmd, saveCmd, warningCmd(err))
+		}
+	} else {
+		m.launchResult = msg.res
+		m.err = msg.err
+		if msg.err != nil {
+			m.state = ViewStateError
+			return m, dispatchCmd
+		}
 	}
 
 	if msg.res != nil && msg.res.LauncherID != "" {
 		selection := m.selection
+		var renderedCommand, renderedPrompt string
 		if msg.spec != nil {
 			selection = msg.spec.Selection
+			renderedCommand = msg.spec.RenderedCommand
+			renderedPrompt = msg.spec.RenderedPrompt
+		}
+		worktreePath := m.selectedWorktree
+		if msg.worktreePath != "" {
+			worktreePath = msg.worktreePath
+		}
+		projectDir := msg.projectDir
+		if projectDir == "" {
+			projectDir = activeProjectDir(m.app)
+		}
+
+		// A restarted agent replaces the old entry. The new window may reuse
+		// the old window name, so the old entry is dropped before adding.
+		if old, ok := m.agents[msg.replacesAgentID]; ok {
+			if old.Capture != nil {
+				_ = old.Capture.Stop(context.Background())
+			}
+			delete(m.agents, msg.replacesAgentID)
+			RemoveAgentNodeFromSidebar(&m, msg.replacesAgentID)
 		}
 
 		agentID := msg.res.LauncherID
@@ -124,7 +161,11 @@ func (m UIModel) handleLaunchResult(msg launchResultMsg) (tea.Model, tea.Cmd) {
 			ID:           agentID,
 			Name:         selection.Ticket.ID,
 			LauncherID:   msg.res.LauncherID,
-			WorktreePath: m.selectedWorktree,
+			WindowID:     msg.res.WindowID,
+			SessionName:  msg.res.SessionName,
+			PID:          msg.res.PID,
+			ProjectDir:   projectDir,
+			WorktreePath: worktreePath,
 			Status:       domain.AgentRunning,
 			StartedAt:    time.Now(),
 			TicketID:     selection.Ticket.ID,
@@ -132,12 +173,15 @@ func (m UIModel) handleLaunchResult(msg launchResultMsg) (tea.Model, tea.Cmd) {
 			HarnessName:  selection.Harness.Name,
 			ModelName:    selection.Model,
 			AgentName:    selection.Agent,
+
+			RenderedCommand: renderedCommand,
+			RenderedPrompt:  renderedPrompt,
 		}
 
 		var capture *tmux.OutputCapture
-		launcherID := msg.res.LauncherID
-		if launcherID != "" && m.app.Runner() != nil && msg.res.LauncherType == domain.LauncherTypeTmux {
-			capture = tmux.NewOutputCapture(m.app.Runner(), launcherID)
+		target := agentInfo.TmuxTarget()
+		if target != "" && m.app.Runner() != nil && msg.res.LauncherType == domain.LauncherTypeTmux {
+			capture = tmux.NewOutputCapture(m.app.Runner(), target)
 			path, captureErr := capture.Start(context.Background())
 			if captureErr != nil {
 				m.warnings = append(m.warnings, fmt.Sprintf("Failed to capture output: %v", captureErr))
@@ -150,24 +194,33 @@ func (m UIModel) handleLaunchResult(msg launchResultMsg) (tea.Model, tea.Cmd) {
 			Info:    agentInfo,
 			Capture: capture,
 		}
+		if msg.spec != nil {
+			m.agents[agentID].PreLaunch = msg.spec.PreLaunch
+			m.agents[agentID].PostExit = msg.spec.PostExit
+			m.agents[agentID].HookDir = msg.spec.WorkDir
+		}
 
 		AddAgentNodeToSidebar(&m, agentInfo)
 
-		m.state = ViewStateMatrix
+		if msg.queued == nil {
+			m.state = ViewStateMatrix
+		}
 
 		return m, tea.Batch(
-			pollAgentStatusCmd(m.app, agentID, msg.res.LauncherID),
+			pollAgentStatusCmd(m.app, agentID, target),
 			startAgentMonitoringCmd(agentID),
-			saveRunningAgentCmd(m.app, msg.spec, msg.res, m.selectedWorktree),
+			saveRunningAgentCmd(m.app, msg.spec, msg.res, projectDir, worktreePath),
 		)
 	}
 
-	m.state = ViewStateMatrix
-	return m, nil
+	if msg.queued == nil {
+		m.state = ViewStateMatrix
+	}
+	return m, dispatchCmd
 }
 
 func (m UIModel) handleWindowSizeMsg(msg tea.WindowSizeMsg) (UIModel, tea.Cmd) {
-	m.layout = Compute(msg.Width, msg.Height, m.showSidebar, m.ticketZoomEnabled)
+	m.layout = m.computeLayout(msg.Width, msg.Height)
 	m.updateSizes()
 	m.dirtyTicket = true
 	m.dirtyHarness = true
@@ -237,6 +290,11 @@ func (m UIModel) handleRunningAgentsLoaded(msg runningAgentsLoadedMsg) (tea.Mode
 	var cmds []tea.Cmd
 	for _, persisted := range msg.agents {
 		agentID := PersistedAgentID(persisted)
+		remote := !persisted.RunsOn(msg.host)
+		if remote {
+			// Launcher IDs and PIDs are only unique per host.
+			agentID += "@" + persisted.Hostname
+		}
 		if existing, ok := m.agents[agentID]; ok && existing != nil {
 			existing.Info.Status = domain.AgentRunning
 			continue
@@ -246,6 +304,10 @@ func (m UIModel) handleRunningAgentsLoaded(msg runningAgentsLoadedMsg) (tea.Mode
 			ID:           agentID,
 			Name:         persisted.Ticket,
 			LauncherID:   persisted.LauncherID,
+			WindowID:     persisted.WindowID,
+			SessionName:  persisted.SessionName,
+			PID:          persisted.PID,
+			ProjectDir:   persisted.ProjectDir,
 			WorktreePath: persisted.WorktreePath,
 			Status:       domain.AgentRunning,
 			StartedAt:    persisted.StartedAt,
@@ -254,13 +316,38 @@ func (m UIModel) handleRunningAgentsLoaded(msg runningAgentsLoadedMsg) (tea.Mode
 			HarnessName:  persisted.HarnessName,
 			ModelName:    persisted.Model,
 			AgentName:    persisted.Agent,
+
+			RenderedCommand: persisted.RenderedCommand,
+			RenderedPrompt:  persisted.RenderedPrompt,
+
+			Remote:   remote,
+			Hostname: persisted.Hostname,
+			Owner:    persisted.Owner,
+		}
+		if remote {
+			// Another host owns this agent's window and process; show it
+			// without capturing or polling.
+			m.agents[agentID] = &RunningAgent{Info: info}
+			AddAgentNodeToSidebar(&m, info)
+			continue
+		}
+		target := info.TmuxTarget()
+		var capture *tmux.OutputCapture
+		if target != "" && m.app.Runner() != nil && persisted.LauncherType == domain.LauncherTypeTmux {
+			capture = tmux.NewOutputCapture(m.app.Runner(), target)
+		}
+		m.agents[agentID] = &RunningAgent{
+			Info:      info,
+			Capture:   capture,
+			PreLaunch: persisted.PreLaunch,
+			PostExit:  persisted.PostExit,
+			HookDir:   persisted.HookDir,
 		}
-		m.agents[agentID] = &RunningAgent{Info: info}
 		AddAgentNodeToSidebar(&m, info)
 
-		if persisted.LauncherID != "" {
+		if target != "" {
 			cmds = append(cmds,
-				pollAgentStatusCmd(m.app, agentID, persisted.LauncherID),
+				pollAgentStatusCmd(m.app, agentID, target),
 				startAgentMonitoringCmd(agentID),
 			)
 		}
@@ -304,6 +391,33 @@ func (m UIModel) handleTicketUpdateCheckNeeded() (tea.Model, tea.Cmd) {
 	})
 }
 
+// handleTicketWritten closes the ticket form after a successful write and
+// makes the next auto-refresh poll reload the list, whose latest update time
+// may not have moved (e.g. when a ticket leaves the ready view). A failed
+// write stays in the open form, or becomes a warning after an inline edit.
+func (m UIModel) handleTicketWritten(msg ticketWrittenMsg) (tea.Model, tea.Cmd) {
+	inForm := m.state == ViewStateTicketForm
+	if msg.err != nil {
+		if inForm {
+			m.ticketForm.saving = false
+			m.ticketForm.err = msg.err
+			return m, nil
+		}
+		return m.handleWarningMsg(warningMsg{fmt.Errorf("failed to save %s: %w", msg.id, msg.err)})
+	}
+
+	if inForm {
+		m.state = ViewStateMatrix
+		m.ticketForm = ticketForm{}
+	}
+	m.lastTicketUpdate = time.Time{}
+	if i, ok := m.ticketList.SelectedItem().(ticketItem); ok && m.detailDocked && m.detail.ticketID == msg.id {
+		m.detail.setDetail(nil, nil)
+		return m, m.loadTicketDetail(i.ticket)
+	}
+	return m, nil
+}
+
 func (m UIModel) handleTicketsAutoRefreshed(msg ticketsAutoRefreshedMsg) (tea.Model, tea.Cmd) {
 	if !msg.dbUpdatedAt.IsZero() {
 		m.lastTicketUpdate = msg.dbUpdatedAt
@@ -40,8 +40,10 @@ func NewUIModel(app *app.App, harnesses []domain.Harness) UIModel {
 	theme := GetTheme(themeName)
 
 	var registry *discovery.Registry
+	var defaults domain.Defaults
 	if app != nil {
 		registry = app.Registry
+		defaults = app.Opts.Defaults
 	}
 
 	hl := newHarnessList(harnesses, registry, theme)
@@ -111,6 +113,9 @@ func NewUIModel(app *app.App, harnesses []domain.Harness) UIModel {
 		dirtyModel:   true,
 		dirtyAgent:   true,
 
+		baseHarnesses: harnesses,
+		baseDefaults:  defaults,
+
 		animState: AnimationState{
 			StartTime:       time.Now(),
 			ColorCycleStart: time.Now(),
@@ -196,6 +201,8 @@ func (m UIModel) handleCoreMsgs(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 		return m, m.continueInitAfterRegistry(), true
 	case ticketsLoadedMsg:
 		m.lastTicketUpdate = latestTicketUpdate(msg)
+		var configCmd tea.Cmd
+		m, configCmd = m.applyProjectConfig()
 		updatedM, _ := m.handleTicketsLoaded(msg)
 		if !updatedM.(UIModel).pollStarted {
 			um := updatedM.(UIModel)
@@ -204,18 +211,38 @@ func (m UIModel) handleCoreMsgs(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 				tea.Tick(ticketPollingInterval, func(t time.Time) tea.Msg {
 					return ticketUpdateCheckMsg{}
 				}),
-				loadRunningAgentsCmd(m.app),
+				// The launch queue is dispatched by the concurrency
+				// limits, which count the running agents.
+				tea.Sequence(loadRunningAgentsCmd(m.app), loadLaunchQueueCmd(m.app)),
+				configCmd,
+				configTickCmd(m.app),
 			), true
 		}
-		return updatedM, nil, true
+		return updatedM, configCmd, true
 	case errMsg:
 		newM, cmd := m.handleErrMsg(msg)
 		return newM, cmd, true
 	case warningMsg:
 		newM, cmd := m.handleWarningMsg(msg)
 		return newM, cmd, true
-	case modalContentMsg:
-		m.modalContent = string(msg)
+	case ticketWrittenMsg:
+		newM, cmd := m.handleTicketWritten(msg)
+		return newM, cmd, true
+	case launchTextEditedMsg:
+		newM, cmd := m.handleLaunchTextEdited(msg)
+		return newM, cmd, true
+	case snippetSavedMsg:
+		newM, cmd := m.handleSnippetSaved(msg)
+		return newM, cmd, true
+	case promptContextLoadedMsg:
+		newM, cmd := m.handlePromptContextLoaded(msg)
+		return newM, cmd, true
+	case ticketDetailLoadedMsg:
+		// Drop answers for a ticket the pane has since moved away from.
+		if msg.id == m.detail.ticketID {
+			m.detail.setDetail(msg.detail, msg.err)
+			m.detail.layout(m.detailPaneWidth())
+		}
 		return m, nil, true
 	case tea.WindowSizeMsg:
 		newM, cmd := m.handleWindowSizeMsg(msg)
@@ -236,6 +263,18 @@ func (m UIModel) handleProjectMsgs(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 	case runningAgentsLoadedMsg:
 		newM, cmd := m.handleRunningAgentsLoaded(msg)
 		return newM, cmd, true
+	case launchQueueLoadedMsg:
+		newM, cmd := m.handleLaunchQueueLoaded(msg)
+		return newM, cmd, true
+	case agentHistoryLoadedMsg:
+		// A nil history means "still loading", so keep empty results non-nil.
+		m.history = msg.sessions
+		if m.history == nil {
+			m.history = []domain.AgentSession{}
+		}
+		m.historyErr = msg.err
+		m.historyOffset = 0
+		return m, nil, true
 	case WorktreeSelectedMsg:
 		newM, cmd := m.handleWorktreeSelected(msg)
 		return newM, cmd, true
@@ -298,6 +337,12 @@ func (m UIModel) handleAgentMsgs(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 	case agentOutputMsg:
 		newM, cmd := m.HandleAgentOutput(msg)
 		return newM, cmd, true
+	case agentAttentionMsg:
+		newM, cmd := m.HandleAgentAttention(msg)
+		return newM, cmd, true
+	case agentVerifiedMsg:
+		newM, cmd := m.HandleAgentVerified(msg)
+		return newM, cmd, true
 	case animationTickMsg:
 		newM, cmd := m.handleAnimationTick(msg)
 		return newM, cmd, true
@@ -328,6 +373,11 @@ func (m UIModel) handleAgentMsgs(msg tea.Msg) (tea.Model, tea.Cmd, bool) {
 	case refreshAnimationTickMsg:
 		newM, cmd := m.handleRefreshAnimationTick()
 		return newM, cmd, true
+	case configCheckMsg:
+		return m, checkConfigCmd(m.app, m.configProject, m.configStamps), true
+	case configCheckedMsg:
+		newM, cmd := m.handleConfigChecked(msg)
+		return newM, cmd, true
 	}
 	return m, nil, false
 }
@@ -398,6 +448,9 @@ func (m UIModel) handleFocusUpdate(msg tea.Msg) (UIModel, tea.Cmd) {
 	case FocusTickets:
 		m.ticketList, cmd = m.ticketList.Update(msg)
 		m.dirtyTicket = true
+		var detailCmd tea.Cmd
+		m, detailCmd = m.syncDockedDetail()
+		cmd = tea.Batch(cmd, detailCmd)
 	case FocusHarness:
 		return m.handleHarnessFocusUpdate(msg)
 	case FocusModel:
@@ -432,11 +485,7 @@ func (m UIModel) continueInitAfterRegistry() tea.Cmd {
 				return errMsg{err}
 			}
 
-			tickets, err := project.Store().ListTickets(context.Background(), data.TicketFilter{})
-			if err != nil {
-				return errMsg{err}
-			}
-			return ticketsLoadedMsg(tickets)
+			return ticketsResult(project.Store().ListTickets(context.Background(), data.TicketFilter{}))
 		},
 		discoverWorktreesCmd(app),
 		// Animation tick is only started on demand (LockIn) to save CPU
@@ -2,20 +2,24 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
-	osexec "os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
 
 	"github.com/megatherium/blunderbust/internal/app"
 	"github.com/megatherium/blunderbust/internal/config"
 	"github.com/megatherium/blunderbust/internal/data"
 	"github.com/megatherium/blunderbust/internal/data/dolt"
 	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/exec"
 	"github.com/megatherium/blunderbust/internal/exec/tmux"
 	"github.com/megatherium/blunderbust/internal/ui/sidebar"
 )
@@ -38,21 +42,47 @@ func startServerAndRetryCmd(myApp *app.App, store *dolt.Store) tea.Cmd {
 
 func loadTicketsCmd(store data.TicketStore) tea.Cmd {
 	return func() tea.Msg {
-		tickets, err := store.ListTickets(context.Background(), data.TicketFilter{})
-		if err != nil {
-			return errMsg{err}
-		}
-		return ticketsLoadedMsg(tickets)
+		return ticketsResult(store.ListTickets(context.Background(), data.TicketFilter{}))
+	}
+}
+
+// ticketsResult turns the result of ListTickets into a message. A
+// truncated list is shown, with a warning.
+func ticketsResult(tickets []domain.Ticket, err error) tea.Msg {
+	switch {
+	case errors.Is(err, data.ErrTicketsTruncated):
+		return tea.BatchMsg{func() tea.Msg { return ticketsLoadedMsg(tickets) }, warningCmd(err)}
+	case err != nil:
+		return errMsg{err}
 	}
+	return ticketsLoadedMsg(tickets)
 }
 
-func loadModalCmd(ticketID string) tea.Cmd {
+// loadTicketDetailCmd loads the full record of ticket. Stores that cannot
+// provide one fall back to the ticket as listed.
+func loadTicketDetailCmd(store data.TicketStore, ticket domain.Ticket) tea.Cmd {
 	return func() tea.Msg {
-		out, err := osexec.Command("bd", "show", ticketID).CombinedOutput()
-		if err != nil {
-			return modalContentMsg(fmt.Sprintf("Error loading bd show:\n%v\n%s", err, string(out)))
+		detailStore, ok := store.(data.TicketDetailStore)
+		if !ok {
+			return ticketDetailLoadedMsg{id: ticket.ID, detail: &domain.TicketDetail{Ticket: ticket}}
 		}
-		return modalContentMsg(string(out))
+		detail, err := detailStore.TicketDetail(context.Background(), ticket.ID)
+		return ticketDetailLoadedMsg{id: ticket.ID, detail: detail, err: err}
+	}
+}
+
+// createTicketCmd files a new ticket through store.
+func createTicketCmd(store data.TicketWriter, t data.NewTicket) tea.Cmd {
+	return func() tea.Msg {
+		id, err := store.CreateTicket(context.Background(), t)
+		return ticketWrittenMsg{id: id, err: err}
+	}
+}
+
+// updateTicketCmd changes the fields of ticket id set in u.
+func updateTicketCmd(store data.TicketWriter, id string, u data.TicketUpdate) tea.Cmd {
+	return func() tea.Msg {
+		return ticketWrittenMsg{id: id, err: store.UpdateTicket(context.Background(), id, u)}
 	}
 }
 
@@ -114,53 +144,99 @@ func discoverWorktreesCmd(myApp *app.App) tea.Cmd {
 	}
 }
 
+// launchCmd launches the confirmed selection. The launch holds a slot
+// against the concurrency limits until its result arrives.
 func (m UIModel) launchCmd() tea.Cmd {
+	slot := &domain.AgentSlot{Harness: m.selection.Harness.Name, Model: m.selection.Model}
 	return func() tea.Msg {
-		workDir := m.selectedWorktree
-		if workDir == "" {
-			workDir = app.ExtractRepoRoot(m.app.Opts.BeadsDir)
-		}
-
-		spec, err := m.app.Renderer.RenderSelection(m.selection, workDir)
+		spec, err := m.launchSpec()
 		if err != nil {
 			return launchResultMsg{
-				res: nil,
-				err: fmt.Errorf("failed to render launch spec: %w", err),
+				err:  fmt.Errorf("failed to render launch spec: %w", err),
+				slot: slot,
 			}
 		}
 
 		spec.LauncherID = m.selection.Ticket.ID
+		return startLaunchCmd(m.app, *spec, launchResultMsg{slot: slot})()
+	}
+}
 
-		res, err := m.app.Launcher.Launch(context.Background(), *spec)
-		return launchResultMsg{res: res, spec: spec, err: err}
+// commandTimeout returns how long a hook, verify_command or context
+// provider may run.
+func commandTimeout(myApp *app.App) time.Duration {
+	if myApp == nil {
+		return domain.DefaultCommandTimeout
 	}
+	return myApp.CommandTimeout()
 }
 
-func loadRunningAgentsCmd(myApp *app.App) tea.Cmd {
+// runHooks runs hooks in order with sh in dir, each bounded by timeout, and
+// stops at the first that fails.
+func runHooks(hooks []string, dir string, timeout time.Duration, env ...string) error {
+	for _, hook := range hooks {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := exec.RunHook(ctx, hook, dir, env...)
+		cancel()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startLaunchCmd runs spec's pre_launch hooks, unless in dry-run mode, and
+// launches it. The result is reported in msg, which carries whatever else
+// handleLaunchResult needs to know about the launch.
+func startLaunchCmd(myApp *app.App, spec domain.LaunchSpec, msg launchResultMsg) tea.Cmd {
 	return func() tea.Msg {
-		project := myApp.Project()
-		if project == nil || project.Store() == nil {
-			if myApp.Opts.Debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] loadRunningAgentsCmd: no project or store\n")
+		msg.spec = &spec
+		if !myApp.Opts.DryRun {
+			if err := runHooks(spec.PreLaunch, spec.WorkDir, myApp.CommandTimeout()); err != nil {
+				msg.err = fmt.Errorf("launch aborted by pre_launch %w", err)
+				return msg
 			}
-			return runningAgentsLoadedMsg{}
 		}
 
-		store, ok := project.Store().(*dolt.Store)
-		if !ok {
+		msg.res, msg.err = myApp.Launcher.Launch(context.Background(), spec)
+		return msg
+	}
+}
+
+// postExitHooksCmd runs an ended agent's post_exit hooks in order, with the
+// agent's final status in BDB_AGENT_STATUS and its exit code, when known,
+// in BDB_EXIT_CODE. Each hook may run for timeout. A failing hook is
+// reported as a warning and stops the remaining ones.
+func postExitHooksCmd(hooks []string, dir string, timeout time.Duration, info domain.AgentInfo) tea.Cmd {
+	if len(hooks) == 0 {
+		return nil
+	}
+	env := []string{"BDB_AGENT_STATUS=" + info.Status.String(), "BDB_EXIT_CODE="}
+	if info.ExitCode != nil {
+		env[1] += strconv.Itoa(*info.ExitCode)
+	}
+	return func() tea.Msg {
+		if err := runHooks(hooks, dir, timeout, env...); err != nil {
+			return warningMsg{err: fmt.Errorf("agent %s: post_exit %w", info.Name, err)}
+		}
+		return nil
+	}
+}
+
+func loadRunningAgentsCmd(myApp *app.App) tea.Cmd {
+	return func() tea.Msg {
+		store, err := myApp.AgentState(context.Background())
+		if err != nil {
+			return runningAgentsLoadedMsg{err: err}
+		}
+		if store == nil {
 			if myApp.Opts.Debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] loadRunningAgentsCmd: store is not dolt.Store\n")
+				fmt.Fprintf(os.Stderr, "[DEBUG] loadRunningAgentsCmd: no agent state store\n")
 			}
 			return runningAgentsLoadedMsg{}
 		}
 
-		projectDirs := make([]string, 0, len(myApp.GetProjects()))
-		for _, p := range myApp.GetProjects() {
-			projectDirs = append(projectDirs, p.Dir)
-		}
-		if len(projectDirs) == 0 && myApp.ActiveProject != "" {
-			projectDirs = append(projectDirs, myApp.ActiveProject)
-		}
+		projectDirs := workspaceProjectDirs(myApp)
 
 		if myApp.Opts.Debug {
 			fmt.Fprintf(os.Stderr, "[DEBUG] loadRunningAgentsCmd: querying projectDirs=%v\n", projectDirs)
@@ -189,11 +265,38 @@ func loadRunningAgentsCmd(myApp *app.App) tea.Cmd {
 			}
 		}
 
-		return runningAgentsLoadedMsg{agents: agents}
+		return runningAgentsLoadedMsg{agents: agents, host: store.Host()}
+	}
+}
+
+// workspaceProjectDirs returns the project directories agents are tracked for.
+func workspaceProjectDirs(myApp *app.App) []string {
+	projectDirs := make([]string, 0, len(myApp.GetProjects()))
+	for _, p := range myApp.GetProjects() {
+		projectDirs = append(projectDirs, p.Dir)
+	}
+	if len(projectDirs) == 0 && myApp.ActiveProject != "" {
+		projectDirs = append(projectDirs, myApp.ActiveProject)
+	}
+	return projectDirs
+}
+
+// historySessionLimit caps how many sessions the history view loads.
+const historySessionLimit = 500
+
+func loadAgentHistoryCmd(myApp *app.App) tea.Cmd {
+	return func() tea.Msg {
+		store, err := myApp.AgentState(context.Background())
+		if err != nil || store == nil {
+			return agentHistoryLoadedMsg{err: err}
+		}
+
+		sessions, err := store.ListAgentSessions(context.Background(), workspaceProjectDirs(myApp), historySessionLimit)
+		return agentHistoryLoadedMsg{sessions: sessions, err: err}
 	}
 }
 
-func saveRunningAgentCmd(myApp *app.App, spec *domain.LaunchSpec, result *domain.LaunchResult, worktreePath string) tea.Cmd {
+func saveRunningAgentCmd(myApp *app.App, spec *domain.LaunchSpec, result *domain.LaunchResult, projectDir, worktreePath string) tea.Cmd {
 	return func() tea.Msg {
 		if myApp == nil || spec == nil || result == nil {
 			if myApp != nil && myApp.Opts.Debug {
@@ -203,17 +306,13 @@ func saveRunningAgentCmd(myApp *app.App, spec *domain.LaunchSpec, result *domain
 			return nil
 		}
 
-		project := myApp.Project()
-		if project == nil || project.Store() == nil {
-			if myApp.Opts.Debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] saveRunningAgentCmd: no project or store\n")
-			}
-			return nil
+		store, err := myApp.AgentState(context.Background())
+		if err != nil {
+			return warningMsg{err: err}
 		}
-		store, ok := project.Store().(*dolt.Store)
-		if !ok {
+		if store == nil {
 			if myApp.Opts.Debug {
-				fmt.Fprintf(os.Stderr, "[DEBUG] saveRunningAgentCmd: store is not dolt.Store\n")
+				fmt.Fprintf(os.Stderr, "[DEBUG] saveRunningAgentCmd: no agent state store\n")
 			}
 			return nil
 		}
@@ -226,9 +325,8 @@ func saveRunningAgentCmd(myApp *app.App, spec *domain.LaunchSpec, result *domain
 			}
 		}
 
-		projectDir := myApp.ActiveProject
 		if projectDir == "" {
-			projectDir = app.ExtractRepoRoot(myApp.Opts.BeadsDir)
+			projectDir = activeProjectDir(myApp)
 		}
 		if worktreePath == "" {
 			worktreePath = projectDir
@@ -246,6 +344,7 @@ func saveRunningAgentCmd(myApp *app.App, spec *domain.LaunchSpec, result *domain
 			fmt.Fprintf(os.Stderr, "[DEBUG]   worktreePath=%s\n", worktreePath)
 			fmt.Fprintf(os.Stderr, "[DEBUG]   PID=%d\n", result.PID)
 			fmt.Fprintf(os.Stderr, "[DEBUG]   launcherID=%s\n", result.LauncherID)
+			fmt.Fprintf(os.Stderr, "[DEBUG]   windowID=%s session=%s\n", result.WindowID, result.SessionName)
 			fmt.Fprintf(os.Stderr, "[DEBUG]   launcherType=%d\n", result.LauncherType)
 			fmt.Fprintf(os.Stderr, "[DEBUG]   ticket=%s\n", spec.Selection.Ticket.ID)
 			fmt.Fprintf(os.Stderr, "[DEBUG]   harness=%s\n", spec.Selection.Harness.Name)
@@ -253,18 +352,26 @@ func saveRunningAgentCmd(myApp *app.App, spec *domain.LaunchSpec, result *domain
 			fmt.Fprintf(os.Stderr, "[DEBUG]   renderedCommand=%s\n", spec.RenderedCommand)
 		}
 
-		err := store.UpsertRunningAgent(context.Background(), domain.PersistedRunningAgent{
+		err = store.UpsertRunningAgent(context.Background(), domain.PersistedRunningAgent{
 			ProjectDir:    projectDir,
 			WorktreePath:  worktreePath,
 			PID:           result.PID,
 			LauncherType:  result.LauncherType,
 			LauncherID:    result.LauncherID,
+			WindowID:      result.WindowID,
+			SessionName:   result.SessionName,
 			Ticket:        spec.Selection.Ticket.ID,
 			TicketTitle:   spec.Selection.Ticket.Title,
 			HarnessName:   spec.Selection.Harness.Name,
 			HarnessBinary: harnessBinary,
 			Model:         spec.Selection.Model,
 			Agent:         spec.Selection.Agent,
+
+			RenderedCommand: spec.RenderedCommand,
+			RenderedPrompt:  spec.RenderedPrompt,
+			PreLaunch:       spec.PreLaunch,
+			PostExit:        spec.PostExit,
+			HookDir:         spec.WorkDir,
 		})
 		if err != nil {
 			if myApp.Opts.Debug {
@@ -283,24 +390,29 @@ func saveRunningAgentCmd(myApp *app.App, spec *domain.LaunchSpec, result *domain
 
 // Agent monitoring commands
 
-func pollAgentStatusCmd(myApp *app.App, agentID, launcherID string) tea.Cmd {
+// pollAgentStatusCmd checks the agent's tmux window, addressed by target
+// (a window ID, or a window name for agents persisted before IDs were tracked).
+func pollAgentStatusCmd(myApp *app.App, agentID, target string) tea.Cmd {
 	return func() tea.Msg {
 		if myApp.StatusChecker() == nil {
 			return AgentStatusMsg{AgentID: agentID, Status: domain.AgentRunning}
 		}
 
-		status := myApp.StatusChecker().CheckStatus(context.Background(), launcherID)
-		var agentStatus domain.AgentStatus
-		switch status {
-		case tmux.Running:
-			agentStatus = domain.AgentRunning
+		state := myApp.StatusChecker().CheckWindow(context.Background(), target)
+		switch state.Status {
+		case tmux.Exited:
+			exitCode := state.ExitCode
+			agentStatus := domain.AgentCompleted
+			if exitCode != 0 {
+				agentStatus = domain.AgentFailed
+			}
+			return AgentStatusMsg{AgentID: agentID, Status: agentStatus, ExitCode: &exitCode}
 		case tmux.Dead:
-			agentStatus = domain.AgentCompleted
+			// Window closed before the exit status could be read.
+			return AgentStatusMsg{AgentID: agentID, Status: domain.AgentCompleted}
 		default:
-			agentStatus = domain.AgentRunning
+			return AgentStatusMsg{AgentID: agentID, Status: domain.AgentRunning}
 		}
-
-		return AgentStatusMsg{AgentID: agentID, Status: agentStatus}
 	}
 }
 
@@ -325,31 +437,112 @@ func readAgentOutputCmd(agentID string, capture *tmux.OutputCapture) tea.Cmd {
 	}
 }
 
+// activeProjectDir returns the directory agents launched now are recorded under.
+func activeProjectDir(myApp *app.App) string {
+	if myApp.ActiveProject != "" {
+		return myApp.ActiveProject
+	}
+	return app.ExtractRepoRoot(myApp.Opts.BeadsDir)
+}
+
+// finishRunningAgentCmd persists an agent's final status, saves its pane
+// output to a log file while the remain-on-exit window is still around, and
+// appends the run to the session history.
+func finishRunningAgentCmd(myApp *app.App, info domain.AgentInfo, capture *tmux.OutputCapture) tea.Cmd {
+	return func() tea.Msg {
+		if myApp == nil || info.PID <= 0 || info.LauncherID == "" {
+			return nil
+		}
+		ctx := context.Background()
+		store, err := myApp.AgentState(ctx)
+		if err != nil {
+			return warningMsg{err: err}
+		}
+		if store == nil {
+			return nil
+		}
+
+		if err := store.FinishRunningAgent(ctx, info.LauncherID, info.PID, info.Status, info.ExitCode); err != nil {
+			if myApp.Opts.Debug {
+				fmt.Fprintf(os.Stderr, "[DEBUG] finishRunningAgentCmd: FinishRunningAgent error: %v\n", err)
+			}
+			return warningMsg{err: fmt.Errorf("failed to persist agent status: %w", err)}
+		}
+
+		var logPath string
+		if capture != nil && info.WindowID != "" {
+			if path, err := app.AgentLogPath(info.LauncherID, info.EndedAt); err == nil {
+				if err := capture.SaveHistory(ctx, path); err == nil {
+					logPath = path
+				} else if myApp.Opts.Debug {
+					fmt.Fprintf(os.Stderr, "[DEBUG] finishRunningAgentCmd: SaveHistory error: %v\n", err)
+				}
+			}
+		}
+
+		projectDir := info.ProjectDir
+		if projectDir == "" {
+			projectDir = activeProjectDir(myApp)
+		}
+		err = store.RecordAgentSession(ctx, domain.AgentSession{
+			ProjectDir:    projectDir,
+			WorktreePath:  info.WorktreePath,
+			LauncherID:    info.LauncherID,
+			Ticket:        info.TicketID,
+			TicketTitle:   info.TicketTitle,
+			HarnessName:   info.HarnessName,
+			Model:         info.ModelName,
+			Agent:         info.AgentName,
+			Status:        info.Status,
+			ExitCode:      info.ExitCode,
+			StartedAt:     info.StartedAt,
+			EndedAt:       info.EndedAt,
+			OutputLogPath: logPath,
+		})
+		if err != nil {
+			return warningMsg{err: fmt.Errorf("failed to record agent session: %w", err)}
+		}
+		return nil
+	}
+}
+
 // Agent clearing commands
 
-func clearAgentCmd(agentID string, capture *tmux.OutputCapture) tea.Cmd {
+// killExitedWindow closes a window kept open by remain-on-exit.
+// Best-effort: the user may already have closed it.
+func killExitedWindow(runner tmux.CommandRunner, windowID string) {
+	if runner == nil || windowID == "" {
+		return
+	}
+	_, _ = runner.Run(context.Background(), "tmux", "kill-window", "-t", windowID)
+}
+
+func clearAgentCmd(agentID string, capture *tmux.OutputCapture, runner tmux.CommandRunner, exitedWindowID string) tea.Cmd {
 	return func() tea.Msg {
 		// Stop output capture if still running
 		if capture != nil {
 			_ = capture.Stop(context.Background())
 		}
+		killExitedWindow(runner, exitedWindowID)
 
 		return AgentClearedMsg{AgentID: agentID}
 	}
 }
 
 type agentToClear struct {
-	id      string
-	capture *tmux.OutputCapture
+	id       string
+	capture  *tmux.OutputCapture
+	windowID string
 }
 
-func clearAllStoppedAgentsCmd(agents []agentToClear) tea.Cmd {
+func clearAllStoppedAgentsCmd(agents []agentToClear, runner tmux.CommandRunner) tea.Cmd {
 	return func() tea.Msg {
 		cleared := make([]string, 0, len(agents))
 		for _, a := range agents {
 			if a.capture != nil {
 				_ = a.capture.Stop(context.Background())
 			}
+			killExitedWindow(runner, a.windowID)
 			cleared = append(cleared, a.id)
 		}
 
@@ -360,6 +553,87 @@ func clearAllStoppedAgentsCmd(agents []agentToClear) tea.Cmd {
 	}
 }
 
+// detectAttentionCmd reads an agent's pane and matches its harness rules.
+func detectAttentionCmd(agentID string, capture *tmux.OutputCapture, rules []domain.AttentionRule) tea.Cmd {
+	return func() tea.Msg {
+		content, err := capture.ReadOutput()
+		if err != nil {
+			return nil
+		}
+		return agentAttentionMsg{
+			agentID: agentID,
+			state:   domain.DetectAttention(rules, ansi.Strip(string(content))),
+		}
+	}
+}
+
+// attentionBell is where the terminal bell is written for the "bell" mode.
+var attentionBell io.Writer = os.Stderr
+
+// notifyAttentionCmd tells the user that an agent needs attention, using the
+// configured mode.
+func notifyAttentionCmd(runner tmux.CommandRunner, mode string, info domain.AgentInfo) tea.Cmd {
+	return func() tea.Msg {
+		switch mode {
+		case domain.AttentionNotifyBell:
+			_, _ = io.WriteString(attentionBell, "\a")
+		case domain.AttentionNotifyDisplayMessage:
+			if runner == nil {
+				return nil
+			}
+			text := fmt.Sprintf("bdb: %s %s", info.Name, info.Attention.Description())
+			_, _ = runner.Run(context.Background(), "tmux", "display-message", text)
+		}
+		return nil
+	}
+}
+
+// Agent interaction commands
+
+func warningCmd(err error) tea.Cmd {
+	return func() tea.Msg {
+		return warningMsg{err: err}
+	}
+}
+
+// windowActionCmd runs an action against an agent's tmux window and reports
+// failures as warnings.
+func windowActionCmd(runner tmux.CommandRunner, action func(context.Context, *tmux.WindowController) error) tea.Cmd {
+	return func() tea.Msg {
+		if runner == nil {
+			return nil
+		}
+		if err := action(context.Background(), tmux.NewWindowController(runner)); err != nil {
+			return warningMsg{err: err}
+		}
+		return nil
+	}
+}
+
+// restartAgentCmd kills the agent's old window, if it is still around, and
+// launches spec in its place through startLaunchCmd, so pre_launch hooks
+// run as for any launch. Agents saved before window IDs were tracked are
+// killed by window name. The result replaces the old agent in the UI and
+// releases slot.
+func restartAgentCmd(myApp *app.App, spec domain.LaunchSpec, old domain.AgentInfo, slot *domain.AgentSlot) tea.Cmd {
+	return func() tea.Msg {
+		if myApp == nil || myApp.Launcher == nil {
+			return launchResultMsg{slot: slot, err: fmt.Errorf("failed to restart agent %s: no launcher", old.Name)}
+		}
+		killExitedWindow(myApp.Runner(), old.TmuxTarget())
+
+		msg := startLaunchCmd(myApp, spec, launchResultMsg{
+			slot:            slot,
+			worktreePath:    old.WorktreePath,
+			replacesAgentID: old.ID,
+		})().(launchResultMsg)
+		if msg.err != nil {
+			msg.err = fmt.Errorf("failed to restart agent %s: %w", old.Name, msg.err)
+		}
+		return msg
+	}
+}
+
 // Ticket auto-refresh commands
 
 func checkTicketUpdatesCmd(store data.TicketStore, lastUpdate time.Time) tea.Cmd {
@@ -3,6 +3,7 @@ package ui
 import (
 	"time"
 
+	"github.com/megatherium/blunderbust/internal/config"
 	"github.com/megatherium/blunderbust/internal/data"
 	"github.com/megatherium/blunderbust/internal/domain"
 )
@@ -19,9 +20,63 @@ type launchResultMsg struct {
 	res  *domain.LaunchResult
 	spec *domain.LaunchSpec
 	err  error
+
+	// Set when an existing agent is restarted: the new agent keeps the old
+	// agent's worktree and replaces its sidebar entry.
+	worktreePath    string
+	replacesAgentID string
+
+	// slot is released from the launches counted against the concurrency
+	// limits. Launches started from the queue are for projectDir, which
+	// may no longer be active; queued is put back at the head of the
+	// queue when it fails.
+	slot       *domain.AgentSlot
+	projectDir string
+	queued     *domain.QueuedLaunch
+}
+
+// launchQueueLoadedMsg carries the launch queue saved by the last session.
+type launchQueueLoadedMsg struct {
+	queue []domain.QueuedLaunch
+	err   error
+}
+
+type ticketDetailLoadedMsg struct {
+	id     string
+	detail *domain.TicketDetail
+	err    error
+}
+
+// ticketWrittenMsg reports the result of creating or updating a ticket.
+type ticketWrittenMsg struct {
+	id  string
+	err error
+}
+
+// launchTextEditedMsg carries the prompt, or the command if command is set,
+// after editing it in $EDITOR.
+type launchTextEditedMsg struct {
+	command bool
+	text    string
+	err     error
+}
+
+// snippetSavedMsg reports the result of saving a prompt snippet.
+type snippetSavedMsg struct {
+	harness string
+	snippet domain.PromptSnippet
+	from    string
+	path    string
+	err     error
 }
 
-type modalContentMsg string
+// promptContextLoadedMsg carries the prompt context gathered for the ticket
+// and harness on the confirm screen.
+type promptContextLoadedMsg struct {
+	ticketID string
+	harness  string
+	context  domain.PromptContext
+}
 
 // addProjectConfirmedMsg is emitted when user confirms adding a project.
 type addProjectConfirmedMsg struct {
@@ -43,13 +98,20 @@ type worktreesDiscoveredMsg struct {
 
 type runningAgentsLoadedMsg struct {
 	agents []domain.PersistedRunningAgent
+	host   domain.HostIdentity // local host, to tell remote agents apart
 	err    error
 }
 
+type agentHistoryLoadedMsg struct {
+	sessions []domain.AgentSession
+	err      error
+}
+
 // Agent-related messages
 type AgentStatusMsg struct {
-	AgentID string
-	Status  domain.AgentStatus
+	AgentID  string
+	Status   domain.AgentStatus
+	ExitCode *int
 }
 
 type AgentHoveredMsg struct {
@@ -76,6 +138,21 @@ type agentOutputMsg struct {
 	content string
 }
 
+// agentAttentionMsg reports the attention state detected in an agent's output.
+type agentAttentionMsg struct {
+	agentID string
+	state   domain.AttentionState
+}
+
+// agentVerifiedMsg reports the result of a verify_command run on an agent's
+// worktree. commentErr is set when the ticket comment could not be added.
+type agentVerifiedMsg struct {
+	agentID    string
+	passed     bool
+	output     string
+	commentErr error
+}
+
 // Auto-refresh messages
 type ticketUpdateCheckMsg struct{}
 
@@ -89,6 +166,21 @@ type clearRefreshIndicatorMsg struct{}
 
 type refreshAnimationTickMsg struct{}
 
+// Config reload messages
+type configCheckMsg struct{}
+
+// configCheckedMsg is the result of checking the config files for changes.
+// When they changed, the config and the project layer were reloaded.
+type configCheckedMsg struct {
+	project  string // the active project the layer was loaded for
+	stamps   map[string]config.FileStamp
+	changed  bool
+	cfg      *domain.Config
+	layer    *config.ProjectLayer
+	err      error // the global config is invalid
+	layerErr error // the project config is invalid
+}
+
 type serverStartedMsg struct {
 	store data.TicketStore
 }
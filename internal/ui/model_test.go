@@ -161,10 +161,11 @@ func TestUIModel_Update_Messages(t *testing.T) {
 	warnM := newModel.(UIModel)
 	assert.Len(t, warnM.warnings, 1)
 
-	modalMsg := modalContentMsg("test content")
-	newModel, _ = m.Update(modalMsg)
-	modM := newModel.(UIModel)
-	assert.Equal(t, "test content", modM.modalContent)
+	m.detail = newTicketDetailPane("bb-001")
+	detailMsg := ticketDetailLoadedMsg{id: "bb-001", detail: &domain.TicketDetail{Ticket: domain.Ticket{ID: "bb-001"}}}
+	newModel, _ = m.Update(detailMsg)
+	detailM := newModel.(UIModel)
+	assert.Equal(t, "bb-001", detailM.detail.detail.ID)
 
 	res := &domain.LaunchResult{LauncherID: "test-window", LauncherType: domain.LauncherTypeTmux}
 	launchMsg := launchResultMsg{res: res, err: nil}
@@ -1491,6 +1492,9 @@ func TestHandleRunningAgentsLoaded_RestoresMaterializedLaunchContext(t *testing.
 				Model:        "m1",
 				Agent:        "a1",
 				StartedAt:    time.Now(),
+				PreLaunch:    []string{"git pull"},
+				PostExit:     []string{"make clean"},
+				HookDir:      "/repo/wt",
 			},
 		},
 	}
@@ -1498,12 +1502,51 @@ func TestHandleRunningAgentsLoaded_RestoresMaterializedLaunchContext(t *testing.
 	newModel, _ := m.handleRunningAgentsLoaded(msg)
 	updated := newModel.(UIModel)
 
-	info := updated.agents["agent-window:42"].Info
+	agent := updated.agents["agent-window:42"]
+	info := agent.Info
 	assert.Equal(t, "bb-999", info.TicketID)
 	assert.Equal(t, "Persisted title", info.TicketTitle)
 	assert.Equal(t, "h1", info.HarnessName)
 	assert.Equal(t, "m1", info.ModelName)
 	assert.Equal(t, "a1", info.AgentName)
+	assert.Equal(t, []string{"git pull"}, agent.PreLaunch)
+	assert.Equal(t, []string{"make clean"}, agent.PostExit, "post_exit hooks survive a bdb restart")
+	assert.Equal(t, "/repo/wt", agent.HookDir)
+}
+
+func TestHandleRunningAgentsLoaded_RemoteAgentsAreReadOnly(t *testing.T) {
+	app := newTestApp()
+	m := NewUIModel(app, nil)
+
+	msg := runningAgentsLoadedMsg{
+		host: domain.HostIdentity{Hostname: "laptop", MachineID: "m-1", User: "alice"},
+		agents: []domain.PersistedRunningAgent{
+			{
+				ID:           1,
+				PID:          42,
+				LauncherID:   "bb-1",
+				LauncherType: domain.LauncherTypeTmux,
+				WindowID:     "@3",
+				Ticket:       "bb-1",
+				HarnessName:  "h1",
+				Hostname:     "desktop",
+				MachineID:    "m-2",
+				Owner:        "bob",
+				StartedAt:    time.Now(),
+			},
+		},
+	}
+
+	newModel, cmd := m.handleRunningAgentsLoaded(msg)
+	updated := newModel.(UIModel)
+
+	agent := updated.agents["bb-1:42@desktop"]
+	if assert.NotNil(t, agent) {
+		assert.True(t, agent.Info.Remote)
+		assert.Equal(t, "bob@desktop", agent.Info.HostLabel())
+		assert.Nil(t, agent.Capture, "remote agents are not captured")
+	}
+	assert.Nil(t, cmd, "remote agents are not polled")
 }
 
 func TestHandleAgentSelected_ClearsHoveredAgentID(t *testing.T) {
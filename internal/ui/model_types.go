@@ -5,9 +5,11 @@ import (
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/config"
 	"github.com/megatherium/blunderbust/internal/data"
 	"github.com/megatherium/blunderbust/internal/domain"
 	"github.com/megatherium/blunderbust/internal/exec/tmux"
@@ -38,6 +40,7 @@ const (
 	ticketPollingInterval    = 3 * time.Second
 	refreshIndicatorDuration = 3 * time.Second
 	animationTickInterval    = 500 * time.Millisecond
+	configPollInterval       = 2 * time.Second
 )
 
 type FocusColumn int
@@ -60,6 +63,11 @@ const (
 	ViewStateAgentOutput
 	ViewStateConfirm
 	ViewStateError
+	ViewStateHistory
+	ViewStateAgentPrompt
+	ViewStateTicketDetail
+	ViewStateTicketForm
+	ViewStateQueue
 )
 
 // UIModel represents the complete state of the TUI application.
@@ -73,6 +81,11 @@ const (
 //   - ViewStateMatrix: Main matrix view (ticket/harness/model/agent columns)
 //   - ViewStateConfirm: Launch confirmation
 //   - ViewStateError: Error display with retry options
+//   - ViewStateHistory: Finished agent sessions with aggregate statistics
+//   - ViewStateAgentPrompt: Follow-up prompt input for a running agent
+//   - ViewStateTicketDetail: Full ticket record rendered as markdown
+//   - ViewStateTicketForm: Form for creating a ticket or editing its priority, status and assignee
+//   - ViewStateQueue: Launches waiting for a concurrency limit, to reorder or cancel
 //
 // Note: showModal is a separate overlay system used for error/info messages
 // and is composited on top of the main content.
@@ -93,6 +106,37 @@ const (
 //	→ Agent output view
 //	→ Enter or Back → state = ViewStateMatrix → back to matrix
 //
+//	Sidebar agent node + 's' → state = ViewStateAgentPrompt
+//	→ Enter sends the text to the agent's window, Esc cancels
+//	→ state = ViewStateAgentOutput if an agent was being viewed, else ViewStateMatrix
+//
+// Valid State Transitions (Confirm screen):
+//
+//	Agent column + Enter → state = ViewStateConfirm (launch edits reset)
+//	→ harnesses with context providers gather prompt context → promptContextLoadedMsg;
+//	  Enter does not launch until it arrives
+//	→ 'e'/'E' open the prompt/command in $EDITOR (tea.ExecProcess) → launchTextEditedMsg
+//	→ 'p' cycles the harness's prompt snippets, 's' saves the prompt as one → snippetSavedMsg
+//	→ Enter launches the edited spec, Esc → state = ViewStateMatrix
+//
+// Valid State Transitions (Ticket detail):
+//
+//	Ticket column + 'i' → state = ViewStateTicketDetail
+//	→ '/' searches, n/N jump between matches
+//	→ Esc, 'q' or 'i' → state = ViewStateMatrix
+//
+//	In zoom mode 'i' instead docks the pane beside the ticket column, where it
+//	follows the ticket cursor; 'i' again undocks it.
+//
+// Valid State Transitions (Ticket form):
+//
+//	Ticket column + 'n' (new) or 'e' (edit) → state = ViewStateTicketForm
+//	→ ctrl+s, or Enter on the last field, writes the ticket
+//	→ ticketWrittenMsg → state = ViewStateMatrix; the auto-refresh poll reloads the list
+//	→ Esc cancels → state = ViewStateMatrix
+//
+//	'+' and '-' change the selected ticket's priority without opening the form.
+//
 // Column Disable Logic:
 //
 //	modelColumnDisabled = true when harness has no models
@@ -132,6 +176,46 @@ type UIModel struct {
 	viewingAgentID string                   // Which agent output is displayed ("" = show matrix)
 	hoveredAgentID string                   // Agent currently hovered in sidebar ("" = no hover)
 
+	// Follow-up prompt input (ViewStateAgentPrompt)
+	agentPrompt   textinput.Model
+	promptAgentID string // agent the prompt is sent to
+
+	// Agent session history view (ViewStateHistory)
+	history       []domain.AgentSession
+	historyErr    error
+	historyOffset int // first session row shown
+
+	// Launch queue (ViewStateQueue): launches held back by a concurrency
+	// limit. starting are the launches under way, which count against the
+	// limits until their result arrives.
+	queue       []domain.QueuedLaunch
+	queueCursor int
+	queueLoaded bool // the queue saved by the last session has been merged in
+	starting    []domain.AgentSlot
+
+	// Ticket detail pane (ViewStateTicketDetail, or docked in zoom mode)
+	detail       ticketDetailPane
+	detailDocked bool
+
+	// Ticket create/edit form (ViewStateTicketForm)
+	ticketForm ticketForm
+
+	// Project-local config: harnesses holds baseHarnesses, the global
+	// config's, with the .blunderbust.yaml of configProject layered over them.
+	// baseDefaults, layered the same way, are preselected when a project
+	// becomes active.
+	baseHarnesses  []domain.Harness
+	baseDefaults   domain.Defaults
+	configProject  string
+	projectLayered bool // configProject has a .blunderbust.yaml
+
+	// configStamps are the watched config files as last loaded; nil until
+	// the first check, or after configProject changed.
+	configStamps map[string]config.FileStamp
+
+	// Prompt and command edits made on the confirm screen (ViewStateConfirm)
+	launchEdit launchEdit
+
 	// Column disable state - set based on harness configuration
 	modelColumnDisabled bool // true when harness has no models
 	agentColumnDisabled bool // true when harness has no agents
@@ -189,4 +273,15 @@ type RunningAgent struct {
 	Info       *domain.AgentInfo
 	Capture    *tmux.OutputCapture
 	LastOutput string
+
+	// PreLaunch and PostExit are the rendered hooks the agent was launched
+	// with, run in HookDir: PreLaunch again when it is restarted, PostExit
+	// when it ends. They are persisted, so agents restored from a previous
+	// session keep them.
+	PreLaunch []string
+	PostExit  []string
+	HookDir   string
+
+	// VerifyOutput is the end of the output of the last verify_command run.
+	VerifyOutput string
 }
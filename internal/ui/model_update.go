@@ -24,13 +24,16 @@ import (
 //    - registryLoadedMsg: Initial registry load
 //    - ticketsLoadedMsg: Ticket data loaded
 //    - errMsg/warningMsg: Error/warning display
-//    - modalContentMsg: Modal content updates
+//    - ticketDetailLoadedMsg: Ticket detail pane content
+//    - ticketWrittenMsg: Ticket created or updated through the form or inline edit
+//    - launchTextEditedMsg/snippetSavedMsg: Confirm screen prompt and command edits
 //    - tea.WindowSizeMsg: Window resize events
 //    - tea.KeyMsg: Keyboard input (dispatched via handleKeyMsg)
 //
 // 4. Project Messages: handleProjectMsgs() handles:
 //    - worktreesDiscoveredMsg: Worktree discovery results
 //    - runningAgentsLoadedMsg: Running agents loaded
+//    - launchQueueLoadedMsg: Launch queue saved by the last session loaded
 //    - WorktreeSelectedMsg: Worktree selection change
 //    - serverStartedMsg: Server started notification
 //    - OpenFilePickerMsg: Open file picker
@@ -50,6 +53,7 @@ import (
 //    - AgentClearedMsg/AllStoppedAgentsClearedMsg: Agent clearing
 //    - ticketUpdateCheckMsg/ticketUpdateCheckNeededMsg: Ticket updates
 //    - ticketsAutoRefreshedMsg/clearRefreshIndicatorMsg/refreshAnimationTickMsg: Refresh handling
+//    - configCheckMsg/configCheckedMsg: Config file change checks and reloads
 //
 // 6. Focus Update: handleFocusUpdate() handles focus-specific updates based on current focus
 //    - FocusSidebar: Sidebar cursor and selection
@@ -64,11 +68,18 @@ import (
 // 1. File picker keys (handleFilePickerKeyMsg)
 // 2. Add project modal keys (handleAddProjectModalKeyMsg)
 // 3. Error state keys (handleErrorStateKeyMsg)
-// 4. Modal keys (handleModalKeyMsg)
-// 5. Global keys (handleGlobalKeyMsg)
-// 6. Navigation keys (handleNavigationKeysMsg)
-// 7. Enter key (special handling with lock-in animation)
-// 8. Sidebar agent keys (HandleSidebarAgentKeysMsg)
+// 4. History view keys (handleHistoryKeyMsg)
+// 5. Launch queue keys (handleQueueKeyMsg)
+// 6. Ticket detail keys (handleTicketDetailKeyMsg)
+// 7. Ticket form keys (handleTicketFormKeyMsg)
+// 8. Agent prompt input keys (handleAgentPromptKeyMsg)
+// 9. Confirm screen edit keys (handleConfirmKeyMsg)
+// 10. Modal keys (handleModalKeyMsg)
+// 11. Global keys (handleGlobalKeyMsg)
+// 12. Ticket edit keys (handleTicketEditKeyMsg)
+// 13. Navigation keys (handleNavigationKeysMsg)
+// 14. Enter key (special handling with lock-in animation)
+// 15. Sidebar agent keys (HandleSidebarAgentKeysMsg)
 //
 // Caching Strategy:
 //
@@ -101,6 +112,30 @@ func (m UIModel) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 		return model, cmd, handled
 	}
 
+	if model, cmd, handled := m.handleHistoryKeyMsg(msg); handled {
+		return model, cmd, handled
+	}
+
+	if model, cmd, handled := m.handleQueueKeyMsg(msg); handled {
+		return model, cmd, handled
+	}
+
+	if model, cmd, handled := m.handleTicketDetailKeyMsg(msg); handled {
+		return model, cmd, handled
+	}
+
+	if model, cmd, handled := m.handleTicketFormKeyMsg(msg); handled {
+		return model, cmd, handled
+	}
+
+	if model, cmd, handled := m.handleAgentPromptKeyMsg(msg); handled {
+		return model, cmd, handled
+	}
+
+	if model, cmd, handled := m.handleConfirmKeyMsg(msg); handled {
+		return model, cmd, handled
+	}
+
 	if model, cmd, handled := m.handleModalKeyMsg(); handled {
 		return model, cmd, true
 	}
@@ -109,6 +144,10 @@ func (m UIModel) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
 		return model, cmd, true
 	}
 
+	if model, cmd, handled := m.handleTicketEditKeyMsg(msg); handled {
+		return model, cmd, true
+	}
+
 	if model, cmd, handled := m.handleNavigationKeysMsg(msg); handled {
 		return model, cmd, true
 	}
@@ -141,18 +180,21 @@ func (m *UIModel) updateKeyBindings() {
 			m.keys.Info.SetEnabled(false)
 			m.keys.Zoom.SetEnabled(false)
 			m.keys.Enter.SetEnabled(true)
+			m.setTicketEditKeysEnabled(false)
 		case FocusTickets:
 			m.keys.Back.SetEnabled(false)
 			m.keys.Refresh.SetEnabled(true)
 			m.keys.Info.SetEnabled(true)
 			m.keys.Zoom.SetEnabled(true)
 			m.keys.Enter.SetEnabled(true)
+			m.setTicketEditKeysEnabled(true)
 		default:
 			m.keys.Back.SetEnabled(true)
 			m.keys.Refresh.SetEnabled(false)
 			m.keys.Info.SetEnabled(false)
 			m.keys.Zoom.SetEnabled(false)
 			m.keys.Enter.SetEnabled(true)
+			m.setTicketEditKeysEnabled(false)
 		}
 		m.keys.ToggleSidebar.SetEnabled(true)
 		m.keys.ToggleTheme.SetEnabled(true)
@@ -164,6 +206,7 @@ func (m *UIModel) updateKeyBindings() {
 		m.keys.Zoom.SetEnabled(false)
 		m.keys.ToggleSidebar.SetEnabled(false)
 		m.keys.ToggleTheme.SetEnabled(false)
+		m.setTicketEditKeysEnabled(false)
 	default:
 		m.keys.Back.SetEnabled(true)
 		m.keys.Refresh.SetEnabled(false)
@@ -172,9 +215,17 @@ func (m *UIModel) updateKeyBindings() {
 		m.keys.Zoom.SetEnabled(false)
 		m.keys.ToggleSidebar.SetEnabled(false)
 		m.keys.ToggleTheme.SetEnabled(true)
+		m.setTicketEditKeysEnabled(false)
 	}
 }
 
+func (m *UIModel) setTicketEditKeysEnabled(enabled bool) {
+	m.keys.NewTicket.SetEnabled(enabled)
+	m.keys.EditTicket.SetEnabled(enabled)
+	m.keys.PriorityUp.SetEnabled(enabled)
+	m.keys.PriorityDown.SetEnabled(enabled)
+}
+
 func updateListCaches(m *UIModel) UIModel {
 	if m.dirtyTicket || !m.initializedTicket {
 		m.ticketViewCache = m.ticketList.View()
@@ -1,11 +1,50 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
+// computeLayout computes the layout for a terminal size, docking the ticket
+// detail pane when it is open in zoom mode.
+func (m UIModel) computeLayout(termW, termH int) LayoutDimensions {
+	layout := Compute(termW, termH, m.showSidebar, m.ticketZoomEnabled)
+	if m.ticketZoomEnabled && m.detailDocked {
+		layout = layout.WithDetailPane()
+	}
+	return layout
+}
+
+// ticketDetailChrome is the number of lines the full-screen detail view
+// uses for its header and footer.
+const ticketDetailChrome = 4
+
+// detailPaneWidth returns the width the ticket detail is rendered at, or 0
+// when it is not shown.
+func (m UIModel) detailPaneWidth() int {
+	switch {
+	case m.state == ViewStateTicketDetail:
+		return m.layout.Width - 2 // search gutter
+	case m.layout.DWidth > 0:
+		return m.layout.DWidth - 4 // border and padding
+	}
+	return 0
+}
+
+// detailPaneHeight returns the number of ticket detail lines shown at once.
+func (m UIModel) detailPaneHeight() int {
+	h := m.layout.InnerListHeight
+	if m.state == ViewStateTicketDetail {
+		h = m.layout.Height - ticketDetailChrome
+	}
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
 func (m *UIModel) updateSizes() {
 	if m.layout.Width == 0 || m.layout.Height == 0 {
 		return
@@ -29,6 +68,7 @@ func (m *UIModel) updateSizes() {
 	m.agentList.SetSize(safeW(m.layout.AWidth), m.layout.InnerListHeight)
 	m.sidebar.SetSize(m.layout.SidebarWidth, m.layout.Height)
 	m.help.Width = m.layout.Width
+	m.detail.layout(m.detailPaneWidth())
 }
 
 func (m UIModel) getThemeValue() ThemePalette {
@@ -44,9 +84,6 @@ func (m UIModel) renderMainContent() string {
 		Focus:              m.focus,
 		ViewingAgentID:     m.viewingAgentID,
 		Selection:          m.selection,
-		Renderer:           m.app.Renderer,
-		DryRun:             m.app.Opts.DryRun,
-		SelectedWorktree:   m.selectedWorktree,
 		CurrentTheme:       m.getThemeValue(),
 		ShowModal:          m.showModal,
 		ModalContent:       m.modalContent,
@@ -58,11 +95,108 @@ func (m UIModel) renderMainContent() string {
 		RetryStore:         m.retryStore,
 		MatrixConfig:       m.buildMatrixConfig(),
 		Agent:              m.agents[m.viewingAgentID],
+		History:            HistoryConfig{Sessions: m.history, Err: m.historyErr, Offset: m.historyOffset},
+		Queue:              m.queueConfig(),
+		TicketDetail:       m.ticketDetailConfig(),
+		AgentPrompt:        m.agentPromptConfig(),
+		TicketForm:         m.ticketFormConfig(),
+		Confirm:            m.confirmConfig(),
 		Filepicker:         m.filepicker,
 		AnimState:          m.animState,
 	})
 }
 
+// agentPromptConfig only renders the text input while it is open; the zero
+// textinput.Model is not meant to be rendered.
+func (m UIModel) agentPromptConfig() AgentPromptConfig {
+	if m.state != ViewStateAgentPrompt {
+		return AgentPromptConfig{}
+	}
+	return AgentPromptConfig{Agent: m.agents[m.promptAgentID], Input: m.agentPrompt.View()}
+}
+
+// queueConfig only renders the launch queue while it is open.
+func (m UIModel) queueConfig() QueueConfig {
+	if m.state != ViewStateQueue {
+		return QueueConfig{}
+	}
+	running := m.runningSlots()
+	cfg := QueueConfig{Queue: m.queue, Cursor: m.queueCursor, Running: len(running)}
+	if m.app != nil {
+		cfg.Limits = m.app.Limits()
+	}
+	for _, q := range m.queue {
+		reason := cfg.Limits.Check(m.harnessNamed(q.HarnessName), q.Model, running)
+		if q.Failed != "" {
+			reason = "failed: " + q.Failed
+		}
+		cfg.Reasons = append(cfg.Reasons, reason)
+	}
+	return cfg
+}
+
+// confirmConfig only renders the launch spec while the confirm screen is
+// open, since rendering runs the harness templates.
+func (m UIModel) confirmConfig() ConfirmConfig {
+	if m.state != ViewStateConfirm {
+		return ConfirmConfig{}
+	}
+	cfg := ConfirmConfig{
+		Selection: m.selection,
+		DryRun:    m.app.Opts.DryRun,
+		WorkDir:   m.selectedWorktree,
+		Edits:     m.launchEdit.status(m.selection.Harness),
+
+		ContextLoading: m.launchEdit.contextLoading,
+	}
+	if m.launchEdit.context != nil {
+		cfg.Context = m.launchEdit.context.Sections
+	}
+	if m.app.Renderer != nil {
+		cfg.Spec, cfg.SpecErr = m.launchSpec()
+	}
+	if m.launchEdit.naming {
+		cfg.NameInput = m.launchEdit.snippetName.View()
+	}
+	return cfg
+}
+
+// ticketFormConfig only renders the form while it is open.
+func (m UIModel) ticketFormConfig() TicketFormConfig {
+	if m.state != ViewStateTicketForm {
+		return TicketFormConfig{}
+	}
+	return TicketFormConfig{
+		Title:  m.ticketForm.title(),
+		Rows:   m.ticketForm.rows(),
+		Err:    m.ticketForm.err,
+		Saving: m.ticketForm.saving,
+	}
+}
+
+// ticketDetailConfig only renders the detail view while it is open.
+func (m UIModel) ticketDetailConfig() TicketDetailConfig {
+	if m.state != ViewStateTicketDetail {
+		return TicketDetailConfig{}
+	}
+	cfg := TicketDetailConfig{
+		TicketID: m.detail.ticketID,
+		Lines:    m.detail.view(m.detailPaneHeight(), m.detail.query != "", m.getThemeValue()),
+		Search:   m.detail.searchStatus(),
+	}
+	if n := len(m.detail.lines); n > 0 {
+		end := m.detail.offset + m.detailPaneHeight()
+		if end > n {
+			end = n
+		}
+		cfg.Position = fmt.Sprintf("%d-%d/%d", m.detail.offset+1, end, n)
+	}
+	if m.detail.searching {
+		cfg.SearchInput = m.detail.search.View()
+	}
+	return cfg
+}
+
 func (m UIModel) buildMatrixConfig() MatrixConfig {
 	var theme ThemePalette
 	if m.currentTheme != nil {
@@ -79,6 +213,7 @@ func (m UIModel) buildMatrixConfig() MatrixConfig {
 		HWidth:              m.layout.HWidth,
 		MWidth:              m.layout.MWidth,
 		AWidth:              m.layout.AWidth,
+		DWidth:              m.layout.DWidth,
 		ModelColumnDisabled: m.modelColumnDisabled,
 		AgentColumnDisabled: m.agentColumnDisabled,
 		Focus:               m.focus,
@@ -94,6 +229,9 @@ func (m UIModel) buildMatrixConfig() MatrixConfig {
 		ModelTitle:          m.modelList.Title,
 		AgentTitle:          m.agentList.Title,
 	}
+	if cfg.DWidth > 0 {
+		cfg.DetailView = strings.Join(m.detail.view(m.detailPaneHeight(), false, theme), "\n")
+	}
 
 	if m.hoveredAgentID != "" {
 		if agent, ok := m.agents[m.hoveredAgentID]; ok && agent != nil && agent.Info != nil {
@@ -155,6 +293,9 @@ func (m UIModel) View() string {
 		}
 		helpView = refreshIcon + " Tickets refreshed  " + helpView
 	}
+	if len(m.queue) > 0 {
+		helpView = fmt.Sprintf("⏸ %d queued  ", len(m.queue)) + helpView
+	}
 
 	helpView = footerStyle.Render(helpView)
 
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// applyProjectConfig layers the active project's .blunderbust.yaml over the
+// global harnesses and defaults once the project has changed, and
+// preselects the defaults. An invalid project config is reported and the
+// global config is used instead.
+func (m UIModel) applyProjectConfig() (UIModel, tea.Cmd) {
+	if m.app == nil || m.app.ActiveProject == m.configProject {
+		return m, nil
+	}
+	m.configProject = m.app.ActiveProject
+	m.configStamps = nil
+
+	var cmd tea.Cmd
+	layer, err := m.app.ProjectLayer(m.configProject)
+	if err != nil {
+		cmd = warningCmd(fmt.Errorf("project config ignored: %w", err))
+	}
+	// Rebuild the column even without a layer: when: conditions may depend
+	// on the project.
+	m.projectLayered = layer != nil
+	m = m.setHarnesses(layer.ApplyHarnesses(m.baseHarnesses))
+	return m.selectDefaults(layer.ApplyDefaults(m.baseDefaults)), cmd
+}
+
+// selectDefaults selects the default harness, model and agent in their
+// columns. A default that is not shown, or a harness that is unavailable,
+// leaves the selection as it is.
+func (m UIModel) selectDefaults(d domain.Defaults) UIModel {
+	if d.Harness != "" {
+		for i, item := range m.harnessList.Items() {
+			if hi, ok := item.(harnessItem); ok && hi.harness.Name == d.Harness && hi.unavailable == "" {
+				m.harnessList.Select(i)
+				m.selection.Harness = hi.harness
+				m, _ = m.handleModelSkip()
+				m, _ = m.handleAgentSkip()
+				break
+			}
+		}
+	}
+	if d.Model != "" {
+		for i, item := range m.modelList.Items() {
+			if mi, ok := item.(modelItem); ok && mi.name == d.Model {
+				m.modelList.Select(i)
+				m.selection.Model = d.Model
+				break
+			}
+		}
+	}
+	if d.Agent != "" {
+		for i, item := range m.agentList.Items() {
+			if ai, ok := item.(agentItem); ok && ai.name == d.Agent {
+				m.agentList.Select(i)
+				m.selection.Agent = d.Agent
+				break
+			}
+		}
+	}
+	m.dirtyHarness = true
+	m.dirtyModel = true
+	m.dirtyAgent = true
+	return m
+}
+
+// setHarnesses replaces the harness column, keeping the selected harness by
+// name if it is still shown. Harnesses hidden by their when: conditions are
+// left out of the column.
+func (m UIModel) setHarnesses(harnesses []domain.Harness) UIModel {
+	selected := m.selection.Harness.Name
+	m.harnesses = harnesses
+
+	items := harnessItems(harnesses, m.app.Registry, m.harnessWhenFacts())
+	index := 0
+	for i, item := range items {
+		if item.(harnessItem).harness.Name == selected {
+			index = i
+		}
+	}
+	m.harnessList.SetItems(items)
+	m.harnessList.Select(index)
+
+	m.selection.Harness = domain.Harness{}
+	if i, ok := m.harnessList.SelectedItem().(harnessItem); ok {
+		m.selection.Harness = i.harness
+		m, _ = m.handleModelSkip()
+		m, _ = m.handleAgentSkip()
+	}
+	m.dirtyHarness = true
+	m.dirtyModel = true
+	m.dirtyAgent = true
+	return m
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func TestApplyProjectConfig(t *testing.T) {
+	layered := t.TempDir()
+	plain := t.TempDir()
+	layer := "harnesses:\n  - name: claude\n    command_template: claude --project\n  - name: aider\n    command_template: aider\n"
+	require.NoError(t, os.WriteFile(filepath.Join(layered, config.ProjectConfigFile), []byte(layer), 0o644))
+
+	myApp := newTestApp()
+	myApp.Opts.ConfigPath = filepath.Join(t.TempDir(), "config.yaml")
+	m := NewUIModel(myApp, []domain.Harness{
+		{Name: "opencode", CommandTemplate: "opencode"},
+		{Name: "claude", CommandTemplate: "claude"},
+	})
+	m.harnessList.Select(1)
+	m.selection.Harness = m.harnesses[1]
+
+	myApp.ActiveProject = layered
+	m, cmd := m.applyProjectConfig()
+	assert.Nil(t, cmd)
+	require.Len(t, m.harnesses, 3)
+	assert.Equal(t, "claude --project", m.selection.Harness.CommandTemplate, "the selected harness keeps its place")
+	assert.Len(t, m.harnessList.Items(), 3)
+
+	myApp.ActiveProject = plain
+	m, _ = m.applyProjectConfig()
+	require.Len(t, m.harnesses, 2)
+	assert.Equal(t, "claude", m.selection.Harness.CommandTemplate, "leaving the project restores the global harnesses")
+}
+
+func TestApplyProjectConfig_Defaults(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.ProjectConfigFile), []byte("defaults:\n  model: opus\n"), 0o644))
+
+	myApp := newTestApp()
+	myApp.Opts.Defaults = domain.Defaults{Harness: "claude", Model: "sonnet", Agent: "coder"}
+	m := NewUIModel(myApp, []domain.Harness{
+		{Name: "opencode", CommandTemplate: "opencode"},
+		{Name: "claude", CommandTemplate: "claude", SupportedModels: []string{"sonnet", "opus"}, SupportedAgents: []string{"task", "coder"}},
+	})
+
+	myApp.ActiveProject = dir
+	m, cmd := m.applyProjectConfig()
+	assert.Nil(t, cmd)
+	assert.Equal(t, "claude", m.selection.Harness.Name)
+	assert.Equal(t, "opus", m.selection.Model, "the project's default model replaces the global one")
+	assert.Equal(t, "coder", m.selection.Agent)
+	assert.Equal(t, "opus", m.modelList.SelectedItem().(modelItem).name)
+	assert.Equal(t, "coder", m.agentList.SelectedItem().(agentItem).name)
+}
+
+func TestApplyProjectConfig_InvalidLayer(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.ProjectConfigFile), []byte("harnesses: [{name: x}]\n"), 0o644))
+
+	myApp := newTestApp()
+	m := NewUIModel(myApp, []domain.Harness{{Name: "claude", CommandTemplate: "claude"}})
+	myApp.ActiveProject = dir
+	m, cmd := m.applyProjectConfig()
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(warningMsg)
+	require.True(t, ok)
+	assert.Contains(t, msg.err.Error(), "project config ignored")
+	assert.Len(t, m.harnesses, 1)
+}
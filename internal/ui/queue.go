@@ -0,0 +1,278 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// runningSlots returns the agents counted against the concurrency limits:
+// this host's running agents and the launches under way.
+func (m UIModel) runningSlots() []domain.AgentSlot {
+	slots := append([]domain.AgentSlot(nil), m.starting...)
+	for _, agent := range m.agents {
+		if agent.Info.Status == domain.AgentRunning && !agent.Info.Remote {
+			slots = append(slots, domain.AgentSlot{Harness: agent.Info.HarnessName, Model: agent.Info.ModelName})
+		}
+	}
+	return slots
+}
+
+// limitReason returns why an agent of harness h with model cannot start
+// now, or "" if it can.
+func (m UIModel) limitReason(h domain.Harness, model string) string {
+	if m.app == nil {
+		return ""
+	}
+	return m.app.Limits().Check(h, model, m.runningSlots())
+}
+
+// harnessNamed returns the configured harness called name, or a harness
+// with only the name when it is no longer configured.
+func (m UIModel) harnessNamed(name string) domain.Harness {
+	for _, h := range m.harnesses {
+		if h.Name == name {
+			return h
+		}
+	}
+	return domain.Harness{Name: name}
+}
+
+// launchSelection launches the confirmed selection, or queues it when a
+// concurrency limit is reached.
+func (m UIModel) launchSelection() (UIModel, tea.Cmd) {
+	if m.limitReason(m.selection.Harness, m.selection.Model) == "" {
+		m.starting = append(m.starting, domain.AgentSlot{Harness: m.selection.Harness.Name, Model: m.selection.Model})
+		return m, m.launchCmd()
+	}
+
+	spec, err := m.launchSpec()
+	if err != nil {
+		m.err = fmt.Errorf("failed to render launch spec: %w", err)
+		m.state = ViewStateError
+		return m, nil
+	}
+	q := domain.QueuedLaunch{
+		ID:       m.nextQueueID(),
+		QueuedAt: time.Now(),
+
+		ProjectDir:   activeProjectDir(m.app),
+		WorktreePath: m.selectedWorktree,
+		TicketID:     m.selection.Ticket.ID,
+		TicketTitle:  m.selection.Ticket.Title,
+		HarnessName:  m.selection.Harness.Name,
+		Model:        m.selection.Model,
+		Agent:        m.selection.Agent,
+		EnvFile:      m.selection.ProjectEnvFile,
+
+		RenderedCommand: spec.RenderedCommand,
+		RenderedPrompt:  spec.RenderedPrompt,
+		LauncherID:      m.selection.Ticket.ID,
+		WorkDir:         spec.WorkDir,
+		PreLaunch:       spec.PreLaunch,
+		PostExit:        spec.PostExit,
+	}
+	m.queue = append(m.queue, q)
+	return m, m.saveLaunchQueueCmd()
+}
+
+func (m UIModel) nextQueueID() int {
+	id := 1
+	for _, q := range m.queue {
+		if q.ID >= id {
+			id = q.ID + 1
+		}
+	}
+	return id
+}
+
+// queuedLaunchSpec rebuilds the LaunchSpec of a queued launch. Like for a
+// restart, the harness environment is taken from the current config.
+func queuedLaunchSpec(q domain.QueuedLaunch, harness domain.Harness) domain.LaunchSpec {
+	return domain.LaunchSpec{
+		Selection: domain.Selection{
+			Ticket:         domain.Ticket{ID: q.TicketID, Title: q.TicketTitle},
+			Harness:        harness,
+			Model:          q.Model,
+			Agent:          q.Agent,
+			ProjectEnvFile: q.EnvFile,
+		},
+		RenderedCommand: q.RenderedCommand,
+		RenderedPrompt:  q.RenderedPrompt,
+		LauncherID:      q.LauncherID,
+		WorkDir:         q.WorkDir,
+		PreLaunch:       q.PreLaunch,
+		PostExit:        q.PostExit,
+	}
+}
+
+// dispatchQueue starts the queued launches the concurrency limits allow, in
+// queue order. A launch held back by its harness's or model's limit does
+// not hold back the ones behind it. Failed launches wait for a retry.
+func (m UIModel) dispatchQueue() (UIModel, tea.Cmd) {
+	if len(m.queue) == 0 || m.app == nil || m.app.Launcher == nil {
+		return m, nil
+	}
+	var cmds []tea.Cmd
+	remaining := make([]domain.QueuedLaunch, 0, len(m.queue))
+	for _, q := range m.queue {
+		harness := m.harnessNamed(q.HarnessName)
+		if q.Failed != "" || m.limitReason(harness, q.Model) != "" {
+			remaining = append(remaining, q)
+			continue
+		}
+		slot := q.Slot()
+		m.starting = append(m.starting, slot)
+		cmds = append(cmds, startLaunchCmd(m.app, queuedLaunchSpec(q, harness), launchResultMsg{
+			slot:         &slot,
+			projectDir:   q.ProjectDir,
+			worktreePath: q.WorktreePath,
+			queued:       &q,
+		}))
+	}
+	if len(cmds) == 0 {
+		return m, nil
+	}
+	m.queue = remaining
+	m.clampQueueCursor()
+	return m, tea.Batch(append(cmds, m.saveLaunchQueueCmd())...)
+}
+
+// requeueFailed puts a queued launch that failed to start back at the
+// head of the queue, marked failed, so that it can be retried or cancelled.
+func (m UIModel) requeueFailed(q domain.QueuedLaunch, err error) (UIModel, tea.Cmd) {
+	q.Failed = err.Error()
+	m.queue = append([]domain.QueuedLaunch{q}, m.queue...)
+	if m.queueCursor > 0 {
+		m.queueCursor++
+	}
+	return m, m.saveLaunchQueueCmd()
+}
+
+// removeAgentSlot removes one slot equal to slot.
+func removeAgentSlot(slots []domain.AgentSlot, slot domain.AgentSlot) []domain.AgentSlot {
+	for i, s := range slots {
+		if s == slot {
+			return append(slots[:i:i], slots[i+1:]...)
+		}
+	}
+	return slots
+}
+
+func (m *UIModel) clampQueueCursor() {
+	if m.queueCursor >= len(m.queue) {
+		m.queueCursor = len(m.queue) - 1
+	}
+	if m.queueCursor < 0 {
+		m.queueCursor = 0
+	}
+}
+
+// saveLaunchQueueCmd saves the queue so it survives a restart. Until the
+// last session's queue is loaded, saving would overwrite it.
+func (m UIModel) saveLaunchQueueCmd() tea.Cmd {
+	if !m.queueLoaded || m.app == nil {
+		return nil
+	}
+	myApp := m.app
+	queue := append([]domain.QueuedLaunch(nil), m.queue...)
+	return func() tea.Msg {
+		if err := myApp.SaveLaunchQueue(queue); err != nil {
+			return warningMsg{err: err}
+		}
+		return nil
+	}
+}
+
+func loadLaunchQueueCmd(myApp *app.App) tea.Cmd {
+	return func() tea.Msg {
+		queue, err := myApp.LoadLaunchQueue()
+		return launchQueueLoadedMsg{queue: queue, err: err}
+	}
+}
+
+// handleLaunchQueueLoaded puts the last session's queue ahead of anything
+// queued since and dispatches what the limits allow. It is loaded after the
+// running agents, so they count against the limits.
+func (m UIModel) handleLaunchQueueLoaded(msg launchQueueLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.queueLoaded {
+		return m, nil
+	}
+	if msg.err != nil {
+		// The queue is not saved this session rather than overwritten.
+		m, cmd := m.dispatchQueue()
+		return m, tea.Batch(cmd, warningCmd(msg.err))
+	}
+	m.queueLoaded = true
+	queued := m.queue
+	m.queue = msg.queue
+	for _, q := range queued {
+		q.ID = m.nextQueueID()
+		m.queue = append(m.queue, q)
+	}
+	m, cmd := m.dispatchQueue()
+	return m, tea.Batch(cmd, m.saveLaunchQueueCmd())
+}
+
+// handleQueueKeyMsg handles keys while the launch queue is open: ↑/↓ move
+// the cursor, K/J or shift+↑/↓ move the launch under it up or down the
+// queue, r retries it when it failed and d, x or delete cancel it. All keys are consumed so the matrix
+// underneath does not react.
+func (m UIModel) handleQueueKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	if m.state != ViewStateQueue {
+		return m, nil, false
+	}
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit, true
+	case "esc", "q", "Q":
+		m.state = ViewStateMatrix
+	case "up", "k":
+		if m.queueCursor > 0 {
+			m.queueCursor--
+		}
+	case "down", "j":
+		if m.queueCursor < len(m.queue)-1 {
+			m.queueCursor++
+		}
+	case "K", "shift+up":
+		if m.queueCursor > 0 && m.queueCursor < len(m.queue) {
+			m.queue = moveQueued(m.queue, m.queueCursor, m.queueCursor-1)
+			m.queueCursor--
+			return m, m.saveLaunchQueueCmd(), true
+		}
+	case "J", "shift+down":
+		if m.queueCursor < len(m.queue)-1 {
+			m.queue = moveQueued(m.queue, m.queueCursor, m.queueCursor+1)
+			m.queueCursor++
+			return m, m.saveLaunchQueueCmd(), true
+		}
+	case "r":
+		if m.queueCursor < len(m.queue) && m.queue[m.queueCursor].Failed != "" {
+			m.queue = append([]domain.QueuedLaunch(nil), m.queue...)
+			m.queue[m.queueCursor].Failed = ""
+			m, cmd := m.dispatchQueue()
+			return m, tea.Batch(cmd, m.saveLaunchQueueCmd()), true
+		}
+	case "d", "x", "delete":
+		if m.queueCursor < len(m.queue) {
+			queue := append([]domain.QueuedLaunch(nil), m.queue[:m.queueCursor]...)
+			m.queue = append(queue, m.queue[m.queueCursor+1:]...)
+			m.clampQueueCursor()
+			return m, m.saveLaunchQueueCmd(), true
+		}
+	}
+	return m, nil, true
+}
+
+// moveQueued returns a copy of queue with the launch at i swapped with the
+// one at j.
+func moveQueued(queue []domain.QueuedLaunch, i, j int) []domain.QueuedLaunch {
+	moved := append([]domain.QueuedLaunch(nil), queue...)
+	moved[i], moved[j] = moved[j], moved[i]
+	return moved
+}
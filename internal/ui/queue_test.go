@@ -0,0 +1,218 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/config"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// newQueueTestModel returns a confirm screen model with one claude agent
+// running and room for one agent at a time.
+func newQueueTestModel(t *testing.T) UIModel {
+	t.Helper()
+	m := newConfirmTestModel(t)
+	m.app.Renderer = config.NewRenderer()
+	m.app.Opts.Limits = domain.ConcurrencyLimits{Agents: 1}
+	m.queueLoaded = true
+	m.agents = map[string]*RunningAgent{
+		"agent-1": {Info: &domain.AgentInfo{ID: "agent-1", Name: "bb-0", Status: domain.AgentRunning, HarnessName: "claude"}},
+	}
+	return m
+}
+
+// runLaunchCmds runs cmd and any batched commands, returning the launch
+// results among their messages.
+func runLaunchCmds(cmd tea.Cmd) []launchResultMsg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		var results []launchResultMsg
+		for _, c := range msg {
+			results = append(results, runLaunchCmds(c)...)
+		}
+		return results
+	case launchResultMsg:
+		return []launchResultMsg{msg}
+	}
+	return nil
+}
+
+func TestLaunchSelection_QueuesAtLimit(t *testing.T) {
+	m := newQueueTestModel(t)
+
+	newModel, cmd := m.handleEnterKey()
+	m = newModel.(UIModel)
+	assert.Empty(t, runLaunchCmds(cmd), "nothing is launched over the limit")
+	assert.Equal(t, ViewStateMatrix, m.state)
+	require.Len(t, m.queue, 1)
+	q := m.queue[0]
+	assert.Equal(t, "bb-1", q.TicketID)
+	assert.Equal(t, "claude", q.HarnessName)
+	assert.Equal(t, `claude -p "Work on bb-1"`, q.RenderedCommand)
+	assert.Equal(t, "/work", q.WorkDir)
+	assert.Contains(t, ansi.Strip(m.View()), "1 queued")
+
+	// The agent ending frees the slot for the queued launch.
+	newModel, cmd = m.HandleAgentStatus(AgentStatusMsg{AgentID: "agent-1", Status: domain.AgentCompleted})
+	m = newModel.(UIModel)
+	assert.Empty(t, m.queue)
+	assert.Equal(t, []domain.AgentSlot{{Harness: "claude"}}, m.starting)
+
+	results := runLaunchCmds(cmd)
+	require.Len(t, results, 1)
+	result := results[0]
+	require.NotNil(t, result.queued)
+	assert.Equal(t, "bb-1", result.queued.TicketID)
+	assert.Equal(t, "/work", result.worktreePath)
+	require.NotNil(t, result.spec)
+	assert.Equal(t, `claude -p "Work on bb-1"`, result.spec.RenderedCommand)
+
+	m.state = ViewStateQueue
+	newModel, _ = m.handleLaunchResult(result)
+	m = newModel.(UIModel)
+	assert.Empty(t, m.starting)
+	assert.Equal(t, ViewStateQueue, m.state, "a queued launch does not change the view")
+	require.Contains(t, m.agents, "mock-launcher")
+	assert.Equal(t, "/work", m.agents["mock-launcher"].Info.WorktreePath)
+}
+
+func TestHandleLaunchResult_FailedQueuedLaunchStaysQueued(t *testing.T) {
+	m := newQueueTestModel(t)
+	m.queue = []domain.QueuedLaunch{{ID: 2, TicketID: "bb-2", HarnessName: "claude"}}
+	q := domain.QueuedLaunch{ID: 1, TicketID: "bb-1", HarnessName: "claude"}
+	slot := q.Slot()
+	m.starting = []domain.AgentSlot{slot}
+	delete(m.agents, "agent-1")
+
+	newModel, cmd := m.handleLaunchResult(launchResultMsg{slot: &slot, queued: &q, err: errors.New("no such worktree")})
+	m = newModel.(UIModel)
+	require.Len(t, m.queue, 1, "bb-2 starts in the freed slot")
+	assert.Equal(t, "bb-1", m.queue[0].TicketID)
+	assert.Equal(t, "no such worktree", m.queue[0].Failed)
+	assert.Len(t, runLaunchCmds(cmd), 1)
+
+	// A failed launch is not started again until it is retried.
+	m.starting = nil
+	m, cmd = m.dispatchQueue()
+	assert.Nil(t, cmd)
+	m.state = ViewStateQueue
+	assert.Contains(t, ansi.Strip(m.View()), "failed: no such worktree")
+
+	newModel, cmd, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = newModel.(UIModel)
+	assert.Empty(t, m.queue)
+	assert.Len(t, runLaunchCmds(cmd), 1)
+}
+
+func TestConfigReload_RaisedLimitDispatchesQueue(t *testing.T) {
+	m := newQueueTestModel(t)
+	newModel, _ := m.handleEnterKey()
+	m = newModel.(UIModel)
+	require.Len(t, m.queue, 1)
+
+	newModel, cmd := m.handleConfigChecked(configCheckedMsg{
+		project: m.configProject,
+		changed: true,
+		cfg: &domain.Config{
+			Harnesses: []domain.Harness{m.selection.Harness},
+			General:   &domain.GeneralConfig{MaxConcurrentAgents: 2},
+		},
+	})
+	m = newModel.(UIModel)
+	assert.Equal(t, domain.ConcurrencyLimits{Agents: 2}, m.app.Limits())
+	assert.Empty(t, m.queue, "the raised limit starts the queued launch")
+	assert.Equal(t, []domain.AgentSlot{{Harness: "claude"}}, m.starting)
+	assert.Len(t, runLaunchCmds(cmd), 1)
+}
+
+func TestLaunchSelection_UnderLimit(t *testing.T) {
+	m := newQueueTestModel(t)
+	m.app.Opts.Limits = domain.ConcurrencyLimits{Agents: 2}
+
+	newModel, cmd := m.handleEnterKey()
+	m = newModel.(UIModel)
+	assert.Empty(t, m.queue)
+	require.Len(t, m.starting, 1)
+
+	// The launch under way counts against the limit.
+	assert.Equal(t, "2 of 2 agents running", m.limitReason(m.selection.Harness, ""))
+
+	results := runLaunchCmds(cmd)
+	require.Len(t, results, 1)
+	assert.Nil(t, results[0].queued)
+	newModel, _ = m.handleLaunchResult(results[0])
+	assert.Empty(t, newModel.(UIModel).starting)
+}
+
+func TestHandleQueueKeyMsg_ReorderAndCancel(t *testing.T) {
+	m := NewUIModel(newTestApp(), nil)
+	m.queueLoaded = true
+	m.state = ViewStateQueue
+	m.queue = []domain.QueuedLaunch{{ID: 1, TicketID: "bb-1"}, {ID: 2, TicketID: "bb-2"}, {ID: 3, TicketID: "bb-3"}}
+
+	press := func(k string) {
+		t.Helper()
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		if k == "down" {
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		}
+		newModel, _, handled := m.handleKeyMsg(msg)
+		require.True(t, handled)
+		m = newModel.(UIModel)
+	}
+	tickets := func() []string {
+		var ids []string
+		for _, q := range m.queue {
+			ids = append(ids, q.TicketID)
+		}
+		return ids
+	}
+
+	press("down")
+	press("J")
+	assert.Equal(t, []string{"bb-1", "bb-3", "bb-2"}, tickets())
+	assert.Equal(t, 2, m.queueCursor)
+	press("K")
+	press("K")
+	assert.Equal(t, []string{"bb-2", "bb-1", "bb-3"}, tickets())
+	assert.Equal(t, 0, m.queueCursor)
+
+	press("d")
+	assert.Equal(t, []string{"bb-1", "bb-3"}, tickets())
+	assert.Contains(t, ansi.Strip(m.View()), "bb-3")
+
+	press("q")
+	assert.Equal(t, ViewStateMatrix, m.state)
+}
+
+func TestHandleLaunchQueueLoaded_MergesAndDispatches(t *testing.T) {
+	m := newQueueTestModel(t)
+	m.queueLoaded = false
+	m.queue = []domain.QueuedLaunch{{ID: 1, TicketID: "bb-new", HarnessName: "claude"}}
+
+	saved := []domain.QueuedLaunch{
+		{ID: 1, TicketID: "bb-old", HarnessName: "codex", RenderedCommand: "codex"},
+		{ID: 2, TicketID: "bb-older", HarnessName: "codex", RenderedCommand: "codex"},
+	}
+	newModel, _ := m.handleLaunchQueueLoaded(launchQueueLoadedMsg{queue: saved})
+	m = newModel.(UIModel)
+	assert.True(t, m.queueLoaded)
+	require.Len(t, m.queue, 3, "nothing starts while the agent runs")
+	assert.Equal(t, "bb-old", m.queue[0].TicketID)
+	assert.Equal(t, "bb-new", m.queue[2].TicketID)
+	assert.Equal(t, 3, m.queue[2].ID)
+
+	m.app.Opts.Limits = domain.ConcurrencyLimits{Agents: 2}
+	m, cmd := m.dispatchQueue()
+	require.Len(t, runLaunchCmds(cmd), 1)
+	assert.Equal(t, "bb-older", m.queue[0].TicketID)
+}
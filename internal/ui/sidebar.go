@@ -35,6 +35,9 @@ type SidebarState struct {
 	Cursor       int
 	SelectedPath string
 
+	// AttentionOnly hides agent nodes that do not need attention.
+	AttentionOnly bool
+
 	FlatNodes []FlatNodeInfo
 }
 
@@ -67,6 +70,10 @@ func (s *SidebarState) rebuildFlatNodes() {
 }
 
 func (s *SidebarState) flattenNode(node *domain.SidebarNode, depth int) {
+	if s.AttentionOnly && node.Type == domain.NodeTypeAgent &&
+		(node.AgentInfo == nil || node.AgentInfo.Attention == domain.AttentionNone) {
+		return
+	}
 	s.FlatNodes = append(s.FlatNodes, FlatNodeInfo{
 		Node:    node,
 		Depth:   depth,
@@ -80,6 +87,18 @@ func (s *SidebarState) flattenNode(node *domain.SidebarNode, depth int) {
 	}
 }
 
+// ToggleAttentionFilter switches between showing all agents and only those
+// needing attention, keeping the cursor on the same node when it is visible.
+func (s *SidebarState) ToggleAttentionFilter() {
+	prevPath := ""
+	if node := s.CurrentNode(); node != nil {
+		prevPath = node.Path
+	}
+	s.AttentionOnly = !s.AttentionOnly
+	s.rebuildFlatNodes()
+	RestoreSidebarCursorByPath(s, prevPath)
+}
+
 // VisibleNodes returns the flattened list of all visible nodes.
 func (s *SidebarState) VisibleNodes() []FlatNodeInfo {
 	return s.FlatNodes
@@ -272,10 +291,29 @@ var (
 				Foreground(lipgloss.AdaptiveColor{Light: "88", Dark: "88"}).
 				Bold(true)
 
+	// attentionBadgeStyle is used for the badge of agents needing attention.
+	attentionBadgeStyle = lipgloss.NewStyle().
+				Foreground(ThemeWarning).
+				Bold(true)
+
+	// agentHostStyle is used for the owner@host label of remote agents.
+	agentHostStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.AdaptiveColor{Light: "245", Dark: "245"}).
+			Faint(true)
+
 	// agentCompletedStyle is used for agents that completed successfully.
 	agentCompletedStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.AdaptiveColor{Light: "245", Dark: "245"}).
 				Italic(true)
+
+	// verifyPassedStyle and verifyFailedStyle are used for the badge of an
+	// agent's verify_command result.
+	verifyPassedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.AdaptiveColor{Light: "34", Dark: "34"}).
+				Bold(true)
+	verifyFailedStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.AdaptiveColor{Light: "160", Dark: "160"}).
+				Bold(true)
 )
 
 // SidebarModel is a bubbletea model that renders a tree view of projects,
@@ -350,6 +388,8 @@ func (m SidebarModel) handleKey(msg tea.KeyMsg) (SidebarModel, tea.Cmd) {
 		}
 	case key.Matches(msg, sidebarKeys.AddProject):
 		return m, OpenFilePickerCmd()
+	case key.Matches(msg, sidebarKeys.AttentionFilter):
+		m.state.ToggleAttentionFilter()
 	}
 	return m, nil
 }
@@ -559,33 +599,102 @@ func (m SidebarModel) renderHarnessName(node *domain.SidebarNode, name string, i
 
 // renderAgentName renders the agent name with a colored dot indicator.
 // Green for running, red for failed (with glitch effect), white/gray for completed.
+// Ended agents are followed by their verify_command badge, and agents on
+// other hosts by their owner@host.
 func (m SidebarModel) renderAgentName(node *domain.SidebarNode, name string, isCursor bool) string {
 	if node.AgentInfo == nil {
 		return name
 	}
 
+	badge := attentionBadge(node.AgentInfo)
+	verify := verifyBadge(node.AgentInfo)
+	host := ""
+	if node.AgentInfo.Remote {
+		host = node.AgentInfo.HostLabel()
+	}
+
 	if m.shouldApplyStyle(isCursor) {
+		var line string
 		switch node.AgentInfo.Status {
 		case domain.AgentRunning:
-			// Bold green dot for running agents
-			return agentRunningStyle.Render("● " + name)
+			// Bold green dot for running agents, followed by the attention badge
+			line = agentRunningStyle.Render("● " + name)
+			if badge != "" {
+				line += " " + attentionBadgeStyle.Render(badge)
+			}
+			if host != "" {
+				line += " " + agentHostStyle.Render(host)
+			}
+			return line
 		case domain.AgentFailed:
 			// Glitch effect: alternate between bright red and dark red
 			// Animation frame is incremented in Update() for pure View()
 			if m.animFrame%4 < 2 {
-				return agentFailedStyle.Render("● " + name)
+				line = agentFailedStyle.Render("● " + name)
+			} else {
+				line = agentFailedGlitchStyle.Render("● " + name)
 			}
-			return agentFailedGlitchStyle.Render("● " + name)
 		case domain.AgentCompleted:
 			// Italic gray for completed
-			return agentCompletedStyle.Render("● " + name)
+			line = agentCompletedStyle.Render("● " + name)
 		default:
-			return agentRunningStyle.Render("● " + name)
+			line = agentRunningStyle.Render("● " + name)
+		}
+		switch node.AgentInfo.Verify {
+		case domain.VerifyPassed:
+			line += " " + verifyPassedStyle.Render(verify)
+		case domain.VerifyFailed:
+			line += " " + verifyFailedStyle.Render(verify)
+		case domain.VerifyRunning:
+			line += " " + agentHostStyle.Render(verify)
 		}
+		return line
+	}
+	if badge != "" {
+		name += " " + badge
+	}
+	if verify != "" {
+		name += " " + verify
+	}
+	if host != "" {
+		name += " " + host
 	}
 	return name
 }
 
+// attentionBadge returns the sidebar badge for a running agent that needs
+// attention: ? waiting for approval, … idle, ! error.
+func attentionBadge(info *domain.AgentInfo) string {
+	if info.Status != domain.AgentRunning {
+		return ""
+	}
+	switch info.Attention {
+	case domain.AttentionApproval:
+		return "?"
+	case domain.AttentionIdle:
+		return "…"
+	case domain.AttentionError:
+		return "!"
+	default:
+		return ""
+	}
+}
+
+// verifyBadge returns the sidebar badge for an agent's verify_command
+// result: ✓ passed, ✗ failed, ◌ still running.
+func verifyBadge(info *domain.AgentInfo) string {
+	switch info.Verify {
+	case domain.VerifyPassed:
+		return "✓"
+	case domain.VerifyFailed:
+		return "✗"
+	case domain.VerifyRunning:
+		return "◌"
+	default:
+		return ""
+	}
+}
+
 // SetSize sets the dimensions of the sidebar.
 func (m *SidebarModel) SetSize(width, height int) {
 	m.width = width
@@ -686,6 +795,8 @@ var sidebarKeys = struct {
 	Expand     key.Binding
 	Collapse   key.Binding
 	AddProject key.Binding
+
+	AttentionFilter key.Binding
 }{
 	Up: key.NewBinding(
 		key.WithKeys("up", "k"),
@@ -711,4 +822,8 @@ var sidebarKeys = struct {
 		key.WithKeys("a"),
 		key.WithHelp("a", "add project"),
 	),
+	AttentionFilter: key.NewBinding(
+		key.WithKeys("!"),
+		key.WithHelp("!", "agents needing attention"),
+	),
 }
@@ -606,6 +606,81 @@ func TestSidebarModel_RenderHarnessName_NilInfo(t *testing.T) {
 	assert.Equal(t, "harness-1", name)
 }
 
+func TestSidebarModel_RenderAgentName_AttentionBadge(t *testing.T) {
+	m := NewSidebarModel()
+	m.SetFocused(true)
+
+	info := &domain.AgentInfo{ID: "a1", Status: domain.AgentRunning, Attention: domain.AttentionApproval}
+	node := &domain.SidebarNode{Name: "bb-1", Type: domain.NodeTypeAgent, AgentInfo: info}
+
+	assert.Contains(t, m.renderAgentName(node, "bb-1", false), "?")
+	assert.Equal(t, "bb-1 ?", m.renderAgentName(node, "bb-1", true))
+
+	info.Attention = domain.AttentionNone
+	assert.Equal(t, "bb-1", m.renderAgentName(node, "bb-1", true))
+
+	// Finished agents never show a badge.
+	info.Attention = domain.AttentionError
+	info.Status = domain.AgentFailed
+	assert.Equal(t, "bb-1", m.renderAgentName(node, "bb-1", true))
+}
+
+func TestSidebarModel_RenderAgentName_VerifyBadge(t *testing.T) {
+	m := NewSidebarModel()
+	m.SetFocused(true)
+
+	info := &domain.AgentInfo{ID: "a1", Status: domain.AgentCompleted, Verify: domain.VerifyPassed}
+	node := &domain.SidebarNode{Name: "bb-1", Type: domain.NodeTypeAgent, AgentInfo: info}
+
+	assert.Contains(t, m.renderAgentName(node, "bb-1", false), "✓")
+	assert.Equal(t, "bb-1 ✓", m.renderAgentName(node, "bb-1", true))
+
+	info.Verify = domain.VerifyFailed
+	assert.Contains(t, m.renderAgentName(node, "bb-1", false), "✗")
+
+	info.Verify = domain.VerifyRunning
+	assert.Equal(t, "bb-1 ◌", m.renderAgentName(node, "bb-1", true))
+
+	info.Verify = domain.VerifyNone
+	assert.Equal(t, "bb-1", m.renderAgentName(node, "bb-1", true))
+}
+
+func TestSidebarModel_RenderAgentName_RemoteHost(t *testing.T) {
+	m := NewSidebarModel()
+	m.SetFocused(true)
+
+	info := &domain.AgentInfo{ID: "a1", Status: domain.AgentRunning, Remote: true, Hostname: "desktop", Owner: "bob"}
+	node := &domain.SidebarNode{Name: "bb-1", Type: domain.NodeTypeAgent, AgentInfo: info}
+
+	assert.Contains(t, m.renderAgentName(node, "bb-1", false), "bob@desktop")
+	assert.Equal(t, "bb-1 bob@desktop", m.renderAgentName(node, "bb-1", true))
+}
+
+func TestSidebarState_ToggleAttentionFilter(t *testing.T) {
+	s := NewSidebarState()
+	s.SetNodes([]domain.SidebarNode{{
+		Type:       domain.NodeTypeWorktree,
+		Path:       "/repo",
+		IsExpanded: true,
+		Children: []domain.SidebarNode{
+			{Type: domain.NodeTypeAgent, Path: "agent:a1", AgentInfo: &domain.AgentInfo{ID: "a1"}},
+			{Type: domain.NodeTypeAgent, Path: "agent:a2", AgentInfo: &domain.AgentInfo{ID: "a2", Attention: domain.AttentionIdle}},
+		},
+	}})
+	assert.Len(t, s.FlatNodes, 3)
+	assert.True(t, s.SelectByPath("agent:a2"))
+
+	s.ToggleAttentionFilter()
+	assert.True(t, s.AttentionOnly)
+	assert.Len(t, s.FlatNodes, 2)
+	assert.Equal(t, "agent:a2", s.CurrentNode().Path)
+
+	s.ToggleAttentionFilter()
+	assert.False(t, s.AttentionOnly)
+	assert.Len(t, s.FlatNodes, 3)
+	assert.Equal(t, "agent:a2", s.CurrentNode().Path)
+}
+
 func TestSidebarModel_Update_EmitsAgentHoverMessages(t *testing.T) {
 	m := NewSidebarModel()
 	m.SetFocused(true)
@@ -0,0 +1,263 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// minDetailWidth is the narrowest the docked detail pane gets; narrower
+// ticket columns are not split.
+const minDetailWidth = 24
+
+// ticketDetailPane holds the state of the ticket detail view. It is shown
+// full screen (ViewStateTicketDetail) or docked beside the ticket column in
+// zoom mode.
+type ticketDetailPane struct {
+	ticketID string
+	detail   *domain.TicketDetail // nil while loading
+	err      error
+
+	// lines is the rendered markdown, cached for the width it was rendered at.
+	width int
+	lines []string
+
+	offset int // first line shown
+
+	search    textinput.Model
+	searching bool // search input has focus
+	query     string
+	matches   []int // indexes of lines containing query
+	match     int   // current index into matches
+}
+
+func newTicketDetailPane(ticketID string) ticketDetailPane {
+	return ticketDetailPane{ticketID: ticketID}
+}
+
+// setDetail stores a loaded ticket, dropping the rendered lines so the next
+// layout call renders it.
+func (p *ticketDetailPane) setDetail(d *domain.TicketDetail, err error) {
+	p.detail, p.err = d, err
+	p.lines = nil
+	p.offset = 0
+}
+
+// layout renders the ticket at width unless it already was.
+func (p *ticketDetailPane) layout(width int) {
+	if p.detail == nil || width <= 0 || (p.lines != nil && p.width == width) {
+		return
+	}
+	p.width = width
+	p.lines = renderTicketMarkdown(ticketDetailMarkdown(p.detail), width)
+	p.setQuery(p.query)
+}
+
+// view returns height lines starting at the scroll offset. With gutter set,
+// each line is prefixed with a search match marker.
+func (p ticketDetailPane) view(height int, gutter bool, theme ThemePalette) []string {
+	switch {
+	case p.err != nil:
+		return []string{fmt.Sprintf("Failed to load %s: %v", p.ticketID, p.err)}
+	case p.detail == nil:
+		return []string{fmt.Sprintf("Loading %s...", p.ticketID)}
+	}
+
+	end := p.offset + height
+	if end > len(p.lines) {
+		end = len(p.lines)
+	}
+	visible := make([]string, 0, end-p.offset)
+	current := -1
+	if len(p.matches) > 0 {
+		current = p.matches[p.match]
+	}
+	matchStyle := lipgloss.NewStyle().Foreground(theme.FocusIndicator)
+	for i := p.offset; i < end; i++ {
+		line := p.lines[i]
+		if gutter {
+			switch {
+			case i == current:
+				line = matchStyle.Render("▶ ") + line
+			case p.isMatch(i):
+				line = matchStyle.Render("│ ") + line
+			default:
+				line = "  " + line
+			}
+		}
+		visible = append(visible, line)
+	}
+	return visible
+}
+
+func (p ticketDetailPane) isMatch(line int) bool {
+	for _, m := range p.matches {
+		if m == line {
+			return true
+		}
+	}
+	return false
+}
+
+// scroll moves the view by delta lines, keeping a full page visible.
+func (p *ticketDetailPane) scroll(delta, height int) {
+	p.offset += delta
+	if maxOffset := len(p.lines) - height; p.offset > maxOffset {
+		p.offset = maxOffset
+	}
+	if p.offset < 0 {
+		p.offset = 0
+	}
+}
+
+// setQuery finds the lines containing query, case-insensitively and
+// ignoring styling. The current match is reset to the first one.
+func (p *ticketDetailPane) setQuery(query string) {
+	p.query = query
+	p.matches = nil
+	p.match = 0
+	if query == "" {
+		return
+	}
+	needle := strings.ToLower(query)
+	for i, line := range p.lines {
+		if strings.Contains(strings.ToLower(ansi.Strip(line)), needle) {
+			p.matches = append(p.matches, i)
+		}
+	}
+}
+
+// jumpToMatch moves to the next (dir > 0) or previous match, wrapping
+// around, and scrolls it into view.
+func (p *ticketDetailPane) jumpToMatch(dir, height int) {
+	if len(p.matches) == 0 {
+		return
+	}
+	p.match = (p.match + dir + len(p.matches)) % len(p.matches)
+	p.revealMatch(height)
+}
+
+// firstMatchFromOffset selects the first match at or below the top of the
+// view, so a new search does not jump backwards.
+func (p *ticketDetailPane) firstMatchFromOffset(height int) {
+	for i, line := range p.matches {
+		if line >= p.offset {
+			p.match = i
+			break
+		}
+	}
+	p.revealMatch(height)
+}
+
+func (p *ticketDetailPane) revealMatch(height int) {
+	if len(p.matches) == 0 {
+		return
+	}
+	line := p.matches[p.match]
+	if line < p.offset || line >= p.offset+height {
+		p.offset = line - height/3
+		p.scroll(0, height)
+	}
+}
+
+// searchStatus describes the active search for the footer.
+func (p ticketDetailPane) searchStatus() string {
+	switch {
+	case p.query == "":
+		return ""
+	case len(p.matches) == 0:
+		return fmt.Sprintf("no matches for %q", p.query)
+	default:
+		return fmt.Sprintf("match %d/%d for %q", p.match+1, len(p.matches), p.query)
+	}
+}
+
+func newTicketSearchInput(query string) textinput.Model {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.CharLimit = 0
+	ti.Cursor.SetMode(cursor.CursorStatic)
+	ti.SetValue(query)
+	ti.Focus()
+	return ti
+}
+
+// ticketDetailMarkdown lays a ticket out as a markdown document.
+func ticketDetailMarkdown(d *domain.TicketDetail) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s: %s\n\n", d.ID, d.Title)
+
+	meta := []string{fmt.Sprintf("**%s**", d.IssueType), fmt.Sprintf("P%d", d.Priority), d.Status}
+	if d.Assignee != "" {
+		meta = append(meta, "@"+d.Assignee)
+	}
+	b.WriteString(strings.Join(meta, " · ") + "\n\n")
+	if len(d.Labels) > 0 {
+		labels := make([]string, len(d.Labels))
+		for i, l := range d.Labels {
+			labels[i] = "`" + l + "`"
+		}
+		b.WriteString("Labels: " + strings.Join(labels, " ") + "\n\n")
+	}
+	if d.URL != "" {
+		b.WriteString(d.URL + "\n\n")
+	}
+
+	section := func(title, body string) {
+		if strings.TrimSpace(body) != "" {
+			fmt.Fprintf(&b, "## %s\n\n%s\n\n", title, strings.TrimSpace(body))
+		}
+	}
+	section("Description", d.Description)
+	section("Design", d.Design)
+	section("Acceptance Criteria", d.AcceptanceCriteria)
+	section("Notes", d.Notes)
+	section("Depends On", dependencyList(d.Dependencies))
+	section("Dependents", dependencyList(d.Dependents))
+
+	if len(d.Comments) > 0 {
+		fmt.Fprintf(&b, "## Comments (%d)\n\n", len(d.Comments))
+		for _, c := range d.Comments {
+			fmt.Fprintf(&b, "**%s** · %s\n\n%s\n\n", c.Author, c.CreatedAt.Local().Format("2006-01-02 15:04"), strings.TrimSpace(c.Text))
+		}
+	}
+	return b.String()
+}
+
+func dependencyList(deps []domain.TicketDependency) string {
+	var b strings.Builder
+	for _, dep := range deps {
+		fmt.Fprintf(&b, "- **%s**", dep.ID)
+		if dep.Title != "" {
+			b.WriteString(" " + dep.Title)
+		}
+		if dep.Status != "" {
+			fmt.Fprintf(&b, " (%s, %s)", dep.Status, dep.Type)
+		} else {
+			fmt.Fprintf(&b, " (%s)", dep.Type)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderTicketMarkdown renders markdown word-wrapped to width. If glamour
+// fails the source is shown as plain text.
+func renderTicketMarkdown(md string, width int) []string {
+	out := md
+	r, err := glamour.NewTermRenderer(glamour.WithStandardStyle(styles.DarkStyle), glamour.WithWordWrap(width))
+	if err == nil {
+		if rendered, err := r.Render(md); err == nil {
+			out = rendered
+		}
+	}
+	return strings.Split(strings.Trim(out, "\n"), "\n")
+}
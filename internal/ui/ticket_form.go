@@ -0,0 +1,204 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// Ticket form field names.
+const (
+	fieldTitle       = "Title"
+	fieldType        = "Type"
+	fieldPriority    = "Priority"
+	fieldDescription = "Description"
+	fieldParent      = "Parent"
+	fieldDependsOn   = "Depends on"
+	fieldStatus      = "Status"
+	fieldAssignee    = "Assignee"
+)
+
+// ticketFormField is a text input, or a choice between options when options
+// is set.
+type ticketFormField struct {
+	name    string
+	input   textinput.Model
+	options []string
+	choice  int
+}
+
+func (f ticketFormField) value() string {
+	if f.options != nil {
+		return f.options[f.choice]
+	}
+	return strings.TrimSpace(f.input.Value())
+}
+
+// ticketForm holds the state of the ticket form (ViewStateTicketForm). It
+// creates a ticket, or edits the priority, status and assignee of ticket.
+type ticketForm struct {
+	ticket *domain.Ticket // ticket being edited; nil for a new ticket
+	fields []ticketFormField
+	focus  int
+	err    error // validation or write error, shown under the fields
+	saving bool  // a write is in flight
+}
+
+// newCreateTicketForm returns an empty form for a new ticket, filed under
+// parentID if set.
+func newCreateTicketForm(parentID string) ticketForm {
+	f := ticketForm{fields: []ticketFormField{
+		textField(fieldTitle, ""),
+		choiceField(fieldType, domain.TicketIssueTypes, "task"),
+		choiceField(fieldPriority, priorityOptions(), "P2"),
+		textField(fieldDescription, ""),
+		textField(fieldParent, parentID),
+		textField(fieldDependsOn, ""),
+	}}
+	f.fields[5].input.Placeholder = "comma-separated ticket IDs"
+	f.setFocus(0)
+	return f
+}
+
+// newEditTicketForm returns a form prefilled with ticket's priority, status
+// and assignee.
+func newEditTicketForm(ticket domain.Ticket) ticketForm {
+	f := ticketForm{ticket: &ticket, fields: []ticketFormField{
+		choiceField(fieldPriority, priorityOptions(), fmt.Sprintf("P%d", ticket.Priority)),
+		choiceField(fieldStatus, domain.TicketStatuses, ticket.Status),
+		textField(fieldAssignee, ticket.Assignee),
+	}}
+	f.setFocus(0)
+	return f
+}
+
+func textField(name, value string) ticketFormField {
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.CharLimit = 0
+	ti.Cursor.SetMode(cursor.CursorStatic)
+	ti.SetValue(value)
+	return ticketFormField{name: name, input: ti}
+}
+
+// choiceField selects value, or the first option if value is not one of
+// them.
+func choiceField(name string, options []string, value string) ticketFormField {
+	return ticketFormField{name: name, options: options, choice: max(slices.Index(options, value), 0)}
+}
+
+func priorityOptions() []string {
+	options := make([]string, 0, domain.MaxTicketPriority-domain.MinTicketPriority+1)
+	for p := domain.MinTicketPriority; p <= domain.MaxTicketPriority; p++ {
+		options = append(options, fmt.Sprintf("P%d", p))
+	}
+	return options
+}
+
+func (f ticketForm) title() string {
+	if f.ticket != nil {
+		return fmt.Sprintf("Edit %s: %s", f.ticket.ID, f.ticket.Title)
+	}
+	return "New ticket"
+}
+
+func (f ticketForm) value(name string) string {
+	for _, field := range f.fields {
+		if field.name == name {
+			return field.value()
+		}
+	}
+	return ""
+}
+
+func (f ticketForm) lastField() bool {
+	return f.focus == len(f.fields)-1
+}
+
+// setFocus moves the cursor to field i, wrapping around.
+func (f *ticketForm) setFocus(i int) {
+	f.focus = (i + len(f.fields)) % len(f.fields)
+	for j := range f.fields {
+		if f.fields[j].options != nil {
+			continue
+		}
+		if j == f.focus {
+			f.fields[j].input.Focus()
+		} else {
+			f.fields[j].input.Blur()
+		}
+	}
+}
+
+// update cycles the focused choice with left/right, or passes msg to the
+// focused text input.
+func (f *ticketForm) update(msg tea.KeyMsg) tea.Cmd {
+	field := &f.fields[f.focus]
+	if field.options != nil {
+		switch msg.String() {
+		case "left", "h":
+			field.choice = (field.choice + len(field.options) - 1) % len(field.options)
+		case "right", "l", " ":
+			field.choice = (field.choice + 1) % len(field.options)
+		}
+		return nil
+	}
+	var cmd tea.Cmd
+	field.input, cmd = field.input.Update(msg)
+	return cmd
+}
+
+// newTicket returns the ticket the create form describes.
+func (f ticketForm) newTicket() (data.NewTicket, error) {
+	t := data.NewTicket{
+		Title:       f.value(fieldTitle),
+		IssueType:   f.value(fieldType),
+		Priority:    slices.Index(priorityOptions(), f.value(fieldPriority)),
+		Description: f.value(fieldDescription),
+		ParentID:    f.value(fieldParent),
+	}
+	if t.Title == "" {
+		return t, fmt.Errorf("title is required")
+	}
+	for _, id := range strings.Split(f.value(fieldDependsOn), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			t.DependsOn = append(t.DependsOn, id)
+		}
+	}
+	return t, nil
+}
+
+// ticketUpdate returns the fields the edit form changed.
+func (f ticketForm) ticketUpdate() data.TicketUpdate {
+	var u data.TicketUpdate
+	if p := slices.Index(priorityOptions(), f.value(fieldPriority)); p != f.ticket.Priority {
+		u.Priority = &p
+	}
+	if s := f.value(fieldStatus); s != f.ticket.Status {
+		u.Status = &s
+	}
+	if a := f.value(fieldAssignee); a != f.ticket.Assignee {
+		u.Assignee = &a
+	}
+	return u
+}
+
+// rows renders one "label  value" line per field, marking the focused one.
+func (f ticketForm) rows() []TicketFormRow {
+	rows := make([]TicketFormRow, len(f.fields))
+	for i, field := range f.fields {
+		value := field.input.View()
+		if field.options != nil {
+			value = "‹ " + field.value() + " ›"
+		}
+		rows[i] = TicketFormRow{Label: field.name, Value: value, Focused: i == f.focus}
+	}
+	return rows
+}
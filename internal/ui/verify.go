@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/megatherium/blunderbust/internal/app"
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/domain"
+	"github.com/megatherium/blunderbust/internal/exec"
+)
+
+// verifyCommentLines is how much of a failed verify_command's output is
+// quoted in the ticket comment.
+const verifyCommentLines = 30
+
+// projectVerify returns the verify_command settings of an agent's project.
+// Agents on other hosts are never verified: their worktrees are not here.
+func (m UIModel) projectVerify(info *domain.AgentInfo) domain.Verify {
+	if m.app == nil || info.Remote {
+		return domain.Verify{}
+	}
+	project, ok := m.app.ProjectConfig(info.ProjectDir)
+	if !ok {
+		return domain.Verify{}
+	}
+	return project.Verify
+}
+
+// startVerify marks the agent as being verified and returns the command
+// running verify on its worktree.
+func (m UIModel) startVerify(agent *RunningAgent, verify domain.Verify) tea.Cmd {
+	agent.Info.Verify = domain.VerifyRunning
+	agent.VerifyOutput = ""
+	return verifyAgentCmd(m.app, verify, *agent.Info)
+}
+
+// verifyAgentCmd runs verify.Command with sh in an ended agent's worktree,
+// with its ticket ID and final status in BDB_TICKET_ID and
+// BDB_AGENT_STATUS, for at most general.command_timeout. With verify.Comment, the result is added as a comment
+// on the agent's ticket.
+func verifyAgentCmd(myApp *app.App, verify domain.Verify, info domain.AgentInfo) tea.Cmd {
+	dir := info.WorktreePath
+	if dir == "" {
+		dir = info.ProjectDir
+	}
+	env := []string{"BDB_TICKET_ID=" + info.TicketID, "BDB_AGENT_STATUS=" + info.Status.String()}
+	timeout := commandTimeout(myApp)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		output, err := exec.RunCapture(ctx, verify.Command, dir, env...)
+		cancel()
+		msg := agentVerifiedMsg{agentID: info.ID, passed: err == nil, output: output}
+		if err != nil {
+			msg.output = strings.TrimSpace(output + "\n" + err.Error())
+		}
+		if verify.Comment && info.TicketID != "" && myApp != nil {
+			msg.commentErr = commentVerifyResult(context.Background(), myApp, info, verifyComment(info, verify.Command, msg.passed, msg.output))
+		}
+		return msg
+	}
+}
+
+// commentVerifyResult adds text as a comment on the agent's ticket.
+func commentVerifyResult(ctx context.Context, myApp *app.App, info domain.AgentInfo, text string) error {
+	projectDir := info.ProjectDir
+	if projectDir == "" {
+		projectDir = activeProjectDir(myApp)
+	}
+	store, err := myApp.StoreForProject(ctx, projectDir)
+	if err != nil {
+		return err
+	}
+	commenter, ok := store.(data.TicketCommenter)
+	if !ok {
+		return fmt.Errorf("this project's ticket store cannot take comments")
+	}
+	return commenter.AddComment(ctx, info.TicketID, text)
+}
+
+// verifyComment formats the ticket comment for a verify_command result,
+// quoting the end of the output when it failed.
+func verifyComment(info domain.AgentInfo, command string, passed bool, output string) string {
+	result := "passed"
+	if !passed {
+		result = "failed"
+	}
+	text := fmt.Sprintf("Verification %s: `%s` after agent %s (%s) %s.",
+		result, command, info.Name, info.HarnessName, info.Status)
+	if !passed && output != "" {
+		lines := strings.Split(output, "\n")
+		if len(lines) > verifyCommentLines {
+			lines = lines[len(lines)-verifyCommentLines:]
+		}
+		text += "\n\n```\n" + strings.Join(lines, "\n") + "\n```"
+	}
+	return text
+}
+
+// HandleAgentVerified records the result of a verify_command run, shown as
+// a badge on the agent's sidebar node and in its output view.
+func (m UIModel) HandleAgentVerified(msg agentVerifiedMsg) (tea.Model, tea.Cmd) {
+	agent, ok := m.agents[msg.agentID]
+	if !ok {
+		return m, nil
+	}
+	agent.Info.Verify = domain.VerifyFailed
+	if msg.passed {
+		agent.Info.Verify = domain.VerifyPassed
+	}
+	agent.VerifyOutput = msg.output
+	if msg.commentErr != nil {
+		return m, warningCmd(fmt.Errorf("agent %s: failed to comment on %s: %w", agent.Info.Name, agent.Info.TicketID, msg.commentErr))
+	}
+	return m, nil
+}
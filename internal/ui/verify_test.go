@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/data/fake"
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func newVerifyTestModel(t *testing.T, verify domain.Verify) (UIModel, *fake.TicketStore, string) {
+	t.Helper()
+	dir := t.TempDir()
+	store := fake.NewWithSampleData()
+	app := newTestApp()
+	app.AddProject(domain.Project{Dir: dir, Name: "proj", Verify: verify})
+	app.AddStore(dir, store)
+
+	m := NewUIModel(app, nil)
+	m.agents = map[string]*RunningAgent{
+		"agent-1": {Info: &domain.AgentInfo{
+			ID: "agent-1", Name: "bb-001", Status: domain.AgentRunning, HarnessName: "claude",
+			ProjectDir: dir, WorktreePath: dir, TicketID: "bb-001",
+		}},
+	}
+	return m, store, dir
+}
+
+func TestHandleAgentStatus_RunsVerifyCommand(t *testing.T) {
+	m, store, _ := newVerifyTestModel(t, domain.Verify{
+		Command: `echo "$BDB_TICKET_ID $BDB_AGENT_STATUS"; echo FAIL: TestX; exit 1`,
+		Comment: true,
+	})
+
+	newM, cmd := m.HandleAgentStatus(AgentStatusMsg{AgentID: "agent-1", Status: domain.AgentCompleted})
+	m = newM.(UIModel)
+	assert.NotNil(t, cmd)
+	agent := m.agents["agent-1"]
+	assert.Equal(t, domain.VerifyRunning, agent.Info.Verify)
+
+	msg := verifyAgentCmd(m.app, m.projectVerify(agent.Info), *agent.Info)()
+	verified, ok := msg.(agentVerifiedMsg)
+	require.True(t, ok)
+	assert.False(t, verified.passed)
+	assert.NoError(t, verified.commentErr)
+	assert.Contains(t, verified.output, "bb-001 completed")
+	assert.Contains(t, verified.output, "exit status 1")
+
+	newM, _ = m.HandleAgentVerified(verified)
+	m = newM.(UIModel)
+	assert.Equal(t, domain.VerifyFailed, m.agents["agent-1"].Info.Verify)
+	assert.Equal(t, "✗", verifyBadge(m.agents["agent-1"].Info))
+
+	d, err := store.TicketDetail(context.Background(), "bb-001")
+	require.NoError(t, err)
+	require.NotEmpty(t, d.Comments)
+	comment := d.Comments[len(d.Comments)-1].Text
+	assert.Contains(t, comment, "Verification failed: `echo")
+	assert.Contains(t, comment, "after agent bb-001 (claude) completed.")
+	assert.Contains(t, comment, "FAIL: TestX")
+}
+
+func TestHandleAgentStatus_ManualVerify(t *testing.T) {
+	m, _, _ := newVerifyTestModel(t, domain.Verify{Command: "true", Manual: true})
+	m.focus = FocusSidebar
+	m.sidebar.State().SetNodes([]domain.SidebarNode{{
+		Type: domain.NodeTypeAgent, Path: "agent:agent-1", AgentInfo: m.agents["agent-1"].Info,
+	}})
+
+	// A running agent is not verified on request.
+	_, cmd, handled := m.HandleSidebarAgentKeysMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	assert.True(t, handled)
+	if assert.NotNil(t, cmd) {
+		assert.IsType(t, warningMsg{}, cmd())
+	}
+
+	newM, _ := m.HandleAgentStatus(AgentStatusMsg{AgentID: "agent-1", Status: domain.AgentCompleted})
+	m = newM.(UIModel)
+	assert.Equal(t, domain.VerifyNone, m.agents["agent-1"].Info.Verify, "manual verification does not start on exit")
+
+	newM, cmd, handled = m.HandleSidebarAgentKeysMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m = newM.(UIModel)
+	assert.True(t, handled)
+	require.NotNil(t, cmd)
+	assert.Equal(t, domain.VerifyRunning, m.agents["agent-1"].Info.Verify)
+
+	newM, _ = m.HandleAgentVerified(cmd().(agentVerifiedMsg))
+	m = newM.(UIModel)
+	assert.Equal(t, domain.VerifyPassed, m.agents["agent-1"].Info.Verify)
+}
+
+func TestVerifyComment(t *testing.T) {
+	info := domain.AgentInfo{Name: "bb-1", HarnessName: "aider", Status: domain.AgentFailed}
+	assert.Equal(t, "Verification passed: `make test` after agent bb-1 (aider) failed.",
+		verifyComment(info, "make test", true, "ok"))
+
+	lines := make([]string, 40)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", i+1)
+	}
+	comment := verifyComment(info, "make test", false, strings.Join(lines, "\n"))
+	assert.Contains(t, comment, "\n\n```\nline 11\n")
+	assert.NotContains(t, comment, "line 10\n")
+	assert.True(t, strings.HasSuffix(comment, "line 40\n```"))
+}
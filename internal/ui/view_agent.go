@@ -2,6 +2,8 @@ package ui
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
@@ -29,29 +31,97 @@ func RenderAgentOutput(cfg AgentConfig) string {
 
 	header := headerStyle.Render(fmt.Sprintf("Agent: %s", cfg.Agent.Info.Name))
 	statusLine := fmt.Sprintf("Status: %s", statusStyle.Render(statusStr))
+	if info := cfg.Agent.Info; info.Status != domain.AgentRunning && !info.EndedAt.IsZero() {
+		exit := "exit unknown"
+		if info.ExitCode != nil {
+			exit = fmt.Sprintf("exit %d", *info.ExitCode)
+		}
+		statusLine += fmt.Sprintf(" (%s, ran %s)", exit, info.EndedAt.Sub(info.StartedAt).Round(time.Second))
+	}
+	if info := cfg.Agent.Info; info.Status == domain.AgentRunning && info.Attention != domain.AttentionNone {
+		statusLine += " " + lipgloss.NewStyle().Foreground(ThemeWarning).
+			Render(fmt.Sprintf("(needs attention: %s)", info.Attention))
+	}
+	verifyLine, verifyOutput := getAgentVerify(cfg.Agent)
 	launcherLine := fmt.Sprintf("Launcher: %s", cfg.Agent.Info.LauncherID)
+	if cfg.Agent.Info.WindowID != "" {
+		launcherLine += fmt.Sprintf(" (%s in %s)", cfg.Agent.Info.WindowID, cfg.Agent.Info.SessionName)
+	}
+
+	hintLine := "[g jump • s send prompt • x interrupt • X kill • R restart • v verify]"
+	if info := cfg.Agent.Info; info.Remote {
+		launcherLine += fmt.Sprintf(" on %s (read-only)", info.HostLabel())
+		hintLine = "[agent runs on another host: output and actions are unavailable]"
+	}
 
 	outputContent := getAgentOutputContent(cfg.Agent)
 
+	outputHeight := cfg.Height - 10
+	var verifySection []string
+	if verifyLine != "" {
+		verifySection = append(verifySection, verifyLine)
+		outputHeight--
+	}
+	if verifyOutput != "" {
+		verifySection = append(verifySection, lipgloss.NewStyle().Faint(true).Render(verifyOutput))
+		outputHeight -= strings.Count(verifyOutput, "\n") + 1
+	}
+
 	outputStyle := lipgloss.NewStyle().
 		Border(lipgloss.ThickBorder()).
 		BorderForeground(ThemeInactive).
 		Width(cfg.Width-4).
-		Height(cfg.Height-10).
+		Height(outputHeight).
 		Padding(0, 1)
 
-	content := lipgloss.JoinVertical(lipgloss.Top,
-		header,
-		statusLine,
-		launcherLine,
+	lines := []string{header, statusLine, launcherLine}
+	lines = append(lines, verifySection...)
+	lines = append(lines,
 		"",
 		"Output:",
 		outputStyle.Render(outputContent),
 		"",
+		lipgloss.NewStyle().Faint(true).Render(hintLine),
 		"[Press Enter to return to matrix]",
 	)
 
-	return content
+	return lipgloss.JoinVertical(lipgloss.Top, lines...)
+}
+
+// AgentPromptConfig holds configuration for rendering the follow-up prompt input
+type AgentPromptConfig struct {
+	Agent *RunningAgent
+	Input string // rendered text input
+	Width int
+	Theme ThemePalette
+}
+
+// RenderAgentPrompt renders the input for sending a follow-up prompt to a
+// running agent's tmux window.
+func RenderAgentPrompt(cfg AgentPromptConfig) string {
+	if cfg.Agent == nil {
+		return "Agent not found\n\n[Press esc to return]"
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Underline(true)
+	boxWidth := cfg.Width - 4
+	if boxWidth < 20 {
+		boxWidth = 20
+	}
+	inputStyle := lipgloss.NewStyle().
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(ThemeActive).
+		Width(boxWidth).
+		Padding(0, 1)
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		headerStyle.Render(fmt.Sprintf("Send to agent: %s", cfg.Agent.Info.Name)),
+		"The text is typed into the agent's tmux window, followed by Enter.",
+		"",
+		inputStyle.Render(cfg.Input),
+		"",
+		lipgloss.NewStyle().Faint(true).Render("[enter send • esc cancel]"),
+	)
 }
 
 func getAgentStatus(status domain.AgentStatus) (string, lipgloss.Color) {
@@ -67,10 +137,38 @@ func getAgentStatus(status domain.AgentStatus) (string, lipgloss.Color) {
 	}
 }
 
+// verifyOutputLines is how much of the verify_command output is shown above
+// the agent's output.
+const verifyOutputLines = 5
+
+// getAgentVerify returns the verify_command status line of an agent and the
+// end of its output, both empty when the agent has not been verified.
+func getAgentVerify(agent *RunningAgent) (string, string) {
+	var line string
+	switch agent.Info.Verify {
+	case domain.VerifyNone:
+		return "", ""
+	case domain.VerifyRunning:
+		return "Verify: running...", ""
+	case domain.VerifyPassed:
+		line = "Verify: " + verifyPassedStyle.Render("passed")
+	case domain.VerifyFailed:
+		line = "Verify: " + verifyFailedStyle.Render("failed")
+	}
+	output := strings.Split(strings.TrimSpace(agent.VerifyOutput), "\n")
+	if len(output) > verifyOutputLines {
+		output = output[len(output)-verifyOutputLines:]
+	}
+	return line, strings.Join(output, "\n")
+}
+
 func getAgentOutputContent(agent *RunningAgent) string {
 	if agent.LastOutput != "" {
 		return agent.LastOutput
 	}
+	if agent.Info.Remote {
+		return "Output is not available for agents on other hosts"
+	}
 	if agent.Info.Status == domain.AgentRunning {
 		return "Waiting for output..."
 	}
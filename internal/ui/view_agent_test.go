@@ -90,6 +90,27 @@ func TestRenderAgentOutput_AgentFailed(t *testing.T) {
 	assert.Contains(t, s, "Error occurred")
 }
 
+func TestRenderAgentOutput_Verify(t *testing.T) {
+	agent := &RunningAgent{
+		Info: &domain.AgentInfo{
+			Name:       "test-agent",
+			Status:     domain.AgentCompleted,
+			LauncherID: "test-window",
+			Verify:     domain.VerifyFailed,
+		},
+		LastOutput:   "Done",
+		VerifyOutput: "ok 1\nok 2\nok 3\nok 4\nFAIL 5\nexit status 1",
+	}
+
+	s := RenderAgentOutput(AgentConfig{Agent: agent, Width: 80, Height: 24, Theme: MatrixTheme})
+	assert.Contains(t, s, "Verify: failed")
+	assert.Contains(t, s, "exit status 1")
+	assert.NotContains(t, s, "ok 1", "only the end of the verify output is shown")
+
+	agent.Info.Verify = domain.VerifyNone
+	assert.NotContains(t, RenderAgentOutput(AgentConfig{Agent: agent, Width: 80, Height: 24}), "Verify:")
+}
+
 func TestRenderAgentOutput_NoOutput(t *testing.T) {
 	agent := &RunningAgent{
 		Info: &domain.AgentInfo{
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// HistoryConfig holds configuration for rendering the agent history view
+type HistoryConfig struct {
+	Sessions []domain.AgentSession // nil while loading
+	Err      error
+	Offset   int
+	Width    int
+	Height   int
+	Theme    ThemePalette
+}
+
+// RenderHistory renders finished agent sessions with per-model and
+// per-harness aggregates above a scrollable list of recent runs.
+func RenderHistory(cfg HistoryConfig) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Underline(true)
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(cfg.Theme.TitleColor)
+	faint := lipgloss.NewStyle().Faint(true)
+
+	header := headerStyle.Render("Agent History")
+	footer := faint.Render("[↑/↓ scroll • r reload • esc back]")
+
+	switch {
+	case cfg.Err != nil:
+		return lipgloss.JoinVertical(lipgloss.Left, header, "",
+			fmt.Sprintf("Failed to load history: %v", cfg.Err), "", footer)
+	case cfg.Sessions == nil:
+		return lipgloss.JoinVertical(lipgloss.Left, header, "", "Loading history...", "", footer)
+	case len(cfg.Sessions) == 0:
+		return lipgloss.JoinVertical(lipgloss.Left, header, "", "No finished agent sessions yet.", "", footer)
+	}
+
+	summary := domain.SummarizeSessions(cfg.Sessions)
+	lines := []string{
+		header,
+		fmt.Sprintf("%d runs • %.0f%% succeeded • avg %s",
+			summary.Total.Runs, summary.Total.SuccessRate()*100, formatHistoryDuration(summary.Total.AvgDuration)),
+		"",
+		sectionStyle.Render("By model"),
+	}
+	for _, g := range summary.ByModel {
+		lines = append(lines, formatHistoryGroup(g))
+	}
+	lines = append(lines, "", sectionStyle.Render("By harness"))
+	for _, g := range summary.ByHarness {
+		lines = append(lines, formatHistoryGroup(g))
+	}
+	lines = append(lines, "", sectionStyle.Render("Recent sessions"))
+
+	// Fit the session list into what is left of the view.
+	available := cfg.Height - len(lines) - 2
+	if available < 3 {
+		available = 3
+	}
+	offset := cfg.Offset
+	if offset > len(cfg.Sessions)-1 {
+		offset = len(cfg.Sessions) - 1
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	end := offset + available
+	if end > len(cfg.Sessions) {
+		end = len(cfg.Sessions)
+	}
+	for _, s := range cfg.Sessions[offset:end] {
+		lines = append(lines, truncateHistoryLine(formatHistorySession(s), cfg.Width))
+	}
+
+	lines = append(lines, "", footer)
+	return strings.Join(lines, "\n")
+}
+
+func formatHistoryGroup(g domain.SessionGroupStats) string {
+	return fmt.Sprintf("  %-30s %4d runs  %3.0f%% ok  avg %s",
+		g.Key, g.Runs, g.SuccessRate()*100, formatHistoryDuration(g.AvgDuration))
+}
+
+func formatHistorySession(s domain.AgentSession) string {
+	statusStr, statusColor := getAgentStatus(s.Status)
+	status := lipgloss.NewStyle().Foreground(statusColor).Render(fmt.Sprintf("%-9s", statusStr))
+	exit := "   "
+	if s.ExitCode != nil {
+		exit = fmt.Sprintf("%3d", *s.ExitCode)
+	}
+	return fmt.Sprintf("  %s %s %s %-8s %-12s %-20s %s",
+		s.StartedAt.Local().Format("2006-01-02 15:04"), status, exit,
+		formatHistoryDuration(s.Duration()), s.HarnessName, s.Model, s.Ticket)
+}
+
+func formatHistoryDuration(d time.Duration) string {
+	if d <= 0 {
+		return "-"
+	}
+	return d.Round(time.Second).String()
+}
+
+func truncateHistoryLine(line string, width int) string {
+	if width <= 0 || lipgloss.Width(line) <= width {
+		return line
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(line)
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func TestRenderHistory_States(t *testing.T) {
+	loading := RenderHistory(HistoryConfig{Width: 100, Height: 30, Theme: MatrixTheme})
+	assert.Contains(t, loading, "Loading history")
+
+	empty := RenderHistory(HistoryConfig{Sessions: []domain.AgentSession{}, Width: 100, Height: 30, Theme: MatrixTheme})
+	assert.Contains(t, empty, "No finished agent sessions")
+
+	failed := RenderHistory(HistoryConfig{Err: errors.New("boom"), Width: 100, Height: 30, Theme: MatrixTheme})
+	assert.Contains(t, failed, "boom")
+}
+
+func TestRenderHistory_Aggregates(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	exitCode := 1
+	sessions := []domain.AgentSession{
+		{Ticket: "bb-1", HarnessName: "codex", Model: "gpt-5", Status: domain.AgentCompleted, StartedAt: start, EndedAt: start.Add(2 * time.Minute)},
+		{Ticket: "bb-2", HarnessName: "codex", Model: "gpt-5", Status: domain.AgentFailed, ExitCode: &exitCode, StartedAt: start, EndedAt: start.Add(4 * time.Minute)},
+	}
+
+	s := RenderHistory(HistoryConfig{Sessions: sessions, Width: 120, Height: 40, Theme: MatrixTheme})
+	assert.Contains(t, s, "2 runs")
+	assert.Contains(t, s, "50% succeeded")
+	assert.Contains(t, s, "By model")
+	assert.Contains(t, s, "gpt-5")
+	assert.Contains(t, s, "By harness")
+	assert.Contains(t, s, "avg 3m0s")
+	assert.Contains(t, s, "bb-2")
+}
+
+func TestHistoryKeys_OpenScrollAndClose(t *testing.T) {
+	m := NewUIModel(newTestApp(), nil)
+	m.state = ViewStateMatrix
+
+	newModel, cmd, handled := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("H")})
+	assert.True(t, handled)
+	assert.NotNil(t, cmd, "opening history should load sessions")
+	m = newModel.(UIModel)
+	assert.Equal(t, ViewStateHistory, m.state)
+
+	m.history = []domain.AgentSession{{Ticket: "bb-1"}, {Ticket: "bb-2"}}
+	newModel, _, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyDown})
+	m = newModel.(UIModel)
+	assert.Equal(t, 1, m.historyOffset)
+
+	newModel, _, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyDown})
+	m = newModel.(UIModel)
+	assert.Equal(t, 1, m.historyOffset, "offset should stop at the last session")
+
+	newModel, _, handled = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.True(t, handled)
+	assert.Equal(t, ViewStateMatrix, newModel.(UIModel).state)
+}
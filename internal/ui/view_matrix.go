@@ -19,6 +19,7 @@ type MatrixConfig struct {
 	HWidth       int
 	MWidth       int
 	AWidth       int
+	DWidth       int // docked ticket detail pane, 0 when not shown
 
 	// Column disabled states
 	ModelColumnDisabled bool
@@ -39,6 +40,7 @@ type MatrixConfig struct {
 	ModelView   string
 	AgentView   string
 	SidebarView string
+	DetailView  string
 
 	// List titles for focus indicators
 	TicketTitle  string
@@ -82,6 +84,12 @@ func RenderMatrix(cfg MatrixConfig) string {
 	aView := renderAgentColumn(cfg, theme, listHeight, capView, faintCapView)
 
 	matrixWidth := cfg.TWidth + cfg.HWidth + cfg.MWidth + cfg.AWidth + 6
+	if cfg.DWidth > 0 {
+		dView := renderMatrixColumn(cfg.DetailView, cfg.DWidth, false,
+			"Detail", theme, activeBorder, inactiveBorder, capView, capView)
+		tView = lipgloss.JoinHorizontal(lipgloss.Top, tView, lipgloss.NewStyle().Width(2).Render("  "), dView)
+		matrixWidth += cfg.DWidth + 2
+	}
 
 	filterLabel := lipgloss.NewStyle().
 		Bold(true).
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+// QueueConfig holds configuration for rendering the launch queue view
+type QueueConfig struct {
+	Queue   []domain.QueuedLaunch
+	Cursor  int
+	Running int // agents counted against the limits
+	Limits  domain.ConcurrencyLimits
+	Reasons []string // why each queued launch waits or failed
+	Width   int
+	Height  int
+	Theme   ThemePalette
+}
+
+// RenderQueue renders the launches waiting for a concurrency limit, in the
+// order they start.
+func RenderQueue(cfg QueueConfig) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Underline(true)
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(cfg.Theme.TitleColor)
+	faint := lipgloss.NewStyle().Faint(true)
+
+	header := headerStyle.Render("Launch Queue")
+	limit := "no limit"
+	if cfg.Limits.Agents > 0 {
+		limit = fmt.Sprintf("limit %d", cfg.Limits.Agents)
+	}
+	summary := fmt.Sprintf("%d running (%s) • %d queued", cfg.Running, limit, len(cfg.Queue))
+	footer := faint.Render("[↑/↓ select • K/J move up/down • r retry failed • d cancel • esc back]")
+
+	if len(cfg.Queue) == 0 {
+		return lipgloss.JoinVertical(lipgloss.Left, header, summary, "",
+			"No queued launches. Launches beyond max_concurrent_agents wait here.", "", footer)
+	}
+
+	lines := []string{header, summary, ""}
+
+	// Keep the cursor in the part of the queue that fits the view.
+	available := cfg.Height - len(lines) - 2
+	if available < 3 {
+		available = 3
+	}
+	offset := 0
+	if cfg.Cursor >= available {
+		offset = cfg.Cursor - available + 1
+	}
+	end := offset + available
+	if end > len(cfg.Queue) {
+		end = len(cfg.Queue)
+	}
+	for i := offset; i < end; i++ {
+		reason := ""
+		if i < len(cfg.Reasons) {
+			reason = cfg.Reasons[i]
+		}
+		line := truncateHistoryLine(formatQueuedLaunch(i, cfg.Queue[i], reason), cfg.Width)
+		if i == cfg.Cursor {
+			line = selectedStyle.Render("> " + line)
+		} else {
+			line = "  " + line
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, "", footer)
+	return strings.Join(lines, "\n")
+}
+
+func formatQueuedLaunch(i int, q domain.QueuedLaunch, reason string) string {
+	harness := q.HarnessName
+	if q.Model != "" {
+		harness += "/" + q.Model
+	}
+	waiting := formatHistoryDuration(time.Since(q.QueuedAt))
+	line := fmt.Sprintf("%2d. %-12s %-28s waiting %-8s", i+1, q.TicketID, harness, waiting)
+	if reason != "" {
+		line += " " + reason
+	}
+	return line
+}
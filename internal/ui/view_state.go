@@ -3,7 +3,6 @@ package ui
 import (
 	"github.com/charmbracelet/lipgloss"
 
-	"github.com/megatherium/blunderbust/internal/config"
 	"github.com/megatherium/blunderbust/internal/data"
 	"github.com/megatherium/blunderbust/internal/data/dolt"
 	"github.com/megatherium/blunderbust/internal/domain"
@@ -16,9 +15,6 @@ type MainContentConfig struct {
 	Focus              FocusColumn
 	ViewingAgentID     string
 	Selection          domain.Selection
-	Renderer           *config.Renderer
-	DryRun             bool
-	SelectedWorktree   string
 	CurrentTheme       ThemePalette
 	ShowModal          bool
 	ModalContent       string
@@ -32,6 +28,12 @@ type MainContentConfig struct {
 	// View dependencies
 	MatrixConfig MatrixConfig
 	Agent        *RunningAgent
+	History      HistoryConfig
+	Queue        QueueConfig
+	TicketDetail TicketDetailConfig
+	AgentPrompt  AgentPromptConfig
+	TicketForm   TicketFormConfig
+	Confirm      ConfirmConfig
 	Filepicker   filepicker.Model
 	AnimState    AnimationState
 }
@@ -66,9 +68,38 @@ func RenderMainContent(cfg MainContentConfig) string {
 	case ViewStateMatrix:
 		s = RenderMatrix(cfg.MatrixConfig)
 	case ViewStateConfirm:
-		s = confirmView(cfg.Selection, cfg.Renderer, cfg.DryRun, cfg.SelectedWorktree, cfg.CurrentTheme)
+		confirm := cfg.Confirm
+		confirm.Theme = cfg.CurrentTheme
+		s = confirmView(confirm)
 	case ViewStateError:
 		s = renderErrorState(cfg)
+	case ViewStateHistory:
+		history := cfg.History
+		history.Width = cfg.Width
+		history.Height = cfg.Height
+		history.Theme = cfg.CurrentTheme
+		s = RenderHistory(history)
+	case ViewStateQueue:
+		queue := cfg.Queue
+		queue.Width = cfg.Width
+		queue.Height = cfg.Height
+		queue.Theme = cfg.CurrentTheme
+		s = RenderQueue(queue)
+	case ViewStateTicketDetail:
+		detail := cfg.TicketDetail
+		detail.Width = cfg.Width
+		detail.Theme = cfg.CurrentTheme
+		s = RenderTicketDetail(detail)
+	case ViewStateAgentPrompt:
+		prompt := cfg.AgentPrompt
+		prompt.Width = cfg.Width
+		prompt.Theme = cfg.CurrentTheme
+		s = RenderAgentPrompt(prompt)
+	case ViewStateTicketForm:
+		form := cfg.TicketForm
+		form.Width = cfg.Width
+		form.Theme = cfg.CurrentTheme
+		s = RenderTicketForm(form)
 	}
 
 	// Overlay modals on top
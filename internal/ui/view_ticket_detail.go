@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TicketDetailConfig holds configuration for rendering the ticket detail view
+type TicketDetailConfig struct {
+	TicketID    string
+	Lines       []string // visible rendered lines
+	Position    string   // "first-last/total", empty while loading
+	Search      string   // status of the active search
+	SearchInput string   // rendered search input while typing a query
+	Width       int
+	Theme       ThemePalette
+}
+
+// RenderTicketDetail renders the full-screen ticket detail view: the
+// visible part of the rendered ticket between a header and a footer.
+func RenderTicketDetail(cfg TicketDetailConfig) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Underline(true)
+	faint := lipgloss.NewStyle().Faint(true)
+
+	header := headerStyle.Render("Ticket " + cfg.TicketID)
+	if cfg.Position != "" {
+		header += "  " + faint.Render(cfg.Position)
+	}
+
+	status := cfg.SearchInput
+	if status == "" {
+		status = lipgloss.NewStyle().Foreground(cfg.Theme.FocusIndicator).Render(cfg.Search)
+	}
+	footer := faint.Render("[↑/↓ scroll • ctrl+d/u page • g/G top/bottom • / search • n/N next/prev • r reload • esc back]")
+	if cfg.SearchInput != "" {
+		footer = faint.Render("[enter search • esc cancel]")
+	}
+
+	lines := make([]string, 0, len(cfg.Lines)+4)
+	lines = append(lines, header, "")
+	for _, line := range cfg.Lines {
+		lines = append(lines, truncateHistoryLine(line, cfg.Width))
+	}
+	lines = append(lines, status, footer)
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/domain"
+)
+
+func sampleTicketDetail() *domain.TicketDetail {
+	return &domain.TicketDetail{
+		Ticket: domain.Ticket{
+			ID: "bb-7", Title: "Crash on start", Status: "open", Priority: 1, IssueType: "bug", Assignee: "alice",
+			Description: "The app crashes when the config is empty.",
+		},
+		Design:             "Validate the config before use.",
+		AcceptanceCriteria: "- No crash on an empty config",
+		Labels:             []string{"startup"},
+		Dependencies:       []domain.TicketDependency{{ID: "bb-3", Title: "Config loader", Status: "closed", Type: "blocks"}},
+		Comments:           []domain.TicketComment{{Author: "bob", Text: "Also crashes on a missing config.", CreatedAt: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)}},
+	}
+}
+
+func TestTicketDetailMarkdown_Sections(t *testing.T) {
+	md := ticketDetailMarkdown(sampleTicketDetail())
+	for _, want := range []string{
+		"# bb-7: Crash on start", "**bug** · P1 · open · @alice", "`startup`",
+		"## Design", "## Acceptance Criteria", "## Depends On", "- **bb-3** Config loader (closed, blocks)",
+		"## Comments (1)", "**bob**",
+	} {
+		assert.Contains(t, md, want)
+	}
+	assert.NotContains(t, md, "## Notes", "empty sections should be left out")
+}
+
+func TestTicketDetailPane_ScrollAndSearch(t *testing.T) {
+	p := newTicketDetailPane("bb-7")
+	p.setDetail(sampleTicketDetail(), nil)
+	p.layout(60)
+	require.NotEmpty(t, p.lines)
+
+	p.scroll(-5, 4)
+	assert.Equal(t, 0, p.offset)
+	p.scroll(len(p.lines), 4)
+	assert.Equal(t, len(p.lines)-4, p.offset, "scrolling should stop at the last full page")
+
+	p.setQuery("CRASH")
+	assert.Len(t, p.matches, 4, "title, description, acceptance criteria and comment mention crashes")
+	p.offset = 0
+	p.firstMatchFromOffset(4)
+	assert.Contains(t, strings.ToLower(ansi.Strip(p.lines[p.matches[p.match]])), "crash")
+	p.jumpToMatch(-1, 4)
+	assert.Equal(t, 3, p.match, "previous match should wrap around")
+	assert.Contains(t, p.searchStatus(), "match 4/4")
+
+	visible := p.view(len(p.lines), true, MatrixTheme)
+	assert.Contains(t, ansi.Strip(strings.Join(visible, "\n")), "▶ ")
+
+	p.setQuery("nothing like this")
+	assert.Empty(t, p.matches)
+	assert.Contains(t, p.searchStatus(), "no matches")
+}
+
+func TestRenderTicketDetail_States(t *testing.T) {
+	loading := newTicketDetailPane("bb-7")
+	assert.Contains(t, strings.Join(loading.view(10, false, MatrixTheme), "\n"), "Loading bb-7")
+
+	failed := newTicketDetailPane("bb-7")
+	failed.setDetail(nil, errors.New("boom"))
+	assert.Contains(t, strings.Join(failed.view(10, false, MatrixTheme), "\n"), "boom")
+
+	s := RenderTicketDetail(TicketDetailConfig{TicketID: "bb-7", Lines: []string{"body"}, Position: "1-1/1", Width: 80, Theme: MatrixTheme})
+	assert.Contains(t, s, "Ticket bb-7")
+	assert.Contains(t, s, "1-1/1")
+	assert.Contains(t, s, "/ search")
+}
+
+func newTicketDetailTestModel(t *testing.T) UIModel {
+	t.Helper()
+	m := NewUIModel(newTestApp(), nil)
+	m.state = ViewStateMatrix
+	m.focus = FocusTickets
+	m.ticketList.SetItems([]list.Item{ticketItem{ticket: sampleTicketDetail().Ticket}})
+	m.layout = m.computeLayout(140, 40)
+	m.updateSizes()
+	return m
+}
+
+func TestTicketDetailKeys_OpenSearchAndClose(t *testing.T) {
+	m := newTicketDetailTestModel(t)
+
+	newModel, cmd, handled := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	assert.True(t, handled)
+	require.NotNil(t, cmd, "opening the detail view should load the ticket")
+	m = newModel.(UIModel)
+	assert.Equal(t, ViewStateTicketDetail, m.state)
+
+	msg, ok := cmd().(ticketDetailLoadedMsg)
+	require.True(t, ok)
+	assert.Equal(t, "bb-7", msg.id)
+	newModel, _ = m.Update(ticketDetailLoadedMsg{id: "bb-7", detail: sampleTicketDetail()})
+	m = newModel.(UIModel)
+	require.NotEmpty(t, m.detail.lines, "the loaded ticket should be rendered")
+	assert.Contains(t, ansi.Strip(m.View()), "Crash on start")
+
+	newModel, _ = m.Update(ticketDetailLoadedMsg{id: "bb-1", detail: &domain.TicketDetail{}})
+	assert.Equal(t, "bb-7", newModel.(UIModel).detail.detail.ID, "stale loads should be ignored")
+
+	for _, k := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("/")},
+		{Type: tea.KeyRunes, Runes: []rune("config")},
+		{Type: tea.KeyEnter},
+	} {
+		newModel, _, handled = m.handleKeyMsg(k)
+		assert.True(t, handled)
+		m = newModel.(UIModel)
+	}
+	assert.Equal(t, "config", m.detail.query)
+	assert.NotEmpty(t, m.detail.matches)
+
+	newModel, _, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	m = newModel.(UIModel)
+	assert.Equal(t, ViewStateTicketDetail, m.state, "esc should clear the search first")
+	assert.Empty(t, m.detail.query)
+
+	newModel, _, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m = newModel.(UIModel)
+	assert.Equal(t, ViewStateMatrix, m.state, "q should close the view, not quit")
+}
+
+func TestTicketDetailKeys_DockInZoomMode(t *testing.T) {
+	m := newTicketDetailTestModel(t)
+	newModel, _, _ := m.handleZoomKeyMsg()
+	m = newModel.(UIModel)
+	ticketWidth := m.layout.TWidth
+
+	newModel, cmd, handled := m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	assert.True(t, handled)
+	require.NotNil(t, cmd)
+	m = newModel.(UIModel)
+	assert.Equal(t, ViewStateMatrix, m.state)
+	assert.True(t, m.detailDocked)
+	assert.Positive(t, m.layout.DWidth)
+	assert.Equal(t, ticketWidth, m.layout.TWidth+m.layout.DWidth+2)
+
+	newModel, _ = m.Update(cmd())
+	m = newModel.(UIModel)
+	assert.Contains(t, ansi.Strip(RenderMatrix(m.buildMatrixConfig())), "Detail")
+
+	newModel, _, _ = m.handleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	m = newModel.(UIModel)
+	assert.False(t, m.detailDocked)
+	assert.Equal(t, ticketWidth, m.layout.TWidth)
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TicketFormRow is one field of the ticket form.
+type TicketFormRow struct {
+	Label   string
+	Value   string // rendered input or choice
+	Focused bool
+}
+
+// TicketFormConfig holds configuration for rendering the ticket form
+type TicketFormConfig struct {
+	Title  string
+	Rows   []TicketFormRow
+	Err    error
+	Saving bool
+	Width  int
+	Theme  ThemePalette
+}
+
+// RenderTicketForm renders the form for creating or editing a ticket.
+func RenderTicketForm(cfg TicketFormConfig) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Underline(true)
+	faint := lipgloss.NewStyle().Faint(true)
+	focused := lipgloss.NewStyle().Foreground(cfg.Theme.FocusIndicator).Bold(true)
+
+	labelWidth := 0
+	for _, row := range cfg.Rows {
+		labelWidth = max(labelWidth, len(row.Label))
+	}
+
+	lines := []string{headerStyle.Render(cfg.Title), ""}
+	for _, row := range cfg.Rows {
+		label := fmt.Sprintf("  %-*s  ", labelWidth, row.Label)
+		if row.Focused {
+			label = focused.Render(fmt.Sprintf("▶ %-*s  ", labelWidth, row.Label))
+		}
+		lines = append(lines, truncateHistoryLine(label+row.Value, cfg.Width))
+	}
+	lines = append(lines, "")
+
+	switch {
+	case cfg.Saving:
+		lines = append(lines, "Saving...")
+	case cfg.Err != nil:
+		lines = append(lines, lipgloss.NewStyle().Foreground(ThemeWarning).Render(cfg.Err.Error()))
+	default:
+		lines = append(lines, "")
+	}
+	lines = append(lines, faint.Render("[tab/↑/↓ field • ←/→ choose • enter next • ctrl+s save • esc cancel]"))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/megatherium/blunderbust/internal/data"
+	"github.com/megatherium/blunderbust/internal/data/fake"
+)
+
+// readOnlyStore hides the fake store's write methods.
+type readOnlyStore struct{ data.TicketStore }
+
+func newTicketFormTestModel(t *testing.T, store data.TicketStore) UIModel {
+	t.Helper()
+	myApp := newTestApp()
+	myApp.AddStore("/test/project", store)
+	myApp.ActiveProject = "/test/project"
+	m := NewUIModel(myApp, nil)
+	m.state = ViewStateMatrix
+	m.focus = FocusTickets
+	tickets, _ := store.ListTickets(t.Context(), data.TicketFilter{})
+	items := make([]list.Item, len(tickets))
+	for i, ticket := range tickets {
+		items[i] = ticketItem{ticket: ticket}
+	}
+	m.ticketList.SetItems(items)
+	m.layout = m.computeLayout(140, 40)
+	m.updateSizes()
+	return m
+}
+
+func pressKeys(t *testing.T, m UIModel, keys ...tea.KeyMsg) (UIModel, tea.Cmd) {
+	t.Helper()
+	var cmd tea.Cmd
+	for _, k := range keys {
+		newModel, c, handled := m.handleKeyMsg(k)
+		require.True(t, handled, "key %q should be handled", k.String())
+		m, cmd = newModel.(UIModel), c
+	}
+	return m, cmd
+}
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestTicketForm_CreateTicket(t *testing.T) {
+	store := fake.NewWithSampleData()
+	m := newTicketFormTestModel(t, store)
+
+	m, _ = pressKeys(t, m, runes("n"))
+	require.Equal(t, ViewStateTicketForm, m.state)
+	assert.Contains(t, ansi.Strip(m.View()), "New ticket")
+
+	m, cmd := pressKeys(t, m, tea.KeyMsg{Type: tea.KeyCtrlS})
+	assert.Nil(t, cmd)
+	assert.EqualError(t, m.ticketForm.err, "title is required")
+
+	m, cmd = pressKeys(t, m,
+		runes("Add retries"),
+		tea.KeyMsg{Type: tea.KeyTab},
+		tea.KeyMsg{Type: tea.KeyRight}, // task → bug
+		tea.KeyMsg{Type: tea.KeyTab},
+		tea.KeyMsg{Type: tea.KeyLeft}, // P2 → P1
+		tea.KeyMsg{Type: tea.KeyTab},
+		tea.KeyMsg{Type: tea.KeyTab},
+		runes("bb-004"),
+		tea.KeyMsg{Type: tea.KeyTab},
+		runes("bb-001, bb-002"),
+		tea.KeyMsg{Type: tea.KeyEnter},
+	)
+	require.NotNil(t, cmd, "enter on the last field should submit")
+	assert.True(t, m.ticketForm.saving)
+
+	msg := cmd().(ticketWrittenMsg)
+	require.NoError(t, msg.err)
+	created := store.Tickets[len(store.Tickets)-1]
+	assert.Equal(t, msg.id, created.ID)
+	assert.Equal(t, "Add retries", created.Title)
+	assert.Equal(t, "bug", created.IssueType)
+	assert.Equal(t, 1, created.Priority)
+	assert.Len(t, store.Details[created.ID].Dependencies, 3)
+
+	m.lastTicketUpdate = time.Now()
+	newModel, _ := m.Update(msg)
+	m = newModel.(UIModel)
+	assert.Equal(t, ViewStateMatrix, m.state)
+	assert.True(t, m.lastTicketUpdate.IsZero(), "the next poll should reload the list")
+}
+
+func TestTicketForm_EditTicket(t *testing.T) {
+	store := fake.NewWithSampleData()
+	m := newTicketFormTestModel(t, store)
+	m.ticketList.Select(1)
+	selected := m.ticketList.SelectedItem().(ticketItem).ticket
+	require.Equal(t, "open", selected.Status)
+
+	m, _ = pressKeys(t, m, runes("e"))
+	require.Equal(t, ViewStateTicketForm, m.state)
+	assert.Contains(t, ansi.Strip(m.View()), "Edit "+selected.ID)
+
+	m, cmd := pressKeys(t, m,
+		tea.KeyMsg{Type: tea.KeyDown},
+		tea.KeyMsg{Type: tea.KeyRight}, // open → in_progress
+		tea.KeyMsg{Type: tea.KeyDown},
+		runes("carol"),
+		tea.KeyMsg{Type: tea.KeyEnter},
+	)
+	require.NotNil(t, cmd)
+	u := m.ticketForm.ticketUpdate()
+	assert.Nil(t, u.Priority, "unchanged fields should not be written")
+
+	newModel, _ := m.Update(cmd())
+	m = newModel.(UIModel)
+	assert.Equal(t, ViewStateMatrix, m.state)
+	for _, ticket := range store.Tickets {
+		if ticket.ID == selected.ID {
+			assert.Equal(t, "in_progress", ticket.Status)
+			assert.Equal(t, "carol", ticket.Assignee)
+		}
+	}
+}
+
+func TestTicketForm_EscCancels(t *testing.T) {
+	m := newTicketFormTestModel(t, fake.NewWithSampleData())
+	m, _ = pressKeys(t, m, runes("n"), runes("q"))
+	assert.Equal(t, ViewStateTicketForm, m.state, "q should be typed, not quit")
+	m, cmd := pressKeys(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, cmd)
+	assert.Equal(t, ViewStateMatrix, m.state)
+}
+
+func TestTicketForm_WriteErrorKeepsFormOpen(t *testing.T) {
+	m := newTicketFormTestModel(t, fake.NewWithSampleData())
+	m, _ = pressKeys(t, m, runes("e"))
+	m.ticketForm.saving = true
+
+	newModel, _ := m.Update(ticketWrittenMsg{id: "bb-001", err: errors.New("dolt is down")})
+	m = newModel.(UIModel)
+	assert.Equal(t, ViewStateTicketForm, m.state)
+	assert.False(t, m.ticketForm.saving)
+	assert.Contains(t, ansi.Strip(m.View()), "dolt is down")
+}
+
+func TestTicketEditKeys_ChangePriority(t *testing.T) {
+	store := fake.NewWithSampleData()
+	m := newTicketFormTestModel(t, store)
+	selected := m.ticketList.SelectedItem().(ticketItem).ticket
+
+	_, cmd := pressKeys(t, m, runes("-"))
+	require.NotNil(t, cmd)
+	require.NoError(t, cmd().(ticketWrittenMsg).err)
+	for _, ticket := range store.Tickets {
+		if ticket.ID == selected.ID {
+			assert.Equal(t, selected.Priority+1, ticket.Priority)
+		}
+	}
+}
+
+func TestTicketEditKeys_ReadOnlyStore(t *testing.T) {
+	m := newTicketFormTestModel(t, readOnlyStore{fake.NewWithSampleData()})
+
+	_, cmd := pressKeys(t, m, runes("+"))
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(warningMsg)
+	require.True(t, ok)
+	assert.Contains(t, msg.err.Error(), "read-only")
+
+	m, _ = pressKeys(t, m, runes("n"), runes("Title"), tea.KeyMsg{Type: tea.KeyCtrlS})
+	assert.Equal(t, ViewStateTicketForm, m.state)
+	assert.Contains(t, m.ticketForm.err.Error(), "read-only")
+}